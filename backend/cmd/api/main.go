@@ -12,8 +12,12 @@ import (
 
 	"github.com/feijoa-master/ai-resume-builder/internal/config"
 	"github.com/feijoa-master/ai-resume-builder/internal/database"
+	"github.com/feijoa-master/ai-resume-builder/internal/experiments"
 	"github.com/feijoa-master/ai-resume-builder/internal/handlers"
+	"github.com/feijoa-master/ai-resume-builder/internal/mailer"
 	"github.com/feijoa-master/ai-resume-builder/internal/middleware"
+	"github.com/feijoa-master/ai-resume-builder/internal/pricing"
+	"github.com/feijoa-master/ai-resume-builder/internal/prompts"
 	"github.com/feijoa-master/ai-resume-builder/internal/repository"
 	"github.com/feijoa-master/ai-resume-builder/internal/service"
 	"github.com/feijoa-master/ai-resume-builder/internal/utils"
@@ -52,6 +56,49 @@ func main() {
 	userRepo := repository.NewUserRepository(db.DB)
 	profileRepo := repository.NewProfileRepository(db.DB)
 	documentRepo := repository.NewDocumentRepository(db.DB)
+	applicationRepo := repository.NewApplicationRepository(db.DB)
+	reminderRepo := repository.NewReminderRepository(db.DB)
+	jobPostingRepo := repository.NewJobPostingRepository(db.DB)
+	experimentRepo := repository.NewExperimentRepository(db.DB)
+	feedbackRepo := repository.NewFeedbackRepository(db.DB)
+
+	// Load prompt templates, with overrides from disk if configured
+	promptRegistry, err := prompts.Load(cfg.OpenAI.PromptsDir)
+	if err != nil {
+		log.Fatalf("Failed to load prompts: %v", err)
+	}
+
+	// Load prompt A/B experiments
+	experimentSet, err := experiments.Load(cfg.OpenAI.ExperimentsFile)
+	if err != nil {
+		log.Fatalf("Failed to load experiments: %v", err)
+	}
+	if err := experimentSet.CheckPrompts(promptRegistry); err != nil {
+		log.Fatalf("Failed to load experiments: %v", err)
+	}
+
+	// Load model prices, with additions from disk if configured
+	prices, err := pricing.Load(cfg.OpenAI.PricingFile)
+	if err != nil {
+		log.Fatalf("Failed to load pricing: %v", err)
+	}
+	for _, model := range append([]string{cfg.OpenAI.Model}, cfg.OpenAI.FallbackModels...) {
+		if _, err := prices.Lookup(pricing.OpenAI, model); err != nil {
+			log.Printf("WARNING: %v; its generations are recorded at no cost", err)
+		}
+	}
+
+	// Cache model responses so repeated generations don't pay twice
+	var responseCache service.ResponseCache
+	switch cfg.OpenAI.Cache {
+	case "memory":
+		responseCache = service.NewMemoryCache(service.SystemClock{})
+	case "postgres":
+		responseCache = repository.NewLLMCacheRepository(db.DB)
+	case "off":
+	default:
+		log.Fatalf("Unknown LLM_CACHE %q: use memory, postgres or off", cfg.OpenAI.Cache)
+	}
 
 	// Initialize OpenAI service
 	openaiService := service.NewOpenAIService(
@@ -59,20 +106,42 @@ func main() {
 		cfg.OpenAI.Model,
 		cfg.OpenAI.MaxTokens,
 		cfg.OpenAI.Temperature,
+		promptRegistry,
+		responseCache,
+		cfg.OpenAI.CacheTTL,
+		service.Resilience{
+			MaxRetries:       cfg.OpenAI.MaxRetries,
+			BaseDelay:        cfg.OpenAI.RetryBaseDelay,
+			MaxDelay:         cfg.OpenAI.RetryMaxDelay,
+			FallbackModels:   cfg.OpenAI.FallbackModels,
+			BreakerThreshold: cfg.OpenAI.BreakerThreshold,
+			BreakerCooldown:  cfg.OpenAI.BreakerCooldown,
+		},
 	)
 
 	// Initialize services
 	authService := service.NewAuthService(userRepo, jwtManager)
 	profileService := service.NewProfileService(profileRepo)
-	documentService := service.NewDocumentService(documentRepo, profileRepo, userRepo, openaiService)
+	documentService := service.NewDocumentService(documentRepo, profileRepo, userRepo, jobPostingRepo, experimentRepo, openaiService, experimentSet, prices)
+	applicationService := service.NewApplicationService(applicationRepo, documentRepo)
+	reminderService := service.NewReminderService(reminderRepo, applicationRepo)
+	jobPostingService := service.NewJobPostingService(jobPostingRepo)
+	experimentService := service.NewExperimentService(experimentRepo, documentRepo, experimentSet)
+	feedbackService := service.NewFeedbackService(feedbackRepo, documentRepo, experimentRepo)
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(authService)
 	profileHandler := handlers.NewProfileHandler(profileService)
 	documentHandler := handlers.NewDocumentHandler(documentService)
+	applicationHandler := handlers.NewApplicationHandler(applicationService)
+	reminderHandler := handlers.NewReminderHandler(reminderService)
+	jobPostingHandler := handlers.NewJobPostingHandler(jobPostingService)
+	experimentHandler := handlers.NewExperimentHandler(experimentService)
+	feedbackHandler := handlers.NewFeedbackHandler(feedbackService)
 
 	// Create router
 	router := mux.NewRouter()
+	router.Use(middleware.Localize)
 
 	// API routes
 	api := router.PathPrefix("/api/v1").Subrouter()
@@ -93,42 +162,95 @@ func main() {
 	// User endpoints
 	protected.HandleFunc("/user/me", getMeHandler(authService)).Methods("GET")
 
-	// Profile endpoints
-	protected.HandleFunc("/profile", profileHandler.GetProfile).Methods("GET")
-	protected.HandleFunc("/profile", profileHandler.UpdateProfile).Methods("PUT")
-
-	// Experience endpoints
-	protected.HandleFunc("/profile/experience", profileHandler.CreateExperience).Methods("POST")
-	protected.HandleFunc("/profile/experience", profileHandler.GetExperiences).Methods("GET")
-	protected.HandleFunc("/profile/experience/{id}", profileHandler.UpdateExperience).Methods("PUT")
-	protected.HandleFunc("/profile/experience/{id}", profileHandler.DeleteExperience).Methods("DELETE")
-
-	// Education endpoints
-	protected.HandleFunc("/profile/education", profileHandler.CreateEducation).Methods("POST")
-	protected.HandleFunc("/profile/education", profileHandler.GetEducation).Methods("GET")
-	protected.HandleFunc("/profile/education/{id}", profileHandler.UpdateEducation).Methods("PUT")
-	protected.HandleFunc("/profile/education/{id}", profileHandler.DeleteEducation).Methods("DELETE")
+	// Profile endpoints. The /profile ones edit the default profile, the
+	// /profiles/{profile_id} ones any of the user's profiles.
+	profileRoutes(protected, "/profile", profileHandler)
+	profileRoutes(protected, "/profiles/{profile_id}", profileHandler)
+	protected.HandleFunc("/skills/suggest", profileHandler.SuggestSkills).Methods("GET")
 
-	// Skills endpoints
-	protected.HandleFunc("/profile/skills", profileHandler.CreateSkill).Methods("POST")
-	protected.HandleFunc("/profile/skills", profileHandler.GetSkills).Methods("GET")
-	protected.HandleFunc("/profile/skills/{id}", profileHandler.DeleteSkill).Methods("DELETE")
+	// Named profile endpoints
+	protected.HandleFunc("/profiles", profileHandler.ListProfiles).Methods("GET")
+	protected.HandleFunc("/profiles", profileHandler.CreateProfile).Methods("POST")
+	protected.HandleFunc("/profiles/{id}", profileHandler.RenameProfile).Methods("PATCH")
+	protected.HandleFunc("/profiles/{id}", profileHandler.DeleteProfile).Methods("DELETE")
+	protected.HandleFunc("/profiles/{id}/copy", profileHandler.CopyProfile).Methods("POST")
+	protected.HandleFunc("/profiles/{id}/default", profileHandler.SetDefaultProfile).Methods("PUT")
 
 	// Document generation endpoints
 	protected.HandleFunc("/generate/resume", documentHandler.GenerateResume).Methods("POST")
 	protected.HandleFunc("/generate/cover-letter", documentHandler.GenerateCoverLetter).Methods("POST")
 
+	// Job description analysis endpoints
+	protected.HandleFunc("/jobs/analyze", documentHandler.AnalyzeJob).Methods("POST")
+	protected.HandleFunc("/jobs/skill-gap", documentHandler.SkillGap).Methods("POST")
+
 	// Document management endpoints
 	protected.HandleFunc("/documents", documentHandler.GetDocuments).Methods("GET")
 	protected.HandleFunc("/documents/{id}", documentHandler.GetDocument).Methods("GET")
 	protected.HandleFunc("/documents/{id}", documentHandler.UpdateDocument).Methods("PUT")
 	protected.HandleFunc("/documents/{id}", documentHandler.DeleteDocument).Methods("DELETE")
 
+	// ATS scoring endpoint
+	protected.HandleFunc("/documents/{id}/ats-score", documentHandler.ScoreDocument).Methods("POST")
+
+	// Section regeneration endpoint
+	protected.HandleFunc("/documents/{id}/regenerate", documentHandler.RegenerateSection).Methods("POST")
+	protected.HandleFunc("/documents/{id}/translate", documentHandler.TranslateDocument).Methods("POST")
+	protected.HandleFunc("/conventions", documentHandler.ListConventions).Methods("GET")
+
+	// Document refinement chat endpoints
+	protected.HandleFunc("/documents/{id}/messages", documentHandler.GetDocumentMessages).Methods("GET")
+	protected.HandleFunc("/documents/{id}/messages", documentHandler.RefineDocument).Methods("POST")
+
+	// Document version history endpoints
+	protected.HandleFunc("/documents/{id}/versions", documentHandler.GetDocumentVersions).Methods("GET")
+	protected.HandleFunc("/documents/{id}/versions/diff", documentHandler.DiffDocumentVersions).Methods("GET")
+	protected.HandleFunc("/documents/{id}/versions/{version:[0-9]+}", documentHandler.GetDocumentVersion).Methods("GET")
+	protected.HandleFunc("/documents/{id}/versions/{version:[0-9]+}/restore", documentHandler.RestoreDocumentVersion).Methods("POST")
+
+	// Document signals for experiment reports
+	protected.HandleFunc("/documents/{id}/signals", experimentHandler.RecordSignal).Methods("POST")
+
+	// Document feedback endpoints
+	protected.HandleFunc("/documents/{id}/feedback", feedbackHandler.GetDocumentFeedback).Methods("GET")
+	protected.HandleFunc("/documents/{id}/feedback", feedbackHandler.SaveFeedback).Methods("PUT")
+	protected.HandleFunc("/documents/{id}/feedback", feedbackHandler.DeleteFeedback).Methods("DELETE")
+
+	// Application tracker endpoints
+	protected.HandleFunc("/applications", applicationHandler.GetApplications).Methods("GET")
+	protected.HandleFunc("/applications", applicationHandler.CreateApplication).Methods("POST")
+	protected.HandleFunc("/applications/{id}", applicationHandler.GetApplication).Methods("GET")
+	protected.HandleFunc("/applications/{id}", applicationHandler.UpdateApplication).Methods("PUT")
+	protected.HandleFunc("/applications/{id}", applicationHandler.DeleteApplication).Methods("DELETE")
+	protected.HandleFunc("/applications/{id}/status", applicationHandler.UpdateApplicationStatus).Methods("PATCH")
+	protected.HandleFunc("/applications/{id}/history", applicationHandler.GetApplicationHistory).Methods("GET")
+	protected.HandleFunc("/applications/{id}/follow-up-rules", reminderHandler.GetFollowUpRules).Methods("GET")
+	protected.HandleFunc("/applications/{id}/follow-up-rules", reminderHandler.ReplaceFollowUpRules).Methods("PUT")
+
+	// Reminder endpoints
+	protected.HandleFunc("/reminders", reminderHandler.GetReminders).Methods("GET")
+	protected.HandleFunc("/reminders/{id}/dismiss", reminderHandler.DismissReminder).Methods("POST")
+
+	// Saved job posting endpoints
+	protected.HandleFunc("/job-postings", jobPostingHandler.GetJobPostings).Methods("GET")
+	protected.HandleFunc("/job-postings", jobPostingHandler.CreateJobPosting).Methods("POST")
+	protected.HandleFunc("/job-postings/parse", jobPostingHandler.ParseJobPosting).Methods("POST")
+	protected.HandleFunc("/job-postings/{id}", jobPostingHandler.GetJobPosting).Methods("GET")
+	protected.HandleFunc("/job-postings/{id}", jobPostingHandler.UpdateJobPosting).Methods("PUT")
+	protected.HandleFunc("/job-postings/{id}", jobPostingHandler.DeleteJobPosting).Methods("DELETE")
+
+	// Admin routes (require an ADMIN_EMAILS account)
+	admin := protected.PathPrefix("/admin").Subrouter()
+	admin.Use(middleware.RequireAdmin(cfg.Admin.Emails))
+	admin.HandleFunc("/experiments", experimentHandler.ListExperiments).Methods("GET")
+	admin.HandleFunc("/experiments/{name}/report", experimentHandler.GetExperimentReport).Methods("GET")
+	admin.HandleFunc("/feedback", feedbackHandler.GetFeedbackSummaries).Methods("GET")
+
 	// CORS configuration
 	corsHandler := cors.New(cors.Options{
 		AllowedOrigins:   cfg.Server.AllowedOrigins,
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Authorization", "Content-Type"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Authorization", "Content-Type", "Accept-Language"},
 		AllowCredentials: true,
 	}).Handler(router)
 
@@ -149,12 +271,36 @@ func main() {
 		}
 	}()
 
+	// Start the follow-up reminder scheduler
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	if cfg.Reminder.Enabled {
+		channels := []service.Channel{{Name: "in_app", Notifier: service.InAppNotifier{}}}
+		if cfg.SMTP.Host != "" {
+			channels = append(channels, service.Channel{Name: "email", Notifier: service.NewEmailNotifier(mailer.NewSMTPMailer(mailer.Config{
+				Host:     cfg.SMTP.Host,
+				Port:     cfg.SMTP.Port,
+				Username: cfg.SMTP.Username,
+				Password: cfg.SMTP.Password,
+				From:     cfg.SMTP.From,
+			}))})
+		}
+		if cfg.Reminder.WebhookURL != "" {
+			channels = append(channels, service.Channel{Name: "webhook", Notifier: service.NewWebhookNotifier(cfg.Reminder.WebhookURL)})
+		}
+		notifier := service.NewMultiNotifier(reminderRepo, channels...)
+		scheduler := service.NewReminderScheduler(reminderRepo, userRepo, notifier, service.SystemClock{}, cfg.Reminder.Interval)
+		scheduler.Start(schedulerCtx)
+		log.Printf("⏰ Reminder scheduler running every %s", cfg.Reminder.Interval)
+	}
+
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	log.Println("🛑 Shutting down server...")
+	stopScheduler()
 
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
@@ -202,3 +348,57 @@ func getMeHandler(authService *service.AuthService) http.HandlerFunc {
 		json.NewEncoder(w).Encode(user)
 	}
 }
+
+// profileRoutes registers the endpoints editing a profile and its sections
+// under prefix
+func profileRoutes(router *mux.Router, prefix string, profileHandler *handlers.ProfileHandler) {
+	router.HandleFunc(prefix, profileHandler.GetProfile).Methods("GET")
+	router.HandleFunc(prefix, profileHandler.UpdateProfile).Methods("PUT")
+
+	// Experience endpoints
+	router.HandleFunc(prefix+"/experience", profileHandler.CreateExperience).Methods("POST")
+	router.HandleFunc(prefix+"/experience", profileHandler.GetExperiences).Methods("GET")
+	router.HandleFunc(prefix+"/experience/order", profileHandler.ReorderExperiences).Methods("PATCH")
+	router.HandleFunc(prefix+"/experience/{id}", profileHandler.UpdateExperience).Methods("PUT")
+	router.HandleFunc(prefix+"/experience/{id}", profileHandler.DeleteExperience).Methods("DELETE")
+
+	// Education endpoints
+	router.HandleFunc(prefix+"/education", profileHandler.CreateEducation).Methods("POST")
+	router.HandleFunc(prefix+"/education", profileHandler.GetEducation).Methods("GET")
+	router.HandleFunc(prefix+"/education/order", profileHandler.ReorderEducation).Methods("PATCH")
+	router.HandleFunc(prefix+"/education/{id}", profileHandler.UpdateEducation).Methods("PUT")
+	router.HandleFunc(prefix+"/education/{id}", profileHandler.DeleteEducation).Methods("DELETE")
+
+	// Skills endpoints
+	router.HandleFunc(prefix+"/skills", profileHandler.CreateSkill).Methods("POST")
+	router.HandleFunc(prefix+"/skills", profileHandler.GetSkills).Methods("GET")
+	router.HandleFunc(prefix+"/skills", profileHandler.ReplaceSkills).Methods("PUT")
+	router.HandleFunc(prefix+"/skills/{id}", profileHandler.UpdateSkill).Methods("PUT")
+	router.HandleFunc(prefix+"/skills/{id}", profileHandler.DeleteSkill).Methods("DELETE")
+
+	// Optional profile section endpoints
+	router.HandleFunc(prefix+"/projects", profileHandler.CreateProject).Methods("POST")
+	router.HandleFunc(prefix+"/projects", profileHandler.GetProjects).Methods("GET")
+	router.HandleFunc(prefix+"/projects/{id}", profileHandler.UpdateProject).Methods("PUT")
+	router.HandleFunc(prefix+"/projects/{id}", profileHandler.DeleteProject).Methods("DELETE")
+	router.HandleFunc(prefix+"/certifications", profileHandler.CreateCertification).Methods("POST")
+	router.HandleFunc(prefix+"/certifications", profileHandler.GetCertifications).Methods("GET")
+	router.HandleFunc(prefix+"/certifications/{id}", profileHandler.UpdateCertification).Methods("PUT")
+	router.HandleFunc(prefix+"/certifications/{id}", profileHandler.DeleteCertification).Methods("DELETE")
+	router.HandleFunc(prefix+"/publications", profileHandler.CreatePublication).Methods("POST")
+	router.HandleFunc(prefix+"/publications", profileHandler.GetPublications).Methods("GET")
+	router.HandleFunc(prefix+"/publications/{id}", profileHandler.UpdatePublication).Methods("PUT")
+	router.HandleFunc(prefix+"/publications/{id}", profileHandler.DeletePublication).Methods("DELETE")
+	router.HandleFunc(prefix+"/languages", profileHandler.CreateLanguage).Methods("POST")
+	router.HandleFunc(prefix+"/languages", profileHandler.GetLanguages).Methods("GET")
+	router.HandleFunc(prefix+"/languages/{id}", profileHandler.UpdateLanguage).Methods("PUT")
+	router.HandleFunc(prefix+"/languages/{id}", profileHandler.DeleteLanguage).Methods("DELETE")
+	router.HandleFunc(prefix+"/volunteering", profileHandler.CreateVolunteering).Methods("POST")
+	router.HandleFunc(prefix+"/volunteering", profileHandler.GetVolunteering).Methods("GET")
+	router.HandleFunc(prefix+"/volunteering/{id}", profileHandler.UpdateVolunteering).Methods("PUT")
+	router.HandleFunc(prefix+"/volunteering/{id}", profileHandler.DeleteVolunteering).Methods("DELETE")
+	router.HandleFunc(prefix+"/awards", profileHandler.CreateAward).Methods("POST")
+	router.HandleFunc(prefix+"/awards", profileHandler.GetAwards).Methods("GET")
+	router.HandleFunc(prefix+"/awards/{id}", profileHandler.UpdateAward).Methods("PUT")
+	router.HandleFunc(prefix+"/awards/{id}", profileHandler.DeleteAward).Methods("DELETE")
+}
@@ -0,0 +1,58 @@
+// Command merge-skills normalises stored skills against the bundled taxonomy
+// and merges duplicates within each profile, such as "javascript", "JS" and
+// "Javascript". It only reports what it would change unless -apply is given.
+package main
+
+import (
+	"flag"
+	"log"
+	"strings"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/config"
+	"github.com/feijoa-master/ai-resume-builder/internal/database"
+	"github.com/feijoa-master/ai-resume-builder/internal/repository"
+	"github.com/feijoa-master/ai-resume-builder/internal/service"
+)
+
+func main() {
+	apply := flag.Bool("apply", false, "write the changes instead of only reporting them")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	db, err := database.Connect(database.Config{
+		URL:             cfg.Database.URL,
+		MaxOpenConns:    cfg.Database.MaxOpenConns,
+		MaxIdleConns:    cfg.Database.MaxIdleConns,
+		ConnMaxLifetime: cfg.Database.ConnMaxLifetime,
+	})
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	profileService := service.NewProfileService(repository.NewProfileRepository(db.DB))
+
+	merges, err := profileService.MergeDuplicateSkills(*apply)
+	for _, merge := range merges {
+		names := make([]string, len(merge.Duplicates))
+		for i, duplicate := range merge.Duplicates {
+			names[i] = duplicate.Name
+		}
+		log.Printf("profile %s: %q -> %q (%s), removing %d duplicate(s) [%s]",
+			merge.Keep.ProfileID, merge.OldName, merge.Keep.Name, merge.Keep.Category,
+			len(merge.Duplicates), strings.Join(names, ", "))
+	}
+	if err != nil {
+		log.Fatalf("Failed to merge skills: %v", err)
+	}
+
+	if !*apply {
+		log.Printf("%d skill(s) would change; run with -apply to write them", len(merges))
+		return
+	}
+	log.Printf("%d skill(s) changed", len(merges))
+}
@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -14,6 +15,9 @@ type Config struct {
 	Database DatabaseConfig
 	JWT      JWTConfig
 	OpenAI   OpenAIConfig
+	Reminder ReminderConfig
+	SMTP     SMTPConfig
+	Admin    AdminConfig
 }
 
 type ServerConfig struct {
@@ -38,10 +42,43 @@ type JWTConfig struct {
 }
 
 type OpenAIConfig struct {
-	APIKey      string
-	Model       string
-	MaxTokens   int
-	Temperature float64
+	APIKey          string
+	Model           string
+	MaxTokens       int
+	Temperature     float64
+	PromptsDir      string // directory of prompt files overriding the embedded ones
+	ExperimentsFile string // JSON file of prompt A/B experiments
+	PricingFile     string // JSON file of model prices adding to the built-in ones
+	Cache           string // response cache: memory, postgres or off
+	CacheTTL        time.Duration
+	FallbackModels  []string // models tried in order when the model keeps failing
+	MaxRetries      int      // retries of a model on rate limits and server errors
+	RetryBaseDelay  time.Duration
+	RetryMaxDelay   time.Duration
+	// The circuit breaker of a model opens after BreakerThreshold consecutive
+	// failures and fails fast for BreakerCooldown
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+type ReminderConfig struct {
+	Enabled    bool
+	Interval   time.Duration
+	WebhookURL string
+}
+
+// AdminConfig lists the users allowed on admin endpoints
+type AdminConfig struct {
+	Emails []string
+}
+
+// SMTPConfig is the mail server for email reminders; email is off without a host
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
 }
 
 func Load() (*Config, error) {
@@ -68,13 +105,46 @@ func Load() (*Config, error) {
 			RefreshTokenExpiry: time.Hour * 24 * 7, // 7 days
 		},
 		OpenAI: OpenAIConfig{
-			APIKey:      getEnv("OPENAI_API_KEY", ""),
-			Model:       getEnv("OPENAI_MODEL", "gpt-4o-mini"),
-			MaxTokens:   getEnvAsInt("OPENAI_MAX_TOKENS", 1500),
-			Temperature: getEnvAsFloat("OPENAI_TEMPERATURE", 0.7),
+			APIKey:           getEnv("OPENAI_API_KEY", ""),
+			Model:            getEnv("OPENAI_MODEL", "gpt-4o-mini"),
+			MaxTokens:        getEnvAsInt("OPENAI_MAX_TOKENS", 1500),
+			Temperature:      getEnvAsFloat("OPENAI_TEMPERATURE", 0.7),
+			PromptsDir:       getEnv("PROMPTS_DIR", ""),
+			ExperimentsFile:  getEnv("EXPERIMENTS_FILE", ""),
+			PricingFile:      getEnv("PRICING_FILE", ""),
+			Cache:            getEnv("LLM_CACHE", "memory"),
+			CacheTTL:         time.Minute * time.Duration(getEnvAsInt("LLM_CACHE_TTL_MINUTES", 24*60)),
+			FallbackModels:   getEnvAsList("OPENAI_FALLBACK_MODELS"),
+			MaxRetries:       getEnvAsInt("OPENAI_MAX_RETRIES", 2),
+			RetryBaseDelay:   time.Millisecond * time.Duration(getEnvAsInt("OPENAI_RETRY_BASE_DELAY_MS", 500)),
+			RetryMaxDelay:    time.Millisecond * time.Duration(getEnvAsInt("OPENAI_RETRY_MAX_DELAY_MS", 10000)),
+			BreakerThreshold: getEnvAsInt("OPENAI_BREAKER_THRESHOLD", 5),
+			BreakerCooldown:  time.Second * time.Duration(getEnvAsInt("OPENAI_BREAKER_COOLDOWN_SECONDS", 30)),
+		},
+		Reminder: ReminderConfig{
+			Enabled:    getEnvAsBool("REMINDERS_ENABLED", true),
+			Interval:   time.Minute * time.Duration(getEnvAsInt("REMINDER_INTERVAL_MINUTES", 15)),
+			WebhookURL: getEnv("REMINDER_WEBHOOK_URL", ""),
+		},
+		SMTP: SMTPConfig{
+			Host:     getEnv("SMTP_HOST", ""),
+			Port:     getEnv("SMTP_PORT", "587"),
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+			From:     getEnv("SMTP_FROM", ""),
+		},
+		Admin: AdminConfig{
+			Emails: getEnvAsList("ADMIN_EMAILS"),
 		},
 	}
 
+	if cfg.Reminder.Interval <= 0 {
+		cfg.Reminder.Interval = time.Minute * 15
+	}
+	if cfg.OpenAI.CacheTTL <= 0 {
+		cfg.OpenAI.CacheTTL = time.Hour * 24
+	}
+
 	// Validate required fields
 	if cfg.OpenAI.APIKey == "" {
 		log.Println("WARNING: OPENAI_API_KEY is not set")
@@ -105,3 +175,22 @@ func getEnvAsFloat(key string, defaultValue float64) float64 {
 	}
 	return defaultValue
 }
+
+// getEnvAsList splits a comma-separated variable, dropping empty items
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, ""), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := getEnv(key, "")
+	if value, err := strconv.ParseBool(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
+}
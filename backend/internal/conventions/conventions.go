@@ -0,0 +1,195 @@
+// Package conventions describes how resumes are written in different
+// regions: which personal details they carry, how long they are, how dates
+// are written, the order of entries and the tone. A convention steers the
+// prompt, shapes the generated content and tells renderers how to lay the
+// document out.
+package conventions
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/i18n"
+)
+
+// Default is the convention of documents that don't choose one
+const Default = "us"
+
+var ErrUnknownConvention = errors.New("unknown convention: use one of us, eu, de, jp")
+
+// Convention is one regional style of resume
+type Convention struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	MaxPages    int    `json:"max_pages"`
+
+	// Personal details the document shows, when the profile has them
+	Photo       bool `json:"photo"`
+	DateOfBirth bool `json:"date_of_birth"`
+	Nationality bool `json:"nationality"`
+
+	// Chronological lists experience and education oldest first instead of
+	// most recent first
+	Chronological bool `json:"chronological"`
+
+	// Sections is the order renderers lay the resume sections out in
+	Sections []string `json:"sections"`
+
+	// DateFormat shows how dates are written, e.g. "01/2020"
+	DateFormat string `json:"date_format"`
+	Tone       string `json:"tone"`
+
+	// guidance is added to the prompt
+	guidance []string
+
+	// monthLayout and dateLayout are time layouts for month-year dates and
+	// full dates; an empty monthLayout means month names in the document's
+	// language
+	monthLayout string
+	dateLayout  string
+}
+
+var conventions = []*Convention{
+	{
+		ID:          "us",
+		Name:        "US résumé",
+		Description: "One page, achievement-focused, no personal details",
+		MaxPages:    1,
+		Sections:    []string{"summary", "experience", "education", "skills"},
+		DateFormat:  "Jan 2020",
+		Tone:        "confident and achievement-focused",
+		guidance: []string{
+			"Keep it to one page; two only for candidates with over ten years of experience",
+			"Leave out photo, date of birth, nationality, marital status and other personal details",
+			"Start bullet points with action verbs and quantify results",
+		},
+		dateLayout: "01/02/2006",
+	},
+	{
+		ID:          "eu",
+		Name:        "European CV",
+		Description: "Europass-style CV of up to two pages with language levels",
+		MaxPages:    2,
+		Sections:    []string{"summary", "experience", "education", "skills", "languages"},
+		DateFormat:  "01/2020",
+		Tone:        "factual and professional",
+		guidance: []string{
+			"Keep it to two pages at most",
+			"Give language skills with CEFR levels (A1 to C2) where the profile has them",
+			"Use a factual tone, less promotional than a US résumé",
+		},
+		monthLayout: "01/2006",
+		dateLayout:  "02/01/2006",
+	},
+	{
+		ID:          "de",
+		Name:        "German Lebenslauf",
+		Description: "Tabular CV with photo, date of birth and nationality",
+		MaxPages:    2,
+		Photo:       true,
+		DateOfBirth: true,
+		Nationality: true,
+		Sections:    []string{"personal_details", "summary", "experience", "education", "skills", "languages"},
+		DateFormat:  "01.2020",
+		Tone:        "formal and factual",
+		guidance: []string{
+			"Keep it to two pages at most",
+			"Keep the summary to two sentences; the CV is read as a table of facts",
+			"Use a formal, factual tone without self-praise",
+			"Personal details and the photo are added from the profile; do not write them into other sections",
+		},
+		monthLayout: "01.2006",
+		dateLayout:  "02.01.2006",
+	},
+	{
+		ID:            "jp",
+		Name:          "Japanese rirekisho",
+		Description:   "Standard form with photo and personal details, history oldest first",
+		MaxPages:      2,
+		Photo:         true,
+		DateOfBirth:   true,
+		Nationality:   true,
+		Chronological: true,
+		Sections:      []string{"personal_details", "education", "experience", "certifications", "skills", "summary"},
+		DateFormat:    "2020/01",
+		Tone:          "humble and formal",
+		guidance: []string{
+			"List education and work history oldest first, as a rirekisho does",
+			"Keep entries short and factual; a rirekisho is a form, not a narrative",
+			"Use the summary for the motivation for applying, in two or three sentences",
+			"Use a humble, formal tone and avoid self-promotion",
+		},
+		monthLayout: "2006/01",
+		dateLayout:  "2006/01/02",
+	},
+}
+
+// Get returns a convention by ID; an empty ID gives the default
+func Get(id string) (*Convention, error) {
+	id = strings.ToLower(strings.TrimSpace(id))
+	if id == "" {
+		id = Default
+	}
+	for _, c := range conventions {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return nil, ErrUnknownConvention
+}
+
+// Lookup returns a convention by ID, or the default one for an empty or
+// unknown ID, such as that of a document generated before conventions
+func Lookup(id string) *Convention {
+	if c, err := Get(id); err == nil {
+		return c
+	}
+	c, _ := Get(Default)
+	return c
+}
+
+// List returns all conventions
+func List() []*Convention {
+	return conventions
+}
+
+// Instructions lists the convention's rules for the prompt
+func (c *Convention) Instructions() string {
+	var b strings.Builder
+	b.WriteString("Follow the conventions of a " + c.Name + ":")
+	for _, line := range c.guidance {
+		b.WriteString("\n- " + line)
+	}
+	b.WriteString("\n- Tone: " + c.Tone)
+	return b.String()
+}
+
+// FormatMonth formats a month and year, in the language for conventions
+// that write month names
+func (c *Convention) FormatMonth(t time.Time, language string) string {
+	if c.monthLayout == "" {
+		return i18n.FormatMonth(t, language)
+	}
+	return t.Format(c.monthLayout)
+}
+
+// FormatDate formats a full date, such as a date of birth
+func (c *Convention) FormatDate(t time.Time) string {
+	return t.Format(c.dateLayout)
+}
+
+// FormatPeriod formats a start and end date like i18n.FormatPeriod, in the
+// convention's date format
+func (c *Convention) FormatPeriod(start, end time.Time, current bool, language string) string {
+	if start.IsZero() {
+		return ""
+	}
+
+	to := i18n.Present(language)
+	if !current && !end.IsZero() {
+		to = c.FormatMonth(end, language)
+	}
+	return c.FormatMonth(start, language) + " – " + to
+}
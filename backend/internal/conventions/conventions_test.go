@@ -0,0 +1,58 @@
+package conventions
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGet(t *testing.T) {
+	if c, err := Get(""); err != nil || c.ID != Default {
+		t.Errorf("Get(\"\") = %v, %v", c, err)
+	}
+	if c, err := Get(" DE "); err != nil || c.ID != "de" {
+		t.Errorf("Get(DE) = %v, %v", c, err)
+	}
+	if _, err := Get("uk"); !errors.Is(err, ErrUnknownConvention) {
+		t.Errorf("Get(uk) = %v, want ErrUnknownConvention", err)
+	}
+	if c := Lookup("uk"); c.ID != Default {
+		t.Errorf("Lookup(uk) = %s, want the default", c.ID)
+	}
+}
+
+func TestFormatPeriod(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		convention string
+		language   string
+		current    bool
+		want       string
+	}{
+		{"us", "en", false, "Jan 2020 – Mar 2022"},
+		{"us", "ru", true, "янв. 2020 – настоящее время"},
+		{"eu", "fr", false, "01/2020 – 03/2022"},
+		{"de", "de", true, "01.2020 – heute"},
+		{"jp", "en", false, "2020/01 – 2022/03"},
+	}
+	for _, tt := range tests {
+		if got := Lookup(tt.convention).FormatPeriod(start, end, tt.current, tt.language); got != tt.want {
+			t.Errorf("%s FormatPeriod(%s) = %q, want %q", tt.convention, tt.language, got, tt.want)
+		}
+	}
+}
+
+func TestInstructions(t *testing.T) {
+	for _, c := range List() {
+		instructions := c.Instructions()
+		if !strings.Contains(instructions, c.Name) || !strings.Contains(instructions, c.Tone) {
+			t.Errorf("%s instructions:\n%s", c.ID, instructions)
+		}
+		if c.MaxPages == 0 || len(c.Sections) == 0 || c.dateLayout == "" {
+			t.Errorf("%s is incomplete: %+v", c.ID, c)
+		}
+	}
+}
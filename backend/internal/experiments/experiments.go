@@ -0,0 +1,192 @@
+// Package experiments splits users across prompt and model variants for A/B
+// tests.
+//
+// Experiments are defined in a JSON file:
+//
+//	[
+//	  {
+//	    "name": "resume-concise",
+//	    "kind": "resume",
+//	    "enabled": true,
+//	    "variants": [
+//	      {"name": "control", "weight": 50},
+//	      {"name": "concise", "weight": 50, "prompt": "resume.concise", "model": "gpt-4o"}
+//	    ]
+//	  }
+//	]
+//
+// The kind is the prompt kind the experiment applies to. A variant may force a
+// prompt file (by name, without .tmpl) and a model; anything it leaves empty
+// keeps the usual choice. Users are assigned by hashing the experiment name
+// with the user ID, so a user always gets the same variant for as long as the
+// variants and weights stay the same.
+package experiments
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/prompts"
+	"github.com/google/uuid"
+)
+
+var ErrExperimentNotFound = errors.New("experiment not found")
+
+// Experiment is one A/B test over the prompts of a kind
+type Experiment struct {
+	Name     string    `json:"name"`
+	Kind     string    `json:"kind"`
+	Enabled  bool      `json:"enabled"`
+	Variants []Variant `json:"variants"`
+}
+
+// Variant is one arm of an experiment
+type Variant struct {
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
+	Prompt string `json:"prompt,omitempty"` // prompt file name, e.g. resume.concise
+	Model  string `json:"model,omitempty"`
+}
+
+// Assignment is the variant a user got in an experiment
+type Assignment struct {
+	Experiment string
+	Variant    Variant
+}
+
+// Set holds the experiment definitions
+type Set struct {
+	experiments []*Experiment
+}
+
+// Load reads experiment definitions from a JSON file. An empty path means no
+// experiments.
+func Load(path string) (*Set, error) {
+	if path == "" {
+		return &Set{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read experiments: %w", err)
+	}
+
+	var experiments []*Experiment
+	if err := json.Unmarshal(data, &experiments); err != nil {
+		return nil, fmt.Errorf("failed to parse experiments: %w", err)
+	}
+
+	return New(experiments)
+}
+
+// New checks the definitions and builds a set from them. At most one enabled
+// experiment may run per kind, so every generation has a single variant.
+func New(experiments []*Experiment) (*Set, error) {
+	names := make(map[string]bool, len(experiments))
+	running := make(map[string]string)
+	for _, e := range experiments {
+		if e.Name == "" || e.Kind == "" {
+			return nil, errors.New("experiments need a name and a kind")
+		}
+		if names[e.Name] {
+			return nil, fmt.Errorf("experiment %s is defined twice", e.Name)
+		}
+		names[e.Name] = true
+
+		if len(e.Variants) < 2 {
+			return nil, fmt.Errorf("experiment %s needs at least two variants", e.Name)
+		}
+		variants := make(map[string]bool, len(e.Variants))
+		for _, v := range e.Variants {
+			if v.Name == "" || v.Weight <= 0 {
+				return nil, fmt.Errorf("experiment %s: variants need a name and a positive weight", e.Name)
+			}
+			if variants[v.Name] {
+				return nil, fmt.Errorf("experiment %s: variant %s is defined twice", e.Name, v.Name)
+			}
+			variants[v.Name] = true
+			if v.Prompt != "" && v.Prompt != e.Kind && !strings.HasPrefix(v.Prompt, e.Kind+".") {
+				return nil, fmt.Errorf("experiment %s: prompt %s is not a %s prompt", e.Name, v.Prompt, e.Kind)
+			}
+		}
+
+		if e.Enabled {
+			if other, ok := running[e.Kind]; ok {
+				return nil, fmt.Errorf("experiments %s and %s both run on %s", other, e.Name, e.Kind)
+			}
+			running[e.Kind] = e.Name
+		}
+	}
+
+	return &Set{experiments: experiments}, nil
+}
+
+// CheckPrompts makes sure every prompt a variant forces exists in registry
+func (s *Set) CheckPrompts(registry *prompts.Registry) error {
+	for _, e := range s.experiments {
+		for _, v := range e.Variants {
+			if v.Prompt == "" {
+				continue
+			}
+			if _, err := registry.Get(v.Prompt); err != nil {
+				return fmt.Errorf("experiment %s, variant %s: %w", e.Name, v.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// List returns all experiment definitions
+func (s *Set) List() []*Experiment {
+	return s.experiments
+}
+
+// Get returns an experiment by name
+func (s *Set) Get(name string) (*Experiment, error) {
+	for _, e := range s.experiments {
+		if e.Name == name {
+			return e, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrExperimentNotFound, name)
+}
+
+// Assign returns the user's variant in the enabled experiment on kind, or nil
+// if no experiment runs on it
+func (s *Set) Assign(kind string, userID uuid.UUID) *Assignment {
+	if s == nil {
+		return nil
+	}
+	for _, e := range s.experiments {
+		if e.Enabled && e.Kind == kind {
+			return &Assignment{Experiment: e.Name, Variant: e.Assign(userID)}
+		}
+	}
+	return nil
+}
+
+// Assign picks the user's variant. The hash of the experiment name and user
+// ID is spread over the total weight, so each variant gets its share of users
+// and different experiments split users independently.
+func (e *Experiment) Assign(userID uuid.UUID) Variant {
+	total := 0
+	for _, v := range e.Variants {
+		total += v.Weight
+	}
+
+	sum := sha256.Sum256([]byte(e.Name + ":" + userID.String()))
+	bucket := int(binary.BigEndian.Uint64(sum[:8]) % uint64(total))
+
+	for _, v := range e.Variants {
+		if bucket < v.Weight {
+			return v
+		}
+		bucket -= v.Weight
+	}
+	return e.Variants[len(e.Variants)-1]
+}
@@ -0,0 +1,135 @@
+package experiments
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func testExperiment() *Experiment {
+	return &Experiment{
+		Name:    "resume-concise",
+		Kind:    "resume",
+		Enabled: true,
+		Variants: []Variant{
+			{Name: "control", Weight: 3},
+			{Name: "concise", Weight: 1, Prompt: "resume.concise", Model: "gpt-4o"},
+		},
+	}
+}
+
+func TestAssignIsDeterministicAndWeighted(t *testing.T) {
+	set, err := New([]*Experiment{testExperiment()})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	counts := map[string]int{}
+	for i := 0; i < 4000; i++ {
+		userID := uuid.New()
+		first := set.Assign("resume", userID)
+		if first == nil || first.Experiment != "resume-concise" {
+			t.Fatalf("Assign = %+v", first)
+		}
+		if again := set.Assign("resume", userID); again.Variant.Name != first.Variant.Name {
+			t.Fatalf("user %s got %s, then %s", userID, first.Variant.Name, again.Variant.Name)
+		}
+		counts[first.Variant.Name]++
+	}
+
+	// 3:1 weights, with generous slack for randomness of the user IDs
+	if counts["control"] < 2800 || counts["control"] > 3200 {
+		t.Errorf("variant counts = %v, want about 3000 control", counts)
+	}
+
+	// Assignments must not change between releases, or users switch variants
+	// mid-experiment
+	for id, want := range map[string]string{
+		"6f1c1b52-2a55-4b3a-9d7e-0c7a1a0e5f10": "control",
+		"0b4a4c7e-8f1d-4d2a-b3a9-5e2f6c1d7a88": "control",
+		"c3d2e1f0-1234-4abc-8def-0123456789ab": "concise",
+	} {
+		if got := testExperiment().Assign(uuid.MustParse(id)); got.Name != want {
+			t.Errorf("Assign(%s) = %s, want %s", id, got.Name, want)
+		}
+	}
+}
+
+func TestAssignSkipsOtherKindsAndDisabledExperiments(t *testing.T) {
+	disabled := testExperiment()
+	disabled.Name = "cover-letter-tone"
+	disabled.Kind = "cover_letter"
+	disabled.Enabled = false
+	disabled.Variants[1].Prompt = "cover_letter.warm"
+
+	set, err := New([]*Experiment{testExperiment(), disabled})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if got := set.Assign("cover_letter", uuid.New()); got != nil {
+		t.Errorf("Assign(cover_letter) = %+v, want nil for a disabled experiment", got)
+	}
+	if got := set.Assign("section", uuid.New()); got != nil {
+		t.Errorf("Assign(section) = %+v, want nil", got)
+	}
+
+	var empty *Set
+	if got := empty.Assign("resume", uuid.New()); got != nil {
+		t.Errorf("nil set Assign = %+v", got)
+	}
+}
+
+func TestNewRejectsInvalidExperiments(t *testing.T) {
+	tests := map[string]func(e *Experiment){
+		"no name":        func(e *Experiment) { e.Name = "" },
+		"one variant":    func(e *Experiment) { e.Variants = e.Variants[:1] },
+		"zero weight":    func(e *Experiment) { e.Variants[0].Weight = 0 },
+		"same variant":   func(e *Experiment) { e.Variants[1].Name = "control" },
+		"foreign prompt": func(e *Experiment) { e.Variants[1].Prompt = "cover_letter.warm" },
+	}
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := testExperiment()
+			modify(e)
+			if _, err := New([]*Experiment{e}); err == nil {
+				t.Error("New succeeded, want an error")
+			}
+		})
+	}
+
+	second := testExperiment()
+	second.Name = "resume-bullets"
+	if _, err := New([]*Experiment{testExperiment(), second}); err == nil || !strings.Contains(err.Error(), "both run on resume") {
+		t.Errorf("two enabled experiments on one kind: %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	set, err := Load("")
+	if err != nil || len(set.List()) != 0 {
+		t.Fatalf(`Load("") = %v, %v`, set, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "experiments.json")
+	content := `[{"name": "resume-concise", "kind": "resume", "enabled": true,
+		"variants": [{"name": "control", "weight": 1}, {"name": "concise", "weight": 1, "model": "gpt-4o"}]}]`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	set, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	experiment, err := set.Get("resume-concise")
+	if err != nil || experiment.Variants[1].Model != "gpt-4o" {
+		t.Errorf("Get = %+v, %v", experiment, err)
+	}
+	if _, err := set.Get("unknown"); err == nil {
+		t.Error("Get(unknown) succeeded")
+	}
+}
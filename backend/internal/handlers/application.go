@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/middleware"
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/repository"
+	"github.com/feijoa-master/ai-resume-builder/internal/service"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type ApplicationHandler struct {
+	applicationService *service.ApplicationService
+}
+
+func NewApplicationHandler(applicationService *service.ApplicationService) *ApplicationHandler {
+	return &ApplicationHandler{
+		applicationService: applicationService,
+	}
+}
+
+// CreateApplication starts tracking a job application
+func (h *ApplicationHandler) CreateApplication(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	var app models.Application
+	if err := json.NewDecoder(r.Body).Decode(&app); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	if app.CompanyName == "" || app.JobTitle == "" {
+		respondWithError(w, http.StatusBadRequest, "MISSING_FIELDS", "Company name and job title are required", nil)
+		return
+	}
+
+	created, err := h.applicationService.CreateApplication(userID, &app)
+	if err != nil {
+		respondWithApplicationError(w, err, "CREATE_FAILED", "Failed to create application")
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, created)
+}
+
+// GetApplications lists applications. Filters: status (comma-separated),
+// company (substring), applied_from and applied_to (YYYY-MM-DD, inclusive).
+func (h *ApplicationHandler) GetApplications(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := models.ApplicationFilter{Company: strings.TrimSpace(query.Get("company"))}
+	for _, value := range query["status"] {
+		for _, status := range strings.Split(value, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				filter.Statuses = append(filter.Statuses, status)
+			}
+		}
+	}
+	for name, date := range map[string]*models.NullDate{"applied_from": &filter.AppliedFrom, "applied_to": &filter.AppliedTo} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "INVALID_DATE", name+" must be a date in YYYY-MM-DD format", nil)
+			return
+		}
+		date.Time, date.Valid = parsed, true
+	}
+
+	applications, err := h.applicationService.GetApplications(userID, filter)
+	if err != nil {
+		respondWithApplicationError(w, err, "FETCH_FAILED", "Failed to get applications")
+		return
+	}
+
+	if applications == nil {
+		applications = []*models.Application{}
+	}
+
+	respondWithJSON(w, http.StatusOK, applications)
+}
+
+// GetApplication retrieves a single application
+func (h *ApplicationHandler) GetApplication(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	appID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid application ID", nil)
+		return
+	}
+
+	app, err := h.applicationService.GetApplication(userID, appID)
+	if err != nil {
+		respondWithApplicationError(w, err, "FETCH_FAILED", "Failed to get application")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, app)
+}
+
+// UpdateApplication replaces an application's details; the status is
+// changed through UpdateApplicationStatus
+func (h *ApplicationHandler) UpdateApplication(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	appID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid application ID", nil)
+		return
+	}
+
+	var app models.Application
+	if err := json.NewDecoder(r.Body).Decode(&app); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	if app.CompanyName == "" || app.JobTitle == "" || app.AppliedDate.IsZero() {
+		respondWithError(w, http.StatusBadRequest, "MISSING_FIELDS", "Company name, job title and applied date are required", nil)
+		return
+	}
+
+	updated, err := h.applicationService.UpdateApplication(userID, appID, &app)
+	if err != nil {
+		respondWithApplicationError(w, err, "UPDATE_FAILED", "Failed to update application")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, updated)
+}
+
+// UpdateApplicationStatus moves an application along the status pipeline
+func (h *ApplicationHandler) UpdateApplicationStatus(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	appID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid application ID", nil)
+		return
+	}
+
+	var req models.ApplicationStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	app, err := h.applicationService.ChangeStatus(userID, appID, &req)
+	if err != nil {
+		respondWithApplicationError(w, err, "UPDATE_FAILED", "Failed to change application status")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, app)
+}
+
+// GetApplicationHistory lists the status changes of an application
+func (h *ApplicationHandler) GetApplicationHistory(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	appID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid application ID", nil)
+		return
+	}
+
+	history, err := h.applicationService.GetApplicationHistory(userID, appID)
+	if err != nil {
+		respondWithApplicationError(w, err, "FETCH_FAILED", "Failed to get application history")
+		return
+	}
+
+	if history == nil {
+		history = []*models.ApplicationStatusChange{}
+	}
+
+	respondWithJSON(w, http.StatusOK, history)
+}
+
+// DeleteApplication stops tracking an application
+func (h *ApplicationHandler) DeleteApplication(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	appID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid application ID", nil)
+		return
+	}
+
+	if err := h.applicationService.DeleteApplication(userID, appID); err != nil {
+		respondWithApplicationError(w, err, "DELETE_FAILED", "Failed to delete application")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Application deleted successfully"})
+}
+
+// respondWithApplicationError maps application errors to responses, falling
+// back to a 500 with the given code and message
+func respondWithApplicationError(w http.ResponseWriter, err error, code, message string) {
+	switch {
+	case errors.Is(err, repository.ErrApplicationNotFound):
+		respondWithError(w, http.StatusNotFound, "NOT_FOUND", "Application not found", nil)
+	case errors.Is(err, service.ErrInvalidApplicationStatus):
+		respondWithError(w, http.StatusBadRequest, "INVALID_STATUS", err.Error(), nil)
+	case errors.Is(err, service.ErrInvalidStatusTransition):
+		respondWithError(w, http.StatusConflict, "INVALID_TRANSITION", err.Error(), nil)
+	case errors.Is(err, repository.ErrApplicationStatusChanged):
+		respondWithError(w, http.StatusConflict, "STATUS_CHANGED", err.Error(), nil)
+	case errors.Is(err, service.ErrInvalidApplicationDocument):
+		respondWithError(w, http.StatusBadRequest, "INVALID_DOCUMENT", err.Error(), nil)
+	default:
+		respondWithError(w, http.StatusInternalServerError, code, message, nil)
+	}
+}
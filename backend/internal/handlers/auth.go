@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/feijoa-master/ai-resume-builder/internal/middleware"
 	"github.com/feijoa-master/ai-resume-builder/internal/models"
 	"github.com/feijoa-master/ai-resume-builder/internal/service"
 )
@@ -131,7 +132,7 @@ func respondWithError(w http.ResponseWriter, statusCode int, code, message strin
 	errorResponse := models.ErrorResponse{
 		Error: models.ErrorDetail{
 			Code:    code,
-			Message: message,
+			Message: middleware.Translate(w, message),
 			Details: details,
 		},
 	}
@@ -2,10 +2,15 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
+	"github.com/feijoa-master/ai-resume-builder/internal/conventions"
+	"github.com/feijoa-master/ai-resume-builder/internal/i18n"
 	"github.com/feijoa-master/ai-resume-builder/internal/middleware"
 	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/repository"
 	"github.com/feijoa-master/ai-resume-builder/internal/service"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
@@ -36,8 +41,8 @@ func (h *DocumentHandler) GenerateResume(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Validate required fields
-	if req.JobDescription == "" {
-		respondWithError(w, http.StatusBadRequest, "MISSING_FIELDS", "Job description is required", nil)
+	if req.JobDescription == "" && req.JobPostingID == nil {
+		respondWithError(w, http.StatusBadRequest, "MISSING_FIELDS", "Job description or job posting ID is required", nil)
 		return
 	}
 
@@ -55,6 +60,30 @@ func (h *DocumentHandler) GenerateResume(w http.ResponseWriter, r *http.Request)
 			respondWithError(w, http.StatusForbidden, "NO_FREE_GENERATIONS", "No free generations left. Please upgrade to premium.", nil)
 			return
 		}
+		if errors.Is(err, service.ErrInvalidCustomSection) {
+			respondWithError(w, http.StatusBadRequest, "INVALID_CUSTOM_SECTION", err.Error(), nil)
+			return
+		}
+		if errors.Is(err, i18n.ErrUnsupportedLanguage) {
+			respondWithError(w, http.StatusBadRequest, "UNSUPPORTED_LANGUAGE", err.Error(), nil)
+			return
+		}
+		if errors.Is(err, conventions.ErrUnknownConvention) {
+			respondWithError(w, http.StatusBadRequest, "UNKNOWN_CONVENTION", err.Error(), nil)
+			return
+		}
+		if req.ProfileID != nil && errors.Is(err, repository.ErrProfileNotFound) {
+			respondWithError(w, http.StatusNotFound, "PROFILE_NOT_FOUND", "Profile not found", nil)
+			return
+		}
+		if errors.Is(err, repository.ErrJobPostingNotFound) {
+			respondWithError(w, http.StatusNotFound, "JOB_POSTING_NOT_FOUND", "Job posting not found", nil)
+			return
+		}
+		if errors.Is(err, service.ErrModelUnavailable) {
+			respondWithError(w, http.StatusServiceUnavailable, "MODEL_UNAVAILABLE", "AI generation is temporarily unavailable, try again later", nil)
+			return
+		}
 		respondWithError(w, http.StatusInternalServerError, "GENERATION_FAILED", "Failed to generate resume", nil)
 		return
 	}
@@ -85,8 +114,8 @@ func (h *DocumentHandler) GenerateCoverLetter(w http.ResponseWriter, r *http.Req
 	}
 
 	// Validate required fields
-	if req.JobDescription == "" {
-		respondWithError(w, http.StatusBadRequest, "MISSING_FIELDS", "Job description is required", nil)
+	if req.JobDescription == "" && req.JobPostingID == nil {
+		respondWithError(w, http.StatusBadRequest, "MISSING_FIELDS", "Job description or job posting ID is required", nil)
 		return
 	}
 
@@ -104,6 +133,30 @@ func (h *DocumentHandler) GenerateCoverLetter(w http.ResponseWriter, r *http.Req
 			respondWithError(w, http.StatusForbidden, "NO_FREE_GENERATIONS", "No free generations left. Please upgrade to premium.", nil)
 			return
 		}
+		if errors.Is(err, service.ErrInvalidCustomSection) {
+			respondWithError(w, http.StatusBadRequest, "INVALID_CUSTOM_SECTION", err.Error(), nil)
+			return
+		}
+		if errors.Is(err, i18n.ErrUnsupportedLanguage) {
+			respondWithError(w, http.StatusBadRequest, "UNSUPPORTED_LANGUAGE", err.Error(), nil)
+			return
+		}
+		if errors.Is(err, conventions.ErrUnknownConvention) {
+			respondWithError(w, http.StatusBadRequest, "UNKNOWN_CONVENTION", err.Error(), nil)
+			return
+		}
+		if req.ProfileID != nil && errors.Is(err, repository.ErrProfileNotFound) {
+			respondWithError(w, http.StatusNotFound, "PROFILE_NOT_FOUND", "Profile not found", nil)
+			return
+		}
+		if errors.Is(err, repository.ErrJobPostingNotFound) {
+			respondWithError(w, http.StatusNotFound, "JOB_POSTING_NOT_FOUND", "Job posting not found", nil)
+			return
+		}
+		if errors.Is(err, service.ErrModelUnavailable) {
+			respondWithError(w, http.StatusServiceUnavailable, "MODEL_UNAVAILABLE", "AI generation is temporarily unavailable, try again later", nil)
+			return
+		}
 		respondWithError(w, http.StatusInternalServerError, "GENERATION_FAILED", "Failed to generate cover letter", nil)
 		return
 	}
@@ -214,3 +267,440 @@ func (h *DocumentHandler) DeleteDocument(w http.ResponseWriter, r *http.Request)
 
 	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Document deleted successfully"})
 }
+
+// GetDocumentVersions lists the saved versions of a document
+func (h *DocumentHandler) GetDocumentVersions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	docID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid document ID", nil)
+		return
+	}
+
+	versions, err := h.documentService.GetDocumentVersions(userID, docID)
+	if err != nil {
+		if err == repository.ErrUserNotFound {
+			respondWithError(w, http.StatusNotFound, "NOT_FOUND", "Document not found", nil)
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "FETCH_FAILED", "Failed to get document versions", nil)
+		return
+	}
+
+	if versions == nil {
+		versions = []*models.DocumentVersion{}
+	}
+
+	respondWithJSON(w, http.StatusOK, versions)
+}
+
+// GetDocumentVersion retrieves a single version of a document
+func (h *DocumentHandler) GetDocumentVersion(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	docID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid document ID", nil)
+		return
+	}
+
+	version, err := strconv.Atoi(vars["version"])
+	if err != nil || version < 1 {
+		respondWithError(w, http.StatusBadRequest, "INVALID_VERSION", "Invalid version number", nil)
+		return
+	}
+
+	documentVersion, err := h.documentService.GetDocumentVersion(userID, docID, version)
+	if err != nil {
+		if err == repository.ErrDocumentVersionNotFound {
+			respondWithError(w, http.StatusNotFound, "NOT_FOUND", "Document version not found", nil)
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "FETCH_FAILED", "Failed to get document version", nil)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, documentVersion)
+}
+
+// DiffDocumentVersions compares two versions of a document.
+// Query params: from (required) and to (optional, defaults to the current document).
+func (h *DocumentHandler) DiffDocumentVersions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	docID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid document ID", nil)
+		return
+	}
+
+	fromVersion, err := strconv.Atoi(r.URL.Query().Get("from"))
+	if err != nil || fromVersion < 1 {
+		respondWithError(w, http.StatusBadRequest, "INVALID_VERSION", "Query parameter 'from' must be a version number", nil)
+		return
+	}
+
+	toVersion := 0
+	if to := r.URL.Query().Get("to"); to != "" && to != "current" {
+		toVersion, err = strconv.Atoi(to)
+		if err != nil || toVersion < 1 {
+			respondWithError(w, http.StatusBadRequest, "INVALID_VERSION", "Query parameter 'to' must be a version number or 'current'", nil)
+			return
+		}
+	}
+
+	diff, err := h.documentService.DiffDocumentVersions(userID, docID, fromVersion, toVersion)
+	if err != nil {
+		if err == repository.ErrDocumentVersionNotFound || err == repository.ErrUserNotFound {
+			respondWithError(w, http.StatusNotFound, "NOT_FOUND", "Document version not found", nil)
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "DIFF_FAILED", "Failed to compare document versions", nil)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, diff)
+}
+
+// RestoreDocumentVersion makes an old version the current document
+func (h *DocumentHandler) RestoreDocumentVersion(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	docID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid document ID", nil)
+		return
+	}
+
+	version, err := strconv.Atoi(vars["version"])
+	if err != nil || version < 1 {
+		respondWithError(w, http.StatusBadRequest, "INVALID_VERSION", "Invalid version number", nil)
+		return
+	}
+
+	doc, err := h.documentService.RestoreDocumentVersion(userID, docID, version)
+	if err != nil {
+		if err == repository.ErrDocumentVersionNotFound {
+			respondWithError(w, http.StatusNotFound, "NOT_FOUND", "Document version not found", nil)
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "RESTORE_FAILED", "Failed to restore document version", nil)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, doc)
+}
+
+// RegenerateSection rewrites one section of a document
+func (h *DocumentHandler) RegenerateSection(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	docID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid document ID", nil)
+		return
+	}
+
+	var req models.RegenerateSectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	if req.Section == "" {
+		respondWithError(w, http.StatusBadRequest, "MISSING_FIELDS", "Section is required", nil)
+		return
+	}
+
+	doc, err := h.documentService.RegenerateSection(userID, docID, &req)
+	if err != nil {
+		switch {
+		case err == repository.ErrUserNotFound:
+			respondWithError(w, http.StatusNotFound, "NOT_FOUND", "Document not found", nil)
+		case err == service.ErrNoFreeRegenerationsLeft:
+			respondWithError(w, http.StatusForbidden, "NO_FREE_REGENERATIONS", "No free section regenerations left. Please upgrade to premium.", nil)
+		case err == service.ErrInvalidSection:
+			respondWithError(w, http.StatusBadRequest, "INVALID_SECTION", err.Error(), nil)
+		case errors.Is(err, service.ErrSectionNotFound):
+			respondWithError(w, http.StatusBadRequest, "SECTION_NOT_FOUND", err.Error(), nil)
+		case errors.Is(err, service.ErrModelUnavailable):
+			respondWithError(w, http.StatusServiceUnavailable, "MODEL_UNAVAILABLE", "AI generation is temporarily unavailable, try again later", nil)
+		default:
+			respondWithError(w, http.StatusInternalServerError, "GENERATION_FAILED", "Failed to regenerate section", nil)
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, doc)
+}
+
+// ListConventions lists the regional resume conventions with the layout
+// rules renderers follow
+func (h *DocumentHandler) ListConventions(w http.ResponseWriter, r *http.Request) {
+	respondWithJSON(w, http.StatusOK, h.documentService.ListConventions())
+}
+
+// TranslateDocument saves a copy of a document in another language
+func (h *DocumentHandler) TranslateDocument(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	docID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid document ID", nil)
+		return
+	}
+
+	var req models.TranslateDocumentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	if req.Language == "" {
+		respondWithError(w, http.StatusBadRequest, "MISSING_FIELDS", "Language is required", nil)
+		return
+	}
+
+	doc, err := h.documentService.TranslateDocument(userID, docID, &req)
+	if err != nil {
+		switch {
+		case err == repository.ErrUserNotFound:
+			respondWithError(w, http.StatusNotFound, "NOT_FOUND", "Document not found", nil)
+		case err == service.ErrNoFreeGenerationsLeft:
+			respondWithError(w, http.StatusForbidden, "NO_FREE_GENERATIONS", "No free generations left. Please upgrade to premium.", nil)
+		case errors.Is(err, i18n.ErrUnsupportedLanguage):
+			respondWithError(w, http.StatusBadRequest, "UNSUPPORTED_LANGUAGE", err.Error(), nil)
+		case err == service.ErrSameLanguage:
+			respondWithError(w, http.StatusBadRequest, "SAME_LANGUAGE", "Document is already in that language", nil)
+		case errors.Is(err, service.ErrModelUnavailable):
+			respondWithError(w, http.StatusServiceUnavailable, "MODEL_UNAVAILABLE", "AI generation is temporarily unavailable, try again later", nil)
+		default:
+			respondWithError(w, http.StatusInternalServerError, "TRANSLATION_FAILED", "Failed to translate document", nil)
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, doc)
+}
+
+// GetDocumentMessages retrieves the refinement chat of a document
+func (h *DocumentHandler) GetDocumentMessages(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	docID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid document ID", nil)
+		return
+	}
+
+	messages, err := h.documentService.GetDocumentMessages(userID, docID)
+	if err != nil {
+		if err == repository.ErrUserNotFound {
+			respondWithError(w, http.StatusNotFound, "NOT_FOUND", "Document not found", nil)
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "FETCH_FAILED", "Failed to get messages", nil)
+		return
+	}
+
+	if messages == nil {
+		messages = []*models.DocumentMessage{}
+	}
+
+	respondWithJSON(w, http.StatusOK, messages)
+}
+
+// RefineDocument applies a chat instruction to a document
+func (h *DocumentHandler) RefineDocument(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	docID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid document ID", nil)
+		return
+	}
+
+	var req models.RefineDocumentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	if req.Message == "" {
+		respondWithError(w, http.StatusBadRequest, "MISSING_FIELDS", "Message is required", nil)
+		return
+	}
+
+	response, err := h.documentService.RefineDocument(userID, docID, req.Message)
+	if err != nil {
+		if err == repository.ErrUserNotFound {
+			respondWithError(w, http.StatusNotFound, "NOT_FOUND", "Document not found", nil)
+			return
+		}
+		if err == service.ErrNoFreeGenerationsLeft {
+			respondWithError(w, http.StatusForbidden, "NO_FREE_GENERATIONS", "No free generations left. Please upgrade to premium.", nil)
+			return
+		}
+		if errors.Is(err, service.ErrModelUnavailable) {
+			respondWithError(w, http.StatusServiceUnavailable, "MODEL_UNAVAILABLE", "AI generation is temporarily unavailable, try again later", nil)
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "GENERATION_FAILED", "Failed to refine document", nil)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// AnalyzeJob extracts structured requirements from a job description
+func (h *DocumentHandler) AnalyzeJob(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	var req models.AnalyzeJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	if req.JobDescription == "" {
+		respondWithError(w, http.StatusBadRequest, "MISSING_FIELDS", "Job description is required", nil)
+		return
+	}
+
+	analysis, err := h.documentService.AnalyzeJob(userID, &req)
+	if err != nil {
+		if err == repository.ErrUserNotFound {
+			respondWithError(w, http.StatusNotFound, "NOT_FOUND", "Document not found", nil)
+			return
+		}
+		if err == service.ErrNoFreeGenerationsLeft {
+			respondWithError(w, http.StatusForbidden, "NO_FREE_GENERATIONS", "No free generations left. Please upgrade to premium.", nil)
+			return
+		}
+		if errors.Is(err, service.ErrModelUnavailable) {
+			respondWithError(w, http.StatusServiceUnavailable, "MODEL_UNAVAILABLE", "AI generation is temporarily unavailable, try again later", nil)
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "ANALYSIS_FAILED", "Failed to analyze job description", nil)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, analysis)
+}
+
+// SkillGap compares the user's skills with a job
+func (h *DocumentHandler) SkillGap(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	var req models.SkillGapRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	if req.JobDescription == "" && req.DocumentID == nil {
+		respondWithError(w, http.StatusBadRequest, "MISSING_FIELDS", "Job description or document ID is required", nil)
+		return
+	}
+
+	report, err := h.documentService.SkillGap(userID, &req)
+	if err != nil {
+		if err == repository.ErrUserNotFound {
+			respondWithError(w, http.StatusNotFound, "NOT_FOUND", "Document not found", nil)
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "SKILL_GAP_FAILED", "Failed to analyze skill gap", nil)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, report)
+}
+
+// ScoreDocument returns an ATS match score for a document
+func (h *DocumentHandler) ScoreDocument(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	docID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid document ID", nil)
+		return
+	}
+
+	// The body is optional
+	var req models.ATSScoreRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+			return
+		}
+	}
+
+	score, err := h.documentService.ScoreDocument(userID, docID, &req)
+	if err != nil {
+		switch err {
+		case repository.ErrUserNotFound:
+			respondWithError(w, http.StatusNotFound, "NOT_FOUND", "Document not found", nil)
+		case service.ErrInvalidScoreSource:
+			respondWithError(w, http.StatusBadRequest, "INVALID_SOURCE", err.Error(), nil)
+		default:
+			respondWithError(w, http.StatusInternalServerError, "SCORING_FAILED", "Failed to score document", nil)
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, score)
+}
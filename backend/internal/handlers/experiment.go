@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/experiments"
+	"github.com/feijoa-master/ai-resume-builder/internal/middleware"
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/repository"
+	"github.com/feijoa-master/ai-resume-builder/internal/service"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type ExperimentHandler struct {
+	experimentService *service.ExperimentService
+}
+
+func NewExperimentHandler(experimentService *service.ExperimentService) *ExperimentHandler {
+	return &ExperimentHandler{
+		experimentService: experimentService,
+	}
+}
+
+// RecordSignal stores a signal the client reports on a document, such as a
+// download or a thumbs up
+func (h *ExperimentHandler) RecordSignal(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	docID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid document ID", nil)
+		return
+	}
+
+	var req models.DocumentSignalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	if err := h.experimentService.RecordSignal(userID, docID, req.Signal); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidSignal):
+			respondWithError(w, http.StatusBadRequest, "INVALID_SIGNAL", err.Error(), nil)
+		case errors.Is(err, repository.ErrUserNotFound):
+			respondWithError(w, http.StatusNotFound, "NOT_FOUND", "Document not found", nil)
+		default:
+			respondWithError(w, http.StatusInternalServerError, "SIGNAL_FAILED", "Failed to record signal", nil)
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]string{"message": "Signal recorded"})
+}
+
+// ListExperiments lists the experiment definitions (admin only)
+func (h *ExperimentHandler) ListExperiments(w http.ResponseWriter, r *http.Request) {
+	list := h.experimentService.ListExperiments()
+	if list == nil {
+		list = []*experiments.Experiment{}
+	}
+
+	respondWithJSON(w, http.StatusOK, list)
+}
+
+// GetExperimentReport compares the variants of an experiment (admin only)
+func (h *ExperimentHandler) GetExperimentReport(w http.ResponseWriter, r *http.Request) {
+	report, err := h.experimentService.GetExperimentReport(mux.Vars(r)["name"])
+	if err != nil {
+		if errors.Is(err, experiments.ErrExperimentNotFound) {
+			respondWithError(w, http.StatusNotFound, "NOT_FOUND", "Experiment not found", nil)
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "FETCH_FAILED", "Failed to get experiment report", nil)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/middleware"
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/repository"
+	"github.com/feijoa-master/ai-resume-builder/internal/service"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type FeedbackHandler struct {
+	feedbackService *service.FeedbackService
+}
+
+func NewFeedbackHandler(feedbackService *service.FeedbackService) *FeedbackHandler {
+	return &FeedbackHandler{
+		feedbackService: feedbackService,
+	}
+}
+
+// SaveFeedback rates a document or one of its sections
+func (h *FeedbackHandler) SaveFeedback(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	docID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid document ID", nil)
+		return
+	}
+
+	var req models.FeedbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	feedback, err := h.feedbackService.SaveFeedback(userID, docID, &req)
+	if err != nil {
+		respondWithFeedbackError(w, err, "SAVE_FAILED", "Failed to save feedback")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, feedback)
+}
+
+// GetDocumentFeedback lists the ratings of a document and its sections
+func (h *FeedbackHandler) GetDocumentFeedback(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	docID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid document ID", nil)
+		return
+	}
+
+	feedback, err := h.feedbackService.GetDocumentFeedback(userID, docID)
+	if err != nil {
+		respondWithFeedbackError(w, err, "FETCH_FAILED", "Failed to get feedback")
+		return
+	}
+
+	if feedback == nil {
+		feedback = []*models.GenerationFeedback{}
+	}
+
+	respondWithJSON(w, http.StatusOK, feedback)
+}
+
+// DeleteFeedback removes the rating of a document, or of the section given
+// by the section and index query parameters
+func (h *FeedbackHandler) DeleteFeedback(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	docID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid document ID", nil)
+		return
+	}
+
+	var index *int
+	if value := r.URL.Query().Get("index"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "index must be a number", nil)
+			return
+		}
+		index = &parsed
+	}
+
+	if err := h.feedbackService.DeleteFeedback(userID, docID, r.URL.Query().Get("section"), index); err != nil {
+		respondWithFeedbackError(w, err, "DELETE_FAILED", "Failed to delete feedback")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Feedback deleted"})
+}
+
+// GetFeedbackSummaries aggregates feedback by model, prompt and template
+// (admin only). scope=document or scope=section narrows it.
+func (h *FeedbackHandler) GetFeedbackSummaries(w http.ResponseWriter, r *http.Request) {
+	summaries, err := h.feedbackService.GetFeedbackSummaries(r.URL.Query().Get("scope"))
+	if err != nil {
+		respondWithFeedbackError(w, err, "FETCH_FAILED", "Failed to get feedback summaries")
+		return
+	}
+
+	if summaries == nil {
+		summaries = []*models.FeedbackSummary{}
+	}
+
+	respondWithJSON(w, http.StatusOK, summaries)
+}
+
+// respondWithFeedbackError maps feedback errors to responses, falling back
+// to a 500 with the given code and message
+func respondWithFeedbackError(w http.ResponseWriter, err error, code, message string) {
+	switch {
+	case errors.Is(err, repository.ErrUserNotFound):
+		respondWithError(w, http.StatusNotFound, "NOT_FOUND", "Document not found", nil)
+	case errors.Is(err, repository.ErrFeedbackNotFound):
+		respondWithError(w, http.StatusNotFound, "NOT_FOUND", "Feedback not found", nil)
+	case errors.Is(err, service.ErrInvalidRating),
+		errors.Is(err, service.ErrInvalidFeedbackReason),
+		errors.Is(err, service.ErrFeedbackTooLong),
+		errors.Is(err, service.ErrInvalidFeedbackScope):
+		respondWithError(w, http.StatusBadRequest, "INVALID_FEEDBACK", err.Error(), nil)
+	case errors.Is(err, service.ErrInvalidSection):
+		respondWithError(w, http.StatusBadRequest, "INVALID_SECTION", err.Error(), nil)
+	case errors.Is(err, service.ErrSectionNotFound):
+		respondWithError(w, http.StatusBadRequest, "SECTION_NOT_FOUND", err.Error(), nil)
+	default:
+		respondWithError(w, http.StatusInternalServerError, code, message, nil)
+	}
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/middleware"
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/repository"
+	"github.com/feijoa-master/ai-resume-builder/internal/service"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type JobPostingHandler struct {
+	jobPostingService *service.JobPostingService
+}
+
+func NewJobPostingHandler(jobPostingService *service.JobPostingService) *JobPostingHandler {
+	return &JobPostingHandler{
+		jobPostingService: jobPostingService,
+	}
+}
+
+// CreateJobPosting saves a job posting. Saving a description that is already
+// saved returns the saved posting with 200 instead of 201.
+func (h *JobPostingHandler) CreateJobPosting(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	var posting models.JobPosting
+	if err := json.NewDecoder(r.Body).Decode(&posting); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	if strings.TrimSpace(posting.Title) == "" || strings.TrimSpace(posting.Description) == "" {
+		respondWithError(w, http.StatusBadRequest, "MISSING_FIELDS", "Title and description are required", nil)
+		return
+	}
+
+	saved, created, err := h.jobPostingService.CreateJobPosting(userID, &posting)
+	if err != nil {
+		respondWithJobPostingError(w, err, "CREATE_FAILED", "Failed to save job posting")
+		return
+	}
+
+	status := http.StatusCreated
+	if !created {
+		status = http.StatusOK
+	}
+
+	respondWithJSON(w, status, saved)
+}
+
+// maxPastedPostingLength caps the size of a posting sent to ParseJobPosting
+const maxPastedPostingLength = 1 << 20
+
+// ParseJobPosting extracts the title, company, location and description from
+// a posting pasted from a job board, without saving it
+func (h *JobPostingHandler) ParseJobPosting(w http.ResponseWriter, r *http.Request) {
+	if _, ok := middleware.GetUserIDFromContext(r); !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	var req models.ParseJobPostingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	if strings.TrimSpace(req.Content) == "" {
+		respondWithError(w, http.StatusBadRequest, "MISSING_FIELDS", "Content is required", nil)
+		return
+	}
+	if len(req.Content) > maxPastedPostingLength {
+		respondWithError(w, http.StatusBadRequest, "CONTENT_TOO_LONG", "Content must be at most 1 MB", nil)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, service.ParseJobPosting(req.Content))
+}
+
+// GetJobPostings lists the user's saved postings
+func (h *JobPostingHandler) GetJobPostings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	postings, err := h.jobPostingService.GetJobPostings(userID)
+	if err != nil {
+		respondWithJobPostingError(w, err, "FETCH_FAILED", "Failed to get job postings")
+		return
+	}
+
+	if postings == nil {
+		postings = []*models.JobPosting{}
+	}
+
+	respondWithJSON(w, http.StatusOK, postings)
+}
+
+// GetJobPosting retrieves a single saved posting
+func (h *JobPostingHandler) GetJobPosting(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	postingID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid job posting ID", nil)
+		return
+	}
+
+	posting, err := h.jobPostingService.GetJobPosting(userID, postingID)
+	if err != nil {
+		respondWithJobPostingError(w, err, "FETCH_FAILED", "Failed to get job posting")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, posting)
+}
+
+// UpdateJobPosting replaces a saved posting's details
+func (h *JobPostingHandler) UpdateJobPosting(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	postingID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid job posting ID", nil)
+		return
+	}
+
+	var posting models.JobPosting
+	if err := json.NewDecoder(r.Body).Decode(&posting); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	if strings.TrimSpace(posting.Title) == "" || strings.TrimSpace(posting.Description) == "" {
+		respondWithError(w, http.StatusBadRequest, "MISSING_FIELDS", "Title and description are required", nil)
+		return
+	}
+
+	updated, err := h.jobPostingService.UpdateJobPosting(userID, postingID, &posting)
+	if err != nil {
+		respondWithJobPostingError(w, err, "UPDATE_FAILED", "Failed to update job posting")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, updated)
+}
+
+// DeleteJobPosting deletes a saved posting
+func (h *JobPostingHandler) DeleteJobPosting(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	postingID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid job posting ID", nil)
+		return
+	}
+
+	if err := h.jobPostingService.DeleteJobPosting(userID, postingID); err != nil {
+		respondWithJobPostingError(w, err, "DELETE_FAILED", "Failed to delete job posting")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Job posting deleted successfully"})
+}
+
+// respondWithJobPostingError maps job posting errors to responses, falling
+// back to a 500 with the given code and message
+func respondWithJobPostingError(w http.ResponseWriter, err error, code, message string) {
+	switch {
+	case errors.Is(err, repository.ErrJobPostingNotFound):
+		respondWithError(w, http.StatusNotFound, "NOT_FOUND", "Job posting not found", nil)
+	case errors.Is(err, service.ErrInvalidSalaryRange), errors.Is(err, service.ErrInvalidSalaryCurrency):
+		respondWithError(w, http.StatusBadRequest, "INVALID_SALARY", err.Error(), nil)
+	case errors.Is(err, service.ErrDuplicateJobPosting):
+		respondWithError(w, http.StatusConflict, "DUPLICATE_JOB_POSTING", err.Error(), nil)
+	default:
+		respondWithError(w, http.StatusInternalServerError, code, message, nil)
+	}
+}
@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/feijoa-master/ai-resume-builder/internal/middleware"
 	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/repository"
 	"github.com/feijoa-master/ai-resume-builder/internal/service"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
@@ -21,17 +24,39 @@ func NewProfileHandler(profileService *service.ProfileService) *ProfileHandler {
 	}
 }
 
+// requestProfile returns the authenticated user and the profile the request
+// is about: the {profile_id} route variable of the /profiles/{profile_id}
+// endpoints, or uuid.Nil, meaning the default profile, on the /profile
+// endpoints. When ok is false it has already responded.
+func requestProfile(w http.ResponseWriter, r *http.Request) (userID, profileID uuid.UUID, ok bool) {
+	userID, ok = middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	if value, scoped := mux.Vars(r)["profile_id"]; scoped {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid profile ID", nil)
+			return uuid.Nil, uuid.Nil, false
+		}
+		profileID = id
+	}
+
+	return userID, profileID, true
+}
+
 // GetProfile retrieves user's profile
 func (h *ProfileHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
-	userID, ok := middleware.GetUserIDFromContext(r)
+	userID, profileID, ok := requestProfile(w, r)
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
 		return
 	}
 
-	profile, err := h.profileService.GetProfile(userID)
+	profile, err := h.profileService.GetProfile(userID, profileID)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "FETCH_FAILED", "Failed to get profile", nil)
+		respondWithProfileError(w, err, "FETCH_FAILED", "Failed to get profile")
 		return
 	}
 
@@ -40,9 +65,8 @@ func (h *ProfileHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 
 // UpdateProfile updates user's profile
 func (h *ProfileHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
-	userID, ok := middleware.GetUserIDFromContext(r)
+	userID, profileID, ok := requestProfile(w, r)
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
 		return
 	}
 
@@ -52,8 +76,8 @@ func (h *ProfileHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.profileService.UpdateProfile(userID, &profile); err != nil {
-		respondWithError(w, http.StatusInternalServerError, "UPDATE_FAILED", "Failed to update profile", nil)
+	if err := h.profileService.UpdateProfile(userID, profileID, &profile); err != nil {
+		respondWithProfileError(w, err, "UPDATE_FAILED", "Failed to update profile")
 		return
 	}
 
@@ -63,9 +87,8 @@ func (h *ProfileHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 // Experience handlers
 
 func (h *ProfileHandler) CreateExperience(w http.ResponseWriter, r *http.Request) {
-	userID, ok := middleware.GetUserIDFromContext(r)
+	userID, profileID, ok := requestProfile(w, r)
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
 		return
 	}
 
@@ -81,9 +104,9 @@ func (h *ProfileHandler) CreateExperience(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	createdExp, err := h.profileService.CreateExperience(userID, &exp)
+	createdExp, err := h.profileService.CreateExperience(userID, profileID, &exp)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "CREATE_FAILED", "Failed to create experience", nil)
+		respondWithProfileError(w, err, "CREATE_FAILED", "Failed to create experience")
 		return
 	}
 
@@ -91,15 +114,14 @@ func (h *ProfileHandler) CreateExperience(w http.ResponseWriter, r *http.Request
 }
 
 func (h *ProfileHandler) GetExperiences(w http.ResponseWriter, r *http.Request) {
-	userID, ok := middleware.GetUserIDFromContext(r)
+	userID, profileID, ok := requestProfile(w, r)
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
 		return
 	}
 
-	experiences, err := h.profileService.GetExperiences(userID)
+	experiences, err := h.profileService.GetExperiences(userID, profileID)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "FETCH_FAILED", "Failed to get experiences", nil)
+		respondWithProfileError(w, err, "FETCH_FAILED", "Failed to get experiences")
 		return
 	}
 
@@ -107,9 +129,8 @@ func (h *ProfileHandler) GetExperiences(w http.ResponseWriter, r *http.Request)
 }
 
 func (h *ProfileHandler) UpdateExperience(w http.ResponseWriter, r *http.Request) {
-	userID, ok := middleware.GetUserIDFromContext(r)
+	userID, profileID, ok := requestProfile(w, r)
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
 		return
 	}
 
@@ -126,8 +147,8 @@ func (h *ProfileHandler) UpdateExperience(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	if err := h.profileService.UpdateExperience(userID, expID, &exp); err != nil {
-		respondWithError(w, http.StatusInternalServerError, "UPDATE_FAILED", "Failed to update experience", nil)
+	if err := h.profileService.UpdateExperience(userID, profileID, expID, &exp); err != nil {
+		respondWithProfileError(w, err, "UPDATE_FAILED", "Failed to update experience")
 		return
 	}
 
@@ -135,9 +156,8 @@ func (h *ProfileHandler) UpdateExperience(w http.ResponseWriter, r *http.Request
 }
 
 func (h *ProfileHandler) DeleteExperience(w http.ResponseWriter, r *http.Request) {
-	userID, ok := middleware.GetUserIDFromContext(r)
+	userID, profileID, ok := requestProfile(w, r)
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
 		return
 	}
 
@@ -148,8 +168,8 @@ func (h *ProfileHandler) DeleteExperience(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	if err := h.profileService.DeleteExperience(userID, expID); err != nil {
-		respondWithError(w, http.StatusInternalServerError, "DELETE_FAILED", "Failed to delete experience", nil)
+	if err := h.profileService.DeleteExperience(userID, profileID, expID); err != nil {
+		respondWithProfileError(w, err, "DELETE_FAILED", "Failed to delete experience")
 		return
 	}
 
@@ -159,9 +179,8 @@ func (h *ProfileHandler) DeleteExperience(w http.ResponseWriter, r *http.Request
 // Education handlers
 
 func (h *ProfileHandler) CreateEducation(w http.ResponseWriter, r *http.Request) {
-	userID, ok := middleware.GetUserIDFromContext(r)
+	userID, profileID, ok := requestProfile(w, r)
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
 		return
 	}
 
@@ -176,9 +195,9 @@ func (h *ProfileHandler) CreateEducation(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	createdEdu, err := h.profileService.CreateEducation(userID, &edu)
+	createdEdu, err := h.profileService.CreateEducation(userID, profileID, &edu)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "CREATE_FAILED", "Failed to create education", nil)
+		respondWithProfileError(w, err, "CREATE_FAILED", "Failed to create education")
 		return
 	}
 
@@ -186,15 +205,14 @@ func (h *ProfileHandler) CreateEducation(w http.ResponseWriter, r *http.Request)
 }
 
 func (h *ProfileHandler) GetEducation(w http.ResponseWriter, r *http.Request) {
-	userID, ok := middleware.GetUserIDFromContext(r)
+	userID, profileID, ok := requestProfile(w, r)
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
 		return
 	}
 
-	education, err := h.profileService.GetEducation(userID)
+	education, err := h.profileService.GetEducation(userID, profileID)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "FETCH_FAILED", "Failed to get education", nil)
+		respondWithProfileError(w, err, "FETCH_FAILED", "Failed to get education")
 		return
 	}
 
@@ -202,9 +220,8 @@ func (h *ProfileHandler) GetEducation(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *ProfileHandler) UpdateEducation(w http.ResponseWriter, r *http.Request) {
-	userID, ok := middleware.GetUserIDFromContext(r)
+	userID, profileID, ok := requestProfile(w, r)
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
 		return
 	}
 
@@ -221,8 +238,8 @@ func (h *ProfileHandler) UpdateEducation(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if err := h.profileService.UpdateEducation(userID, eduID, &edu); err != nil {
-		respondWithError(w, http.StatusInternalServerError, "UPDATE_FAILED", "Failed to update education", nil)
+	if err := h.profileService.UpdateEducation(userID, profileID, eduID, &edu); err != nil {
+		respondWithProfileError(w, err, "UPDATE_FAILED", "Failed to update education")
 		return
 	}
 
@@ -230,9 +247,8 @@ func (h *ProfileHandler) UpdateEducation(w http.ResponseWriter, r *http.Request)
 }
 
 func (h *ProfileHandler) DeleteEducation(w http.ResponseWriter, r *http.Request) {
-	userID, ok := middleware.GetUserIDFromContext(r)
+	userID, profileID, ok := requestProfile(w, r)
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
 		return
 	}
 
@@ -243,20 +259,71 @@ func (h *ProfileHandler) DeleteEducation(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if err := h.profileService.DeleteEducation(userID, eduID); err != nil {
-		respondWithError(w, http.StatusInternalServerError, "DELETE_FAILED", "Failed to delete education", nil)
+	if err := h.profileService.DeleteEducation(userID, profileID, eduID); err != nil {
+		respondWithProfileError(w, err, "DELETE_FAILED", "Failed to delete education")
 		return
 	}
 
 	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Education deleted successfully"})
 }
 
+// ReorderExperiences saves a new display order for all experiences
+func (h *ProfileHandler) ReorderExperiences(w http.ResponseWriter, r *http.Request) {
+	userID, profileID, ok := requestProfile(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.ReorderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	experiences, err := h.profileService.ReorderExperiences(userID, profileID, req.IDs)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidOrder) {
+			respondWithError(w, http.StatusBadRequest, "INVALID_ORDER", "IDs must list every experience exactly once", nil)
+			return
+		}
+		respondWithProfileError(w, err, "UPDATE_FAILED", "Failed to reorder experiences")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, experiences)
+}
+
+// ReorderEducation saves a new display order for all education entries
+func (h *ProfileHandler) ReorderEducation(w http.ResponseWriter, r *http.Request) {
+	userID, profileID, ok := requestProfile(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.ReorderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	education, err := h.profileService.ReorderEducation(userID, profileID, req.IDs)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidOrder) {
+			respondWithError(w, http.StatusBadRequest, "INVALID_ORDER", "IDs must list every education entry exactly once", nil)
+			return
+		}
+		respondWithProfileError(w, err, "UPDATE_FAILED", "Failed to reorder education")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, education)
+}
+
 // Skills handlers
 
 func (h *ProfileHandler) CreateSkill(w http.ResponseWriter, r *http.Request) {
-	userID, ok := middleware.GetUserIDFromContext(r)
+	userID, profileID, ok := requestProfile(w, r)
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
 		return
 	}
 
@@ -271,9 +338,9 @@ func (h *ProfileHandler) CreateSkill(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	createdSkill, err := h.profileService.CreateSkill(userID, &skill)
+	createdSkill, err := h.profileService.CreateSkill(userID, profileID, &skill)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "CREATE_FAILED", "Failed to create skill", nil)
+		respondWithSkillError(w, err, &skill, "CREATE_FAILED", "Failed to create skill")
 		return
 	}
 
@@ -281,25 +348,118 @@ func (h *ProfileHandler) CreateSkill(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *ProfileHandler) GetSkills(w http.ResponseWriter, r *http.Request) {
-	userID, ok := middleware.GetUserIDFromContext(r)
+	userID, profileID, ok := requestProfile(w, r)
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
 		return
 	}
 
-	skills, err := h.profileService.GetSkills(userID)
+	// ?group_by=category|group returns groups instead of a flat list
+	if by := r.URL.Query().Get("group_by"); by != "" {
+		groups, err := h.profileService.GetSkillGroups(userID, profileID, by)
+		if err != nil {
+			if err == service.ErrInvalidSkillGrouping {
+				respondWithError(w, http.StatusBadRequest, "INVALID_GROUP_BY", err.Error(), nil)
+				return
+			}
+			respondWithProfileError(w, err, "FETCH_FAILED", "Failed to get skills")
+			return
+		}
+		respondWithJSON(w, http.StatusOK, groups)
+		return
+	}
+
+	skills, err := h.profileService.GetSkills(userID, profileID)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "FETCH_FAILED", "Failed to get skills", nil)
+		respondWithProfileError(w, err, "FETCH_FAILED", "Failed to get skills")
 		return
 	}
 
 	respondWithJSON(w, http.StatusOK, skills)
 }
 
+// UpdateSkill updates a single skill
+func (h *ProfileHandler) UpdateSkill(w http.ResponseWriter, r *http.Request) {
+	userID, profileID, ok := requestProfile(w, r)
+	if !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+	skillID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid skill ID", nil)
+		return
+	}
+
+	var skill models.Skill
+	if err := json.NewDecoder(r.Body).Decode(&skill); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	if skill.Name == "" {
+		respondWithError(w, http.StatusBadRequest, "MISSING_FIELDS", "Skill name is required", nil)
+		return
+	}
+
+	if err := h.profileService.UpdateSkill(userID, profileID, skillID, &skill); err != nil {
+		if err == repository.ErrUserNotFound {
+			respondWithError(w, http.StatusNotFound, "NOT_FOUND", "Skill not found", nil)
+			return
+		}
+		respondWithSkillError(w, err, &skill, "UPDATE_FAILED", "Failed to update skill")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, skill)
+}
+
+// ReplaceSkills saves the whole skills list in the given display order
+func (h *ProfileHandler) ReplaceSkills(w http.ResponseWriter, r *http.Request) {
+	userID, profileID, ok := requestProfile(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.ReplaceSkillsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Skills == nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	skills, err := h.profileService.ReplaceSkills(userID, profileID, req.Skills)
+	if err != nil {
+		if err == service.ErrMissingSkillName {
+			respondWithError(w, http.StatusBadRequest, "MISSING_FIELDS", "Skill name is required", nil)
+			return
+		}
+		respondWithSkillError(w, err, nil, "UPDATE_FAILED", "Failed to save skills")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, skills)
+}
+
+// respondWithSkillError maps skill validation errors to 400/409 and anything
+// else to a 500 with the given code and message
+func respondWithSkillError(w http.ResponseWriter, err error, skill *models.Skill, code, message string) {
+	switch err {
+	case service.ErrInvalidSkillCategory, service.ErrInvalidProficiencyLevel:
+		respondWithError(w, http.StatusBadRequest, "INVALID_SKILL", err.Error(), nil)
+	case service.ErrDuplicateSkill:
+		if skill != nil {
+			respondWithError(w, http.StatusConflict, "DUPLICATE_SKILL", "Skill \""+skill.Name+"\" is already in your profile", nil)
+			return
+		}
+		respondWithError(w, http.StatusConflict, "DUPLICATE_SKILL", "Skills list contains the same skill twice", nil)
+	default:
+		respondWithProfileError(w, err, code, message)
+	}
+}
+
 func (h *ProfileHandler) DeleteSkill(w http.ResponseWriter, r *http.Request) {
-	userID, ok := middleware.GetUserIDFromContext(r)
+	userID, profileID, ok := requestProfile(w, r)
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
 		return
 	}
 
@@ -310,10 +470,25 @@ func (h *ProfileHandler) DeleteSkill(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.profileService.DeleteSkill(userID, skillID); err != nil {
-		respondWithError(w, http.StatusInternalServerError, "DELETE_FAILED", "Failed to delete skill", nil)
+	if err := h.profileService.DeleteSkill(userID, profileID, skillID); err != nil {
+		respondWithProfileError(w, err, "DELETE_FAILED", "Failed to delete skill")
 		return
 	}
 
 	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Skill deleted successfully"})
 }
+
+// SuggestSkills autocompletes skill names from the skills taxonomy
+func (h *ProfileHandler) SuggestSkills(w http.ResponseWriter, r *http.Request) {
+	limit := 10
+	if value := r.URL.Query().Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > 50 {
+			respondWithError(w, http.StatusBadRequest, "INVALID_LIMIT", "Limit must be between 1 and 50", nil)
+			return
+		}
+		limit = n
+	}
+
+	respondWithJSON(w, http.StatusOK, h.profileService.SuggestSkills(r.URL.Query().Get("q"), limit))
+}
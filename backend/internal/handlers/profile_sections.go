@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/repository"
+	"github.com/feijoa-master/ai-resume-builder/internal/service"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// Projects handlers
+
+func (h *ProfileHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
+	createSectionItem(w, r, "Project", validateProject, h.profileService.CreateProject)
+}
+
+func (h *ProfileHandler) GetProjects(w http.ResponseWriter, r *http.Request) {
+	listSectionItems(w, r, "projects", h.profileService.GetProjects)
+}
+
+func (h *ProfileHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	updateSectionItem(w, r, "Project", validateProject, h.profileService.UpdateProject)
+}
+
+func (h *ProfileHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	deleteSectionItem(w, r, "Project", h.profileService.DeleteProject)
+}
+
+func validateProject(project *models.Project) string {
+	if project.Name == "" {
+		return "Project name is required"
+	}
+	return ""
+}
+
+// Certifications handlers
+
+func (h *ProfileHandler) CreateCertification(w http.ResponseWriter, r *http.Request) {
+	createSectionItem(w, r, "Certification", validateCertification, h.profileService.CreateCertification)
+}
+
+func (h *ProfileHandler) GetCertifications(w http.ResponseWriter, r *http.Request) {
+	listSectionItems(w, r, "certifications", h.profileService.GetCertifications)
+}
+
+func (h *ProfileHandler) UpdateCertification(w http.ResponseWriter, r *http.Request) {
+	updateSectionItem(w, r, "Certification", validateCertification, h.profileService.UpdateCertification)
+}
+
+func (h *ProfileHandler) DeleteCertification(w http.ResponseWriter, r *http.Request) {
+	deleteSectionItem(w, r, "Certification", h.profileService.DeleteCertification)
+}
+
+func validateCertification(cert *models.Certification) string {
+	if cert.Name == "" || cert.Issuer == "" {
+		return "Certification name and issuer are required"
+	}
+	if cert.IssueDate.Valid && cert.ExpiryDate.Valid && cert.ExpiryDate.Time.Before(cert.IssueDate.Time) {
+		return "Expiry date must not be before the issue date"
+	}
+	return ""
+}
+
+// Publications handlers
+
+func (h *ProfileHandler) CreatePublication(w http.ResponseWriter, r *http.Request) {
+	createSectionItem(w, r, "Publication", validatePublication, h.profileService.CreatePublication)
+}
+
+func (h *ProfileHandler) GetPublications(w http.ResponseWriter, r *http.Request) {
+	listSectionItems(w, r, "publications", h.profileService.GetPublications)
+}
+
+func (h *ProfileHandler) UpdatePublication(w http.ResponseWriter, r *http.Request) {
+	updateSectionItem(w, r, "Publication", validatePublication, h.profileService.UpdatePublication)
+}
+
+func (h *ProfileHandler) DeletePublication(w http.ResponseWriter, r *http.Request) {
+	deleteSectionItem(w, r, "Publication", h.profileService.DeletePublication)
+}
+
+func validatePublication(pub *models.Publication) string {
+	if pub.Title == "" {
+		return "Publication title is required"
+	}
+	return ""
+}
+
+// Languages handlers
+
+func (h *ProfileHandler) CreateLanguage(w http.ResponseWriter, r *http.Request) {
+	createSectionItem(w, r, "Language", validateLanguage, h.profileService.CreateLanguage)
+}
+
+func (h *ProfileHandler) GetLanguages(w http.ResponseWriter, r *http.Request) {
+	listSectionItems(w, r, "languages", h.profileService.GetLanguages)
+}
+
+func (h *ProfileHandler) UpdateLanguage(w http.ResponseWriter, r *http.Request) {
+	updateSectionItem(w, r, "Language", validateLanguage, h.profileService.UpdateLanguage)
+}
+
+func (h *ProfileHandler) DeleteLanguage(w http.ResponseWriter, r *http.Request) {
+	deleteSectionItem(w, r, "Language", h.profileService.DeleteLanguage)
+}
+
+func validateLanguage(lang *models.Language) string {
+	if lang.Name == "" || lang.Level == "" {
+		return "Language name and level are required"
+	}
+	return ""
+}
+
+// Volunteering handlers
+
+func (h *ProfileHandler) CreateVolunteering(w http.ResponseWriter, r *http.Request) {
+	createSectionItem(w, r, "Volunteering", validateVolunteering, h.profileService.CreateVolunteering)
+}
+
+func (h *ProfileHandler) GetVolunteering(w http.ResponseWriter, r *http.Request) {
+	listSectionItems(w, r, "volunteering", h.profileService.GetVolunteering)
+}
+
+func (h *ProfileHandler) UpdateVolunteering(w http.ResponseWriter, r *http.Request) {
+	updateSectionItem(w, r, "Volunteering", validateVolunteering, h.profileService.UpdateVolunteering)
+}
+
+func (h *ProfileHandler) DeleteVolunteering(w http.ResponseWriter, r *http.Request) {
+	deleteSectionItem(w, r, "Volunteering", h.profileService.DeleteVolunteering)
+}
+
+func validateVolunteering(vol *models.Volunteering) string {
+	if vol.Organization == "" || vol.Role == "" || vol.StartDate.IsZero() {
+		return "Organization, role and start date are required"
+	}
+	return ""
+}
+
+// Awards handlers
+
+func (h *ProfileHandler) CreateAward(w http.ResponseWriter, r *http.Request) {
+	createSectionItem(w, r, "Award", validateAward, h.profileService.CreateAward)
+}
+
+func (h *ProfileHandler) GetAwards(w http.ResponseWriter, r *http.Request) {
+	listSectionItems(w, r, "awards", h.profileService.GetAwards)
+}
+
+func (h *ProfileHandler) UpdateAward(w http.ResponseWriter, r *http.Request) {
+	updateSectionItem(w, r, "Award", validateAward, h.profileService.UpdateAward)
+}
+
+func (h *ProfileHandler) DeleteAward(w http.ResponseWriter, r *http.Request) {
+	deleteSectionItem(w, r, "Award", h.profileService.DeleteAward)
+}
+
+func validateAward(award *models.Award) string {
+	if award.Title == "" {
+		return "Award title is required"
+	}
+	return ""
+}
+
+// The helpers below implement the CRUD endpoints shared by all optional
+// profile sections. validate returns a message for missing or invalid
+// fields, or "" when the item is valid.
+
+func createSectionItem[T any](w http.ResponseWriter, r *http.Request, name string, validate func(*T) string, create func(uuid.UUID, uuid.UUID, *T) (*T, error)) {
+	userID, profileID, ok := requestProfile(w, r)
+	if !ok {
+		return
+	}
+
+	var item T
+	if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	if message := validate(&item); message != "" {
+		respondWithError(w, http.StatusBadRequest, "MISSING_FIELDS", message, nil)
+		return
+	}
+
+	created, err := create(userID, profileID, &item)
+	if err != nil {
+		if err == service.ErrInvalidLanguageLevel {
+			respondWithError(w, http.StatusBadRequest, "INVALID_LEVEL", err.Error(), nil)
+			return
+		}
+		respondWithProfileError(w, err, "CREATE_FAILED", "Failed to create "+strings.ToLower(name))
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, created)
+}
+
+func listSectionItems[T any](w http.ResponseWriter, r *http.Request, name string, list func(uuid.UUID, uuid.UUID) ([]*T, error)) {
+	userID, profileID, ok := requestProfile(w, r)
+	if !ok {
+		return
+	}
+
+	items, err := list(userID, profileID)
+	if err != nil {
+		respondWithProfileError(w, err, "FETCH_FAILED", "Failed to get "+name)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, items)
+}
+
+func updateSectionItem[T any](w http.ResponseWriter, r *http.Request, name string, validate func(*T) string, update func(uuid.UUID, uuid.UUID, uuid.UUID, *T) error) {
+	userID, profileID, ok := requestProfile(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid "+strings.ToLower(name)+" ID", nil)
+		return
+	}
+
+	var item T
+	if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	if message := validate(&item); message != "" {
+		respondWithError(w, http.StatusBadRequest, "MISSING_FIELDS", message, nil)
+		return
+	}
+
+	if err := update(userID, profileID, id, &item); err != nil {
+		switch err {
+		case repository.ErrUserNotFound:
+			respondWithError(w, http.StatusNotFound, "NOT_FOUND", name+" not found", nil)
+		case service.ErrInvalidLanguageLevel:
+			respondWithError(w, http.StatusBadRequest, "INVALID_LEVEL", err.Error(), nil)
+		default:
+			respondWithProfileError(w, err, "UPDATE_FAILED", "Failed to update "+strings.ToLower(name))
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]string{"message": name + " updated successfully"})
+}
+
+func deleteSectionItem(w http.ResponseWriter, r *http.Request, name string, remove func(uuid.UUID, uuid.UUID, uuid.UUID) error) {
+	userID, profileID, ok := requestProfile(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid "+strings.ToLower(name)+" ID", nil)
+		return
+	}
+
+	if err := remove(userID, profileID, id); err != nil {
+		if err == repository.ErrUserNotFound {
+			respondWithError(w, http.StatusNotFound, "NOT_FOUND", name+" not found", nil)
+			return
+		}
+		respondWithProfileError(w, err, "DELETE_FAILED", "Failed to delete "+strings.ToLower(name))
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]string{"message": name + " deleted successfully"})
+}
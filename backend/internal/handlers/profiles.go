@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/middleware"
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/repository"
+	"github.com/feijoa-master/ai-resume-builder/internal/service"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// Named profile handlers
+
+// ListProfiles lists all of the user's profiles, the default one first
+func (h *ProfileHandler) ListProfiles(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	profiles, err := h.profileService.GetProfiles(userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "FETCH_FAILED", "Failed to get profiles", nil)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, profiles)
+}
+
+// CreateProfile adds an empty named profile
+func (h *ProfileHandler) CreateProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	var req models.ProfileNameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	profile, err := h.profileService.CreateProfile(userID, req.Name)
+	if err != nil {
+		respondWithProfileError(w, err, "CREATE_FAILED", "Failed to create profile")
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, profile)
+}
+
+// CopyProfile adds a named profile holding a copy of another one
+func (h *ProfileHandler) CopyProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	profileID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid profile ID", nil)
+		return
+	}
+
+	var req models.ProfileNameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	profile, err := h.profileService.CopyProfile(userID, profileID, req.Name)
+	if err != nil {
+		respondWithProfileError(w, err, "CREATE_FAILED", "Failed to copy profile")
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, profile)
+}
+
+// RenameProfile renames a profile
+func (h *ProfileHandler) RenameProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	profileID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid profile ID", nil)
+		return
+	}
+
+	var req models.ProfileNameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	profile, err := h.profileService.RenameProfile(userID, profileID, req.Name)
+	if err != nil {
+		respondWithProfileError(w, err, "UPDATE_FAILED", "Failed to rename profile")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, profile)
+}
+
+// SetDefaultProfile makes a profile the default one
+func (h *ProfileHandler) SetDefaultProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	profileID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid profile ID", nil)
+		return
+	}
+
+	profile, err := h.profileService.SetDefaultProfile(userID, profileID)
+	if err != nil {
+		respondWithProfileError(w, err, "UPDATE_FAILED", "Failed to set default profile")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, profile)
+}
+
+// DeleteProfile deletes a profile other than the default one
+func (h *ProfileHandler) DeleteProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	profileID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid profile ID", nil)
+		return
+	}
+
+	if err := h.profileService.DeleteProfile(userID, profileID); err != nil {
+		respondWithProfileError(w, err, "DELETE_FAILED", "Failed to delete profile")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Profile deleted successfully"})
+}
+
+// respondWithProfileError maps named profile errors to responses, falling
+// back to a 500 with the given code and message
+func respondWithProfileError(w http.ResponseWriter, err error, code, message string) {
+	switch {
+	case errors.Is(err, repository.ErrProfileNotFound):
+		respondWithError(w, http.StatusNotFound, "NOT_FOUND", "Profile not found", nil)
+	case errors.Is(err, service.ErrMissingProfileName):
+		respondWithError(w, http.StatusBadRequest, "MISSING_FIELDS", "Profile name is required", nil)
+	case errors.Is(err, service.ErrProfileNameTooLong):
+		respondWithError(w, http.StatusBadRequest, "INVALID_NAME", err.Error(), nil)
+	case errors.Is(err, service.ErrDuplicateProfileName):
+		respondWithError(w, http.StatusConflict, "DUPLICATE_NAME", err.Error(), nil)
+	case errors.Is(err, service.ErrDeleteDefaultProfile):
+		respondWithError(w, http.StatusConflict, "DEFAULT_PROFILE", err.Error(), nil)
+	default:
+		respondWithError(w, http.StatusInternalServerError, code, message, nil)
+	}
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/middleware"
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/repository"
+	"github.com/feijoa-master/ai-resume-builder/internal/service"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type ReminderHandler struct {
+	reminderService *service.ReminderService
+}
+
+func NewReminderHandler(reminderService *service.ReminderService) *ReminderHandler {
+	return &ReminderHandler{
+		reminderService: reminderService,
+	}
+}
+
+// GetFollowUpRules lists the follow-up rules of an application
+func (h *ReminderHandler) GetFollowUpRules(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	appID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid application ID", nil)
+		return
+	}
+
+	rules, err := h.reminderService.GetFollowUpRules(userID, appID)
+	if err != nil {
+		respondWithReminderError(w, err, "FETCH_FAILED", "Failed to get follow-up rules")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, rules)
+}
+
+// ReplaceFollowUpRules replaces the follow-up rules of an application
+func (h *ReminderHandler) ReplaceFollowUpRules(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	appID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid application ID", nil)
+		return
+	}
+
+	var req models.FollowUpRulesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+
+	rules, err := h.reminderService.ReplaceFollowUpRules(userID, appID, req.Rules)
+	if err != nil {
+		respondWithReminderError(w, err, "UPDATE_FAILED", "Failed to update follow-up rules")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, rules)
+}
+
+// GetReminders lists the user's reminders. Dismissed reminders are included
+// with include_dismissed=true.
+func (h *ReminderHandler) GetReminders(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	includeDismissed := false
+	if value := r.URL.Query().Get("include_dismissed"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "include_dismissed must be true or false", nil)
+			return
+		}
+		includeDismissed = parsed
+	}
+
+	reminders, err := h.reminderService.GetReminders(userID, includeDismissed)
+	if err != nil {
+		respondWithReminderError(w, err, "FETCH_FAILED", "Failed to get reminders")
+		return
+	}
+
+	if reminders == nil {
+		reminders = []*models.Reminder{}
+	}
+
+	respondWithJSON(w, http.StatusOK, reminders)
+}
+
+// DismissReminder hides a reminder from the list
+func (h *ReminderHandler) DismissReminder(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated", nil)
+		return
+	}
+
+	reminderID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_ID", "Invalid reminder ID", nil)
+		return
+	}
+
+	if err := h.reminderService.DismissReminder(userID, reminderID); err != nil {
+		respondWithReminderError(w, err, "UPDATE_FAILED", "Failed to dismiss reminder")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Reminder dismissed"})
+}
+
+// respondWithReminderError maps reminder errors to responses, falling back
+// to a 500 with the given code and message
+func respondWithReminderError(w http.ResponseWriter, err error, code, message string) {
+	switch {
+	case errors.Is(err, repository.ErrApplicationNotFound):
+		respondWithError(w, http.StatusNotFound, "NOT_FOUND", "Application not found", nil)
+	case errors.Is(err, repository.ErrReminderNotFound):
+		respondWithError(w, http.StatusNotFound, "NOT_FOUND", "Reminder not found", nil)
+	case errors.Is(err, service.ErrInvalidFollowUpStatus),
+		errors.Is(err, service.ErrInvalidFollowUpDays),
+		errors.Is(err, service.ErrDuplicateFollowUpRule):
+		respondWithError(w, http.StatusBadRequest, "INVALID_RULE", err.Error(), nil)
+	default:
+		respondWithError(w, http.StatusInternalServerError, code, message, nil)
+	}
+}
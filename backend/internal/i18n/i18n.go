@@ -0,0 +1,157 @@
+// Package i18n holds the languages documents can be generated in, their
+// date formats and the translations of API error messages.
+//
+// Language tags are BCP 47 style, e.g. ru or de-AT; only the base language
+// decides the formatting and the translations, the full tag is passed on to
+// the prompt registry, which may have region-specific prompts.
+package i18n
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default is the language used when none is given or none is supported
+const Default = "en"
+
+var ErrUnsupportedLanguage = errors.New("unsupported language: use one of en, ru, de, fr, es")
+
+// language describes one supported output language
+type language struct {
+	name    string     // English name, used in prompts
+	months  [12]string // short month names
+	present string     // end of a period that is still ongoing
+}
+
+var languages = map[string]language{
+	"en": {
+		name:    "English",
+		months:  [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		present: "Present",
+	},
+	"ru": {
+		name:    "Russian",
+		months:  [12]string{"янв.", "февр.", "март", "апр.", "май", "июнь", "июль", "авг.", "сент.", "окт.", "нояб.", "дек."},
+		present: "настоящее время",
+	},
+	"de": {
+		name:    "German",
+		months:  [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		present: "heute",
+	},
+	"fr": {
+		name:    "French",
+		months:  [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		present: "aujourd'hui",
+	},
+	"es": {
+		name:    "Spanish",
+		months:  [12]string{"ene.", "feb.", "mar.", "abr.", "may.", "jun.", "jul.", "ago.", "sept.", "oct.", "nov.", "dic."},
+		present: "actualidad",
+	},
+}
+
+// Normalize checks that a language tag has a supported base language and
+// returns it lower-cased with a hyphen, e.g. de_AT becomes de-at. An empty
+// tag stays empty.
+func Normalize(tag string) (string, error) {
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if tag == "" {
+		return "", nil
+	}
+	if _, ok := languages[Base(tag)]; !ok {
+		return "", ErrUnsupportedLanguage
+	}
+	return tag, nil
+}
+
+// Base returns the language of a tag without region, e.g. de for de-AT
+func Base(tag string) string {
+	base, _, _ := strings.Cut(strings.ToLower(strings.ReplaceAll(tag, "_", "-")), "-")
+	return base
+}
+
+// Name returns the English name of a tag's language, or an empty string for
+// an empty or unsupported tag
+func Name(tag string) string {
+	return languages[Base(tag)].name
+}
+
+// lookup returns the language of a tag, falling back to English
+func lookup(tag string) language {
+	if l, ok := languages[Base(tag)]; ok {
+		return l
+	}
+	return languages[Default]
+}
+
+// FormatMonth formats a date as short month and year, e.g. "Mar 2021" or
+// "март 2021"
+func FormatMonth(t time.Time, tag string) string {
+	return lookup(tag).months[t.Month()-1] + " " + strconv.Itoa(t.Year())
+}
+
+// Present returns the word ending a period that is still ongoing, e.g.
+// "Present" or "heute"
+func Present(tag string) string {
+	return lookup(tag).present
+}
+
+// FormatPeriod formats a start and end date, e.g. "Jan 2020 – Mar 2022". A
+// current period, or one without an end, ends in the language's word for
+// present; a zero start gives an empty period.
+func FormatPeriod(start, end time.Time, current bool, tag string) string {
+	if start.IsZero() {
+		return ""
+	}
+
+	to := Present(tag)
+	if !current && !end.IsZero() {
+		to = FormatMonth(end, tag)
+	}
+	return FormatMonth(start, tag) + " – " + to
+}
+
+// ParseAcceptLanguage picks the supported language the client prefers most
+// from an Accept-Language header, or Default
+func ParseAcceptLanguage(header string) string {
+	type choice struct {
+		base string
+		q    float64
+	}
+
+	var choices []choice
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if _, ok := languages[Base(tag)]; ok && q > 0 {
+			choices = append(choices, choice{Base(tag), q})
+		}
+	}
+	if len(choices) == 0 {
+		return Default
+	}
+
+	// Stable, so equal weights keep the client's order
+	sort.SliceStable(choices, func(i, j int) bool { return choices[i].q > choices[j].q })
+	return choices[0].base
+}
+
+// Message translates an English API message. Messages without a translation,
+// and all messages for English, are returned unchanged.
+func Message(tag, message string) string {
+	if translated, ok := catalogs[Base(tag)][message]; ok {
+		return translated
+	}
+	return message
+}
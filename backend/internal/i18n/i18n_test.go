@@ -0,0 +1,96 @@
+package i18n
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+		err  error
+	}{
+		{"", "", nil},
+		{"ru", "ru", nil},
+		{" DE_at ", "de-at", nil},
+		{"fr-CA", "fr-ca", nil},
+		{"xx", "", ErrUnsupportedLanguage},
+		{"english", "", ErrUnsupportedLanguage},
+	}
+	for _, tt := range tests {
+		got, err := Normalize(tt.tag)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("Normalize(%q) = %q, %v, want %q, %v", tt.tag, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestFormatPeriod(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2022, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		end     time.Time
+		current bool
+		tag     string
+		want    string
+	}{
+		{"english", end, false, "en", "Jan 2020 – Mar 2022"},
+		{"no language", end, false, "", "Jan 2020 – Mar 2022"},
+		{"russian", end, false, "ru", "янв. 2020 – март 2022"},
+		{"german region", end, false, "de-AT", "Jan. 2020 – März 2022"},
+		{"current", end, true, "fr", "janv. 2020 – aujourd'hui"},
+		{"no end", time.Time{}, false, "es", "ene. 2020 – actualidad"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatPeriod(start, tt.end, tt.current, tt.tag); got != tt.want {
+				t.Errorf("FormatPeriod = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := FormatPeriod(time.Time{}, end, false, "en"); got != "" {
+		t.Errorf("FormatPeriod without start = %q, want empty", got)
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", "en"},
+		{"ru", "ru"},
+		{"ru-RU,ru;q=0.9,en-US;q=0.8,en;q=0.7", "ru"},
+		{"en;q=0.5, de-CH", "de"},
+		{"ja, fr;q=0.3", "fr"},
+		{"fr;q=0.8, es;q=0.8", "fr"},
+		{"ru;q=0, en;q=0.1", "en"},
+		{"ru;q=abc", "en"},
+		{"*", "en"},
+	}
+	for _, tt := range tests {
+		if got := ParseAcceptLanguage(tt.header); got != tt.want {
+			t.Errorf("ParseAcceptLanguage(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestMessage(t *testing.T) {
+	if got := Message("ru-RU", "Document not found"); got != "Документ не найден" {
+		t.Errorf("Message(ru) = %q", got)
+	}
+	if got := Message("en", "Document not found"); got != "Document not found" {
+		t.Errorf("Message(en) = %q", got)
+	}
+	if got := Message("ru", "No such message"); got != "No such message" {
+		t.Errorf("Message without translation = %q", got)
+	}
+	if got := Message("ru", ErrUnsupportedLanguage.Error()); got == ErrUnsupportedLanguage.Error() {
+		t.Error("ErrUnsupportedLanguage has no Russian translation")
+	}
+}
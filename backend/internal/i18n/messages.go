@@ -0,0 +1,196 @@
+package i18n
+
+// catalogs translates API error messages, keyed by the English message.
+// Messages built from user input, such as validation errors naming a field
+// value, stay in English.
+var catalogs = map[string]map[string]string{
+	"ru": {
+		// Authentication
+		"Authorization header is required":             "Требуется заголовок Authorization",
+		"Authorization header must be Bearer token":    "Заголовок Authorization должен содержать Bearer-токен",
+		"Token has expired":                            "Срок действия токена истёк",
+		"Invalid token":                                "Недействительный токен",
+		"This feature requires a premium subscription": "Эта функция доступна только с премиум-подпиской",
+		"This endpoint is for administrators only":     "Этот раздел доступен только администраторам",
+		"User not authenticated":                       "Пользователь не авторизован",
+		"Email already registered":                     "Этот email уже зарегистрирован",
+		"Email and password are required":              "Укажите email и пароль",
+		"Email, password, and full name are required":  "Укажите email, пароль и полное имя",
+		"Invalid email or password":                    "Неверный email или пароль",
+		"Invalid or expired refresh token":             "Refresh-токен недействителен или истёк",
+		"Refresh token is required":                    "Требуется refresh-токен",
+		"Failed to login":                              "Не удалось войти",
+		"Failed to register user":                      "Не удалось зарегистрировать пользователя",
+
+		// Requests
+		"Invalid request body":                    "Некорректное тело запроса",
+		"index must be a number":                  "index должен быть числом",
+		"include_dismissed must be true or false": "include_dismissed должен быть true или false",
+		"Limit must be between 1 and 50":          "Limit должен быть от 1 до 50",
+
+		// Profiles
+		"Profile not found":             "Профиль не найден",
+		"Invalid profile ID":            "Некорректный ID профиля",
+		"Profile name is required":      "Укажите название профиля",
+		"Failed to get profile":         "Не удалось получить профиль",
+		"Failed to get profiles":        "Не удалось получить профили",
+		"Failed to create profile":      "Не удалось создать профиль",
+		"Failed to update profile":      "Не удалось обновить профиль",
+		"Failed to rename profile":      "Не удалось переименовать профиль",
+		"Failed to copy profile":        "Не удалось скопировать профиль",
+		"Failed to delete profile":      "Не удалось удалить профиль",
+		"Failed to set default profile": "Не удалось сделать профиль основным",
+
+		// Experience and education
+		"Company and position are required":                "Укажите компанию и должность",
+		"Invalid experience ID":                            "Некорректный ID опыта работы",
+		"Failed to get experiences":                        "Не удалось получить опыт работы",
+		"Failed to create experience":                      "Не удалось добавить опыт работы",
+		"Failed to update experience":                      "Не удалось обновить опыт работы",
+		"Failed to delete experience":                      "Не удалось удалить опыт работы",
+		"Failed to reorder experiences":                    "Не удалось изменить порядок опыта работы",
+		"IDs must list every experience exactly once":      "В списке ID каждый опыт работы должен встречаться ровно один раз",
+		"Institution and degree are required":              "Укажите учебное заведение и степень",
+		"Invalid education ID":                             "Некорректный ID образования",
+		"Failed to get education":                          "Не удалось получить образование",
+		"Failed to create education":                       "Не удалось добавить образование",
+		"Failed to update education":                       "Не удалось обновить образование",
+		"Failed to delete education":                       "Не удалось удалить образование",
+		"Failed to reorder education":                      "Не удалось изменить порядок образования",
+		"IDs must list every education entry exactly once": "В списке ID каждая запись об образовании должна встречаться ровно один раз",
+
+		// Skills
+		"Skill not found":                           "Навык не найден",
+		"Invalid skill ID":                          "Некорректный ID навыка",
+		"Skill name is required":                    "Укажите название навыка",
+		"Skills list contains the same skill twice": "Список содержит один и тот же навык дважды",
+		"Failed to get skills":                      "Не удалось получить навыки",
+		"Failed to create skill":                    "Не удалось добавить навык",
+		"Failed to update skill":                    "Не удалось обновить навык",
+		"Failed to delete skill":                    "Не удалось удалить навык",
+		"Failed to save skills":                     "Не удалось сохранить навыки",
+
+		// Optional profile sections
+		"Failed to get projects":                         "Не удалось получить проекты",
+		"Failed to get certifications":                   "Не удалось получить сертификаты",
+		"Failed to get publications":                     "Не удалось получить публикации",
+		"Failed to get languages":                        "Не удалось получить языки",
+		"Failed to get volunteering":                     "Не удалось получить волонтёрский опыт",
+		"Failed to get awards":                           "Не удалось получить награды",
+		"Project name is required":                       "Укажите название проекта",
+		"Certification name and issuer are required":     "Укажите название сертификата и организацию, выдавшую его",
+		"Expiry date must not be before the issue date":  "Дата окончания действия не может быть раньше даты выдачи",
+		"Publication title is required":                  "Укажите название публикации",
+		"Language name and level are required":           "Укажите язык и уровень владения",
+		"Organization, role and start date are required": "Укажите организацию, роль и дату начала",
+		"Award title is required":                        "Укажите название награды",
+		"Project not found":                              "Проект не найден",
+		"Invalid project ID":                             "Некорректный ID проекта",
+		"Failed to create project":                       "Не удалось добавить проект",
+		"Failed to update project":                       "Не удалось обновить проект",
+		"Failed to delete project":                       "Не удалось удалить проект",
+		"Certification not found":                        "Сертификат не найден",
+		"Invalid certification ID":                       "Некорректный ID сертификата",
+		"Failed to create certification":                 "Не удалось добавить сертификат",
+		"Failed to update certification":                 "Не удалось обновить сертификат",
+		"Failed to delete certification":                 "Не удалось удалить сертификат",
+		"Publication not found":                          "Публикация не найдена",
+		"Invalid publication ID":                         "Некорректный ID публикации",
+		"Failed to create publication":                   "Не удалось добавить публикацию",
+		"Failed to update publication":                   "Не удалось обновить публикацию",
+		"Failed to delete publication":                   "Не удалось удалить публикацию",
+		"Language not found":                             "Язык не найден",
+		"Invalid language ID":                            "Некорректный ID языка",
+		"Failed to create language":                      "Не удалось добавить язык",
+		"Failed to update language":                      "Не удалось обновить язык",
+		"Failed to delete language":                      "Не удалось удалить язык",
+		"Volunteering not found":                         "Волонтёрский опыт не найден",
+		"Invalid volunteering ID":                        "Некорректный ID волонтёрского опыта",
+		"Failed to create volunteering":                  "Не удалось добавить волонтёрский опыт",
+		"Failed to update volunteering":                  "Не удалось обновить волонтёрский опыт",
+		"Failed to delete volunteering":                  "Не удалось удалить волонтёрский опыт",
+		"Award not found":                                "Награда не найдена",
+		"Invalid award ID":                               "Некорректный ID награды",
+		"Failed to create award":                         "Не удалось добавить награду",
+		"Failed to update award":                         "Не удалось обновить награду",
+		"Failed to delete award":                         "Не удалось удалить награду",
+
+		// Generation and documents
+		"No free generations left. Please upgrade to premium.":       "Бесплатные генерации закончились. Перейдите на премиум-подписку.",
+		"Job description is required":                                "Укажите описание вакансии",
+		"Job description or document ID is required":                 "Укажите описание вакансии или ID документа",
+		"Job description or job posting ID is required":              "Укажите описание вакансии или ID сохранённой вакансии",
+		"Failed to generate resume":                                  "Не удалось сгенерировать резюме",
+		"Failed to generate cover letter":                            "Не удалось сгенерировать сопроводительное письмо",
+		"Failed to analyze job description":                          "Не удалось проанализировать описание вакансии",
+		"Failed to analyze skill gap":                                "Не удалось сравнить навыки с вакансией",
+		"AI generation is temporarily unavailable, try again later":  "Генерация временно недоступна, попробуйте позже",
+		"Document not found":                                         "Документ не найден",
+		"Invalid document ID":                                        "Некорректный ID документа",
+		"Failed to get documents":                                    "Не удалось получить документы",
+		"Failed to update document":                                  "Не удалось обновить документ",
+		"Failed to delete document":                                  "Не удалось удалить документ",
+		"Failed to score document":                                   "Не удалось оценить документ",
+		"Section is required":                                        "Укажите раздел",
+		"Failed to regenerate section":                               "Не удалось перегенерировать раздел",
+		"Message is required":                                        "Введите сообщение",
+		"Failed to get messages":                                     "Не удалось получить сообщения",
+		"Failed to refine document":                                  "Не удалось доработать документ",
+		"Language is required":                                       "Укажите язык",
+		"Document is already in that language":                       "Документ уже на этом языке",
+		"Failed to translate document":                               "Не удалось перевести документ",
+		"Document version not found":                                 "Версия документа не найдена",
+		"Invalid version number":                                     "Некорректный номер версии",
+		"Failed to get document version":                             "Не удалось получить версию документа",
+		"Failed to get document versions":                            "Не удалось получить версии документа",
+		"Failed to compare document versions":                        "Не удалось сравнить версии документа",
+		"Failed to restore document version":                         "Не удалось восстановить версию документа",
+		"Query parameter 'from' must be a version number":            "Параметр 'from' должен быть номером версии",
+		"Query parameter 'to' must be a version number or 'current'": "Параметр 'to' должен быть номером версии или 'current'",
+
+		// Feedback and experiments
+		"Feedback not found":               "Отзыв не найден",
+		"Failed to get feedback":           "Не удалось получить отзывы",
+		"Failed to save feedback":          "Не удалось сохранить отзыв",
+		"Failed to delete feedback":        "Не удалось удалить отзыв",
+		"Failed to get feedback summaries": "Не удалось получить сводку отзывов",
+		"Failed to record signal":          "Не удалось сохранить сигнал",
+		"Experiment not found":             "Эксперимент не найден",
+		"Failed to get experiment report":  "Не удалось получить отчёт по эксперименту",
+
+		// Applications and reminders
+		"Application not found":                                 "Отклик не найден",
+		"Invalid application ID":                                "Некорректный ID отклика",
+		"Company name and job title are required":               "Укажите компанию и должность",
+		"Company name, job title and applied date are required": "Укажите компанию, должность и дату отклика",
+		"Failed to get application":                             "Не удалось получить отклик",
+		"Failed to get applications":                            "Не удалось получить отклики",
+		"Failed to create application":                          "Не удалось создать отклик",
+		"Failed to update application":                          "Не удалось обновить отклик",
+		"Failed to delete application":                          "Не удалось удалить отклик",
+		"Failed to change application status":                   "Не удалось изменить статус отклика",
+		"Failed to get application history":                     "Не удалось получить историю отклика",
+		"Failed to get follow-up rules":                         "Не удалось получить правила напоминаний",
+		"Failed to update follow-up rules":                      "Не удалось обновить правила напоминаний",
+		"Reminder not found":                                    "Напоминание не найдено",
+		"Invalid reminder ID":                                   "Некорректный ID напоминания",
+		"Failed to get reminders":                               "Не удалось получить напоминания",
+		"Failed to dismiss reminder":                            "Не удалось скрыть напоминание",
+
+		// Job postings
+		"Job posting not found":              "Вакансия не найдена",
+		"Invalid job posting ID":             "Некорректный ID вакансии",
+		"Title and description are required": "Укажите название и описание вакансии",
+		"Content is required":                "Вставьте текст вакансии",
+		"Content must be at most 1 MB":       "Текст не должен превышать 1 МБ",
+		"Failed to get job posting":          "Не удалось получить вакансию",
+		"Failed to get job postings":         "Не удалось получить вакансии",
+		"Failed to save job posting":         "Не удалось сохранить вакансию",
+		"Failed to update job posting":       "Не удалось обновить вакансию",
+		"Failed to delete job posting":       "Не удалось удалить вакансию",
+
+		// Languages and conventions
+		"unsupported language: use one of en, ru, de, fr, es": "язык не поддерживается: используйте en, ru, de, fr или es",
+		"unknown convention: use one of us, eu, de, jp":       "неизвестный формат резюме: используйте us, eu, de или jp",
+	},
+}
@@ -2,10 +2,11 @@ package middleware
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"strings"
 
-	//"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
 	"github.com/feijoa-master/ai-resume-builder/internal/utils"
 	"github.com/google/uuid"
 )
@@ -73,6 +74,26 @@ func RequirePremium(next http.Handler) http.Handler {
 	})
 }
 
+// RequireAdmin middleware lets only the listed emails through
+func RequireAdmin(adminEmails []string) func(http.Handler) http.Handler {
+	admins := make(map[string]bool, len(adminEmails))
+	for _, email := range adminEmails {
+		admins[strings.ToLower(email)] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			email, ok := GetUserEmailFromContext(r)
+			if !ok || !admins[strings.ToLower(email)] {
+				respondWithError(w, http.StatusForbidden, "ADMIN_REQUIRED", "This endpoint is for administrators only")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // Helper functions
 
 // GetUserIDFromContext extracts user ID from request context
@@ -94,13 +115,13 @@ func IsPremiumUser(r *http.Request) bool {
 }
 
 func respondWithError(w http.ResponseWriter, statusCode int, code, message string) {
-	//errorResponse := models.ErrorResponse{
-	//	Error: models.ErrorDetail{
-	//		Code:    code,
-	//		Message: message,
-	//	},
-	//}
+	errorResponse := models.ErrorResponse{
+		Error: models.ErrorDetail{
+			Code:    code,
+			Message: Translate(w, message),
+		},
+	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
-	w.Write([]byte(`{"error":{"code":"` + code + `","message":"` + message + `"}}`))
+	json.NewEncoder(w).Encode(errorResponse)
 }
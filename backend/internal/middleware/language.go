@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/i18n"
+)
+
+// languageWriter carries the language of the request to the error helpers,
+// which only get the ResponseWriter
+type languageWriter struct {
+	http.ResponseWriter
+	language string
+}
+
+func (w *languageWriter) Language() string {
+	return w.language
+}
+
+// Localize picks the response language from the Accept-Language header so
+// error messages can be translated
+func Localize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		language := i18n.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+		w.Header().Add("Vary", "Accept-Language")
+		w.Header().Set("Content-Language", language)
+
+		next.ServeHTTP(&languageWriter{ResponseWriter: w, language: language}, r)
+	})
+}
+
+// Translate returns a message in the language picked by Localize, or
+// unchanged when the writer has no language
+func Translate(w http.ResponseWriter, message string) string {
+	if lw, ok := w.(interface{ Language() string }); ok {
+		return i18n.Message(lw.Language(), message)
+	}
+	return message
+}
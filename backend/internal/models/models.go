@@ -8,26 +8,33 @@ import (
 
 // User represents a registered user
 type User struct {
-	ID                  uuid.UUID `json:"id"`
-	Email               string    `json:"email"`
-	PasswordHash        string    `json:"-"` // Never expose in JSON
-	FullName            string    `json:"full_name"`
-	FreeGenerationsLeft int       `json:"free_generations_left"`
-	IsPremium           bool      `json:"is_premium"`
-	CreatedAt           time.Time `json:"created_at"`
-	UpdatedAt           time.Time `json:"updated_at"`
+	ID                    uuid.UUID `json:"id"`
+	Email                 string    `json:"email"`
+	PasswordHash          string    `json:"-"` // Never expose in JSON
+	FullName              string    `json:"full_name"`
+	FreeGenerationsLeft   int       `json:"free_generations_left"`
+	FreeRegenerationsLeft int       `json:"free_regenerations_left"`
+	IsPremium             bool      `json:"is_premium"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
 }
 
 // Profile represents user's professional profile
 type Profile struct {
 	ID          uuid.UUID `json:"id"`
 	UserID      uuid.UUID `json:"user_id"`
+	Name        string    `json:"name"`
+	IsDefault   bool      `json:"is_default"`
 	Phone       string    `json:"phone,omitempty"`
 	Location    string    `json:"location,omitempty"`
 	LinkedInURL string    `json:"linkedin_url,omitempty"`
 	GithubURL   string    `json:"github_url,omitempty"`
 	WebsiteURL  string    `json:"website_url,omitempty"`
 	Summary     string    `json:"summary,omitempty"`
+	// Personal details only some regional conventions show
+	DateOfBirth NullDate  `json:"date_of_birth,omitempty"`
+	Nationality string    `json:"nationality,omitempty"`
+	PhotoURL    string    `json:"photo_url,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -43,6 +50,7 @@ type Experience struct {
 	IsCurrent    bool      `json:"is_current"`
 	Description  string    `json:"description,omitempty"`
 	Achievements []string  `json:"achievements,omitempty"`
+	SortOrder    int       `json:"sort_order"`
 	CreatedAt    time.Time `json:"created_at"`
 }
 
@@ -56,6 +64,7 @@ type Education struct {
 	StartDate    Date      `json:"start_date"`
 	EndDate      NullDate  `json:"end_date,omitempty"`
 	GPA          float64   `json:"gpa,omitempty"`
+	SortOrder    int       `json:"sort_order"`
 	CreatedAt    time.Time `json:"created_at"`
 }
 
@@ -66,35 +75,401 @@ type Skill struct {
 	Name             string    `json:"name"`
 	Category         string    `json:"category"`                    // technical, soft, language
 	ProficiencyLevel string    `json:"proficiency_level,omitempty"` // beginner, intermediate, advanced, expert
+	Group            string    `json:"group,omitempty"`             // custom group such as "Backend"; defaults to Category
+	SortOrder        int       `json:"sort_order"`
 	CreatedAt        time.Time `json:"created_at"`
 }
 
+// SkillGroup is a named group of skills in display order
+type SkillGroup struct {
+	Name   string   `json:"name"`
+	Skills []*Skill `json:"skills"`
+}
+
+// Project represents a personal, open source or work project
+type Project struct {
+	ID            uuid.UUID `json:"id"`
+	ProfileID     uuid.UUID `json:"profile_id"`
+	Name          string    `json:"name"`
+	Role          string    `json:"role,omitempty"`
+	Description   string    `json:"description,omitempty"`
+	URL           string    `json:"url,omitempty"`
+	RepositoryURL string    `json:"repository_url,omitempty"`
+	TechStack     []string  `json:"tech_stack,omitempty"`
+	StartDate     NullDate  `json:"start_date,omitempty"`
+	EndDate       NullDate  `json:"end_date,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Certification represents a professional certification
+type Certification struct {
+	ID            uuid.UUID `json:"id"`
+	ProfileID     uuid.UUID `json:"profile_id"`
+	Name          string    `json:"name"`
+	Issuer        string    `json:"issuer"`
+	IssueDate     NullDate  `json:"issue_date,omitempty"`
+	ExpiryDate    NullDate  `json:"expiry_date,omitempty"` // null if it doesn't expire
+	CredentialID  string    `json:"credential_id,omitempty"`
+	CredentialURL string    `json:"credential_url,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Publication represents a paper, article or book
+type Publication struct {
+	ID            uuid.UUID `json:"id"`
+	ProfileID     uuid.UUID `json:"profile_id"`
+	Title         string    `json:"title"`
+	Publisher     string    `json:"publisher,omitempty"` // journal, conference or publisher
+	PublishedDate NullDate  `json:"published_date,omitempty"`
+	URL           string    `json:"url,omitempty"`
+	Authors       []string  `json:"authors,omitempty"`
+	Description   string    `json:"description,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Language represents a spoken language
+type Language struct {
+	ID        uuid.UUID `json:"id"`
+	ProfileID uuid.UUID `json:"profile_id"`
+	Name      string    `json:"name"`
+	Level     string    `json:"level"` // CEFR A1, A2, B1, B2, C1, C2 or native
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Volunteering represents volunteer work
+type Volunteering struct {
+	ID           uuid.UUID `json:"id"`
+	ProfileID    uuid.UUID `json:"profile_id"`
+	Organization string    `json:"organization"`
+	Role         string    `json:"role"`
+	StartDate    Date      `json:"start_date"`
+	EndDate      NullDate  `json:"end_date,omitempty"`
+	IsCurrent    bool      `json:"is_current"`
+	Description  string    `json:"description,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Award represents an award or honour
+type Award struct {
+	ID          uuid.UUID `json:"id"`
+	ProfileID   uuid.UUID `json:"profile_id"`
+	Title       string    `json:"title"`
+	Issuer      string    `json:"issuer,omitempty"`
+	Date        NullDate  `json:"date,omitempty"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
 // Document represents generated resume or cover letter
 type Document struct {
 	ID             uuid.UUID              `json:"id"`
 	UserID         uuid.UUID              `json:"user_id"`
-	Type           string                 `json:"type"` // resume, cover_letter
+	ProfileID      *uuid.UUID             `json:"profile_id,omitempty"`     // profile it was generated from
+	JobPostingID   *uuid.UUID             `json:"job_posting_id,omitempty"` // saved posting it was generated for
+	SourceID       *uuid.UUID             `json:"source_id,omitempty"`      // document it was translated from
+	Type           string                 `json:"type"`                     // resume, cover_letter
 	Title          string                 `json:"title"`
 	Content        map[string]interface{} `json:"content"` // JSON content
 	TemplateID     string                 `json:"template_id,omitempty"`
 	JobTitle       string                 `json:"job_title,omitempty"`
 	CompanyName    string                 `json:"company_name,omitempty"`
 	JobDescription string                 `json:"job_description,omitempty"`
-	Status         string                 `json:"status"` // draft, final
+	JobAnalysis    *JobAnalysis           `json:"job_analysis,omitempty"`
+	Language       string                 `json:"language,omitempty"` // language tag it was generated in, e.g. ru; empty for English
+	Convention     string                 `json:"convention"`         // regional resume convention, e.g. us or de
+	Status         string                 `json:"status"`             // draft, final
 	CreatedAt      time.Time              `json:"created_at"`
 	UpdatedAt      time.Time              `json:"updated_at"`
 }
 
 // GenerationHistory tracks AI generation usage
 type GenerationHistory struct {
-	ID               uuid.UUID `json:"id"`
-	UserID           uuid.UUID `json:"user_id"`
-	DocumentID       uuid.UUID `json:"document_id"`
-	PromptTokens     int       `json:"prompt_tokens"`
-	CompletionTokens int       `json:"completion_tokens"`
-	TotalCost        float64   `json:"total_cost"`
-	GenerationTimeMs int       `json:"generation_time_ms"`
-	CreatedAt        time.Time `json:"created_at"`
+	ID               uuid.UUID  `json:"id"`
+	UserID           uuid.UUID  `json:"user_id"`
+	DocumentID       *uuid.UUID `json:"document_id,omitempty"` // nil for calls not tied to a document
+	PromptTokens     int        `json:"prompt_tokens"`
+	CompletionTokens int        `json:"completion_tokens"`
+	CachedTokens     int        `json:"cached_tokens"` // prompt tokens served from the provider's prompt cache
+	TotalCost        float64    `json:"total_cost"`
+	GenerationTimeMs int        `json:"generation_time_ms"`
+	PromptVersion    string     `json:"prompt_version,omitempty"` // e.g. resume@1
+	Provider         string     `json:"provider,omitempty"`       // e.g. openai
+	Model            string     `json:"model,omitempty"`
+	Experiment       string     `json:"experiment,omitempty"` // A/B experiment the generation ran in
+	Variant          string     `json:"variant,omitempty"`
+	Cached           bool       `json:"cached"` // answered from the response cache, at no cost
+	CacheKey         string     `json:"-"`      // response cache key of the request, if the cache is on
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// JobAnalysis is structured data extracted from a job description
+type JobAnalysis struct {
+	RequiredSkills   []string `json:"required_skills"`
+	NiceToHaveSkills []string `json:"nice_to_have_skills"`
+	Seniority        string   `json:"seniority,omitempty"` // intern, junior, mid, senior, lead
+	YearsExperience  int      `json:"years_experience,omitempty"`
+	Responsibilities []string `json:"responsibilities"`
+	Keywords         []string `json:"keywords"`
+	Enriched         bool     `json:"enriched"` // true when an LLM added to the deterministic result
+}
+
+// ATSScore measures how well a resume matches a job description
+type ATSScore struct {
+	Score                   int          `json:"score"`  // 0-100
+	Source                  string       `json:"source"` // document, profile
+	Breakdown               ATSBreakdown `json:"breakdown"`
+	MatchedSkills           []string     `json:"matched_skills"`
+	MissingRequiredSkills   []string     `json:"missing_required_skills"`
+	MissingNiceToHaveSkills []string     `json:"missing_nice_to_have_skills"`
+	MatchedKeywords         []string     `json:"matched_keywords"`
+	MissingKeywords         []string     `json:"missing_keywords"`
+	MissingSections         []string     `json:"missing_sections"`
+	WordCount               int          `json:"word_count"`
+	Gaps                    []ATSGap     `json:"gaps"`
+}
+
+// ATSBreakdown holds the points earned per scoring category
+type ATSBreakdown struct {
+	RequiredSkills   float64 `json:"required_skills"`     // out of 40
+	Keywords         float64 `json:"keywords"`            // out of 25
+	NiceToHaveSkills float64 `json:"nice_to_have_skills"` // out of 10
+	Sections         float64 `json:"sections"`            // out of 15
+	Length           float64 `json:"length"`              // out of 10
+}
+
+// ATSGap is an actionable suggestion for raising the score
+type ATSGap struct {
+	Type     string `json:"type"`     // missing_skill, missing_keyword, missing_section, length
+	Severity string `json:"severity"` // high, medium, low
+	Message  string `json:"message"`
+}
+
+// SkillGapReport compares the user's skills with the skills a job asks for
+type SkillGapReport struct {
+	JobTitle         string         `json:"job_title,omitempty"`
+	Seniority        string         `json:"seniority,omitempty"`
+	Coverage         int            `json:"coverage"` // 0-100, share of required skills covered
+	Matched          []SkillGapItem `json:"matched"`
+	PartiallyMatched []SkillGapItem `json:"partially_matched"` // held at a lower level, or only mentioned in experience
+	Missing          []SkillGapItem `json:"missing"`
+}
+
+// SkillGapItem is one skill of a job and how the user's profile covers it
+type SkillGapItem struct {
+	Skill         string `json:"skill"` // canonical name
+	Category      string `json:"category,omitempty"`
+	Required      bool   `json:"required"` // false for nice-to-have skills
+	RequiredLevel string `json:"required_level,omitempty"`
+	YourSkill     string `json:"your_skill,omitempty"` // the name as entered in the profile
+	YourLevel     string `json:"your_level,omitempty"`
+	Note          string `json:"note,omitempty"`
+}
+
+// DocumentVersion is a snapshot of a document taken before it was updated
+type DocumentVersion struct {
+	ID         uuid.UUID              `json:"id"`
+	DocumentID uuid.UUID              `json:"document_id"`
+	Version    int                    `json:"version"`
+	Title      string                 `json:"title"`
+	Content    map[string]interface{} `json:"content"`
+	Status     string                 `json:"status"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// DocumentMessage is one turn of a document's refinement chat
+type DocumentMessage struct {
+	ID           uuid.UUID  `json:"id"`
+	DocumentID   uuid.UUID  `json:"document_id"`
+	Role         string     `json:"role"` // user, assistant
+	Content      string     `json:"content"`
+	GenerationID *uuid.UUID `json:"generation_id,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// Application statuses. An application starts as applied and moves forward
+// through the pipeline; offer and rejected are final.
+const (
+	ApplicationApplied   = "applied"
+	ApplicationInterview = "interview"
+	ApplicationOffer     = "offer"
+	ApplicationRejected  = "rejected"
+)
+
+// Application is a job the user applied for
+type Application struct {
+	ID                    uuid.UUID            `json:"id"`
+	UserID                uuid.UUID            `json:"user_id"`
+	CompanyName           string               `json:"company_name"`
+	JobTitle              string               `json:"job_title"`
+	JobURL                string               `json:"job_url,omitempty"`
+	Status                string               `json:"status"` // applied, interview, offer, rejected
+	AppliedDate           Date                 `json:"applied_date"`
+	Notes                 string               `json:"notes,omitempty"`
+	Contacts              []ApplicationContact `json:"contacts,omitempty"`
+	ResumeDocumentID      *uuid.UUID           `json:"resume_document_id,omitempty"`
+	CoverLetterDocumentID *uuid.UUID           `json:"cover_letter_document_id,omitempty"`
+	StatusChangedAt       time.Time            `json:"status_changed_at"`
+	CreatedAt             time.Time            `json:"created_at"`
+	UpdatedAt             time.Time            `json:"updated_at"`
+}
+
+// ApplicationContact is a person the user deals with for an application
+type ApplicationContact struct {
+	Name  string `json:"name"`
+	Role  string `json:"role,omitempty"` // recruiter, hiring manager, ...
+	Email string `json:"email,omitempty"`
+	Phone string `json:"phone,omitempty"`
+}
+
+// ApplicationStatusChange is one entry of an application's status history
+type ApplicationStatusChange struct {
+	ID            uuid.UUID `json:"id"`
+	ApplicationID uuid.UUID `json:"application_id"`
+	FromStatus    string    `json:"from_status,omitempty"` // empty for the initial status
+	ToStatus      string    `json:"to_status"`
+	Note          string    `json:"note,omitempty"`
+	ChangedAt     time.Time `json:"changed_at"`
+}
+
+// FollowUpRule sets how many days an application may stay in a status
+// before the user is reminded to follow up. AfterDays 0 turns it off.
+type FollowUpRule struct {
+	Status    string `json:"status"`
+	AfterDays int    `json:"after_days"`
+}
+
+// FollowUpCandidate is an application that may need a follow-up reminder,
+// with the rule the user set for its current status, if any
+type FollowUpCandidate struct {
+	ApplicationID   uuid.UUID
+	UserID          uuid.UUID
+	CompanyName     string
+	JobTitle        string
+	Status          string
+	StatusChangedAt time.Time
+	AfterDays       *int // nil when the default rule applies
+}
+
+// Reminder tells a user to follow up on an application
+type Reminder struct {
+	ID            uuid.UUID  `json:"id"`
+	UserID        uuid.UUID  `json:"user_id"`
+	ApplicationID uuid.UUID  `json:"application_id"`
+	Status        string     `json:"status"`       // application status the reminder is about
+	StatusSince   time.Time  `json:"status_since"` // when the application entered that status
+	Message       string     `json:"message"`
+	DueAt         time.Time  `json:"due_at"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty"`
+	DismissedAt   *time.Time `json:"dismissed_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+
+	DeliveryAttempts int `json:"-"` // failed deliveries so far
+}
+
+// JobPosting is a job description saved for reuse across generations. Its
+// ContentHash identifies the description so the same posting is stored once.
+type JobPosting struct {
+	ID             uuid.UUID `json:"id"`
+	UserID         uuid.UUID `json:"user_id"`
+	Title          string    `json:"title"`
+	CompanyName    string    `json:"company_name,omitempty"`
+	Description    string    `json:"description"`
+	SourceURL      string    `json:"source_url,omitempty"`
+	Location       string    `json:"location,omitempty"`
+	SalaryMin      *int      `json:"salary_min,omitempty"`
+	SalaryMax      *int      `json:"salary_max,omitempty"`
+	SalaryCurrency string    `json:"salary_currency,omitempty"` // ISO 4217 code, e.g. USD
+	ContentHash    string    `json:"content_hash"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ParsedJobPosting is what the posting parser extracted from pasted HTML or
+// text. Source is the detected format: linkedin, indeed, greenhouse, lever or
+// generic.
+type ParsedJobPosting struct {
+	Source      string `json:"source"`
+	Title       string `json:"title,omitempty"`
+	CompanyName string `json:"company_name,omitempty"`
+	Location    string `json:"location,omitempty"`
+	Description string `json:"description"`
+}
+
+// Document signals tell how users received a generated document. Edited and
+// regenerated are recorded by the server, the others are reported by the client.
+const (
+	SignalEdited      = "edited"
+	SignalRegenerated = "regenerated"
+	SignalDownloaded  = "downloaded"
+	SignalThumbsUp    = "thumbs_up"
+	SignalThumbsDown  = "thumbs_down"
+)
+
+// DocumentSignal is one user action on a document
+type DocumentSignal struct {
+	ID         uuid.UUID `json:"id"`
+	DocumentID uuid.UUID `json:"document_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Signal     string    `json:"signal"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ExperimentReport compares the variants of an experiment
+type ExperimentReport struct {
+	Experiment string           `json:"experiment"`
+	Kind       string           `json:"kind"`
+	Enabled    bool             `json:"enabled"`
+	Variants   []*VariantReport `json:"variants"`
+}
+
+// VariantReport sums up the generations of one experiment variant. Signals
+// count the documents that got each signal after the variant generated them,
+// SignalRates divides those by Documents.
+type VariantReport struct {
+	Variant             string             `json:"variant"`
+	Weight              int                `json:"weight"`
+	Generations         int                `json:"generations"`
+	Documents           int                `json:"documents"`
+	AvgPromptTokens     float64            `json:"avg_prompt_tokens"`
+	AvgCompletionTokens float64            `json:"avg_completion_tokens"`
+	AvgGenerationTimeMs float64            `json:"avg_generation_time_ms"`
+	TotalCost           float64            `json:"total_cost"`
+	Signals             map[string]int     `json:"signals"`
+	SignalRates         map[string]float64 `json:"signal_rates"`
+}
+
+// Feedback ratings
+const (
+	RatingUp   = "up"
+	RatingDown = "down"
+)
+
+// GenerationFeedback is a user's rating of a generated document or of one of
+// its sections. A new rating of the same document or section replaces it.
+type GenerationFeedback struct {
+	ID           uuid.UUID  `json:"id"`
+	UserID       uuid.UUID  `json:"user_id"`
+	DocumentID   uuid.UUID  `json:"document_id"`
+	GenerationID *uuid.UUID `json:"generation_id,omitempty"` // latest generation when rated
+	Section      string     `json:"section,omitempty"`       // empty for the whole document, else e.g. summary or experience[1]
+	Rating       string     `json:"rating"`                  // up, down
+	Reasons      []string   `json:"reasons"`
+	Comment      string     `json:"comment,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// FeedbackSummary aggregates the feedback on generations made with one
+// model, prompt version and document template
+type FeedbackSummary struct {
+	Model         string         `json:"model"`
+	PromptVersion string         `json:"prompt_version"`
+	TemplateID    string         `json:"template_id"`
+	Total         int            `json:"total"`
+	Up            int            `json:"up"`
+	Down          int            `json:"down"`
+	Comments      int            `json:"comments"`
+	Reasons       map[string]int `json:"reasons"`
 }
 
 // DTOs (Data Transfer Objects)
@@ -121,12 +496,79 @@ type TokenResponse struct {
 
 // GenerateRequest for document generation
 type GenerateRequest struct {
-	Type           string   `json:"type" validate:"required,oneof=resume cover_letter"`
-	JobDescription string   `json:"job_description" validate:"required"`
-	JobTitle       string   `json:"job_title,omitempty"`
-	CompanyName    string   `json:"company_name,omitempty"`
-	TemplateID     string   `json:"template_id" validate:"required"`
-	CustomSections []string `json:"custom_sections,omitempty"`
+	Type           string     `json:"type" validate:"required,oneof=resume cover_letter"`
+	JobDescription string     `json:"job_description" validate:"required"`
+	JobTitle       string     `json:"job_title,omitempty"`
+	CompanyName    string     `json:"company_name,omitempty"`
+	TemplateID     string     `json:"template_id" validate:"required"`
+	CustomSections []string   `json:"custom_sections,omitempty"` // optional sections to include: projects, certifications, publications, languages, volunteering, awards
+	ProfileID      *uuid.UUID `json:"profile_id,omitempty"`      // profile to generate from; the default profile if omitted
+	JobPostingID   *uuid.UUID `json:"job_posting_id,omitempty"`  // saved posting to use instead of job_description
+	Language       string     `json:"language,omitempty"`        // output language tag, e.g. ru or de-AT; English if omitted
+	Convention     string     `json:"convention,omitempty"`      // regional resume convention: us (default), eu, de or jp
+	Fresh          bool       `json:"fresh,omitempty"`           // ask the model again instead of reusing a cached response
+}
+
+// TranslateDocumentRequest asks for a copy of a document in another language
+type TranslateDocumentRequest struct {
+	Language string `json:"language"` // language tag, e.g. de or de-AT
+}
+
+// ProfileNameRequest names a new, copied or renamed profile
+type ProfileNameRequest struct {
+	Name string `json:"name"`
+}
+
+// ReorderRequest lists every entry of a profile section in the new order
+type ReorderRequest struct {
+	IDs []uuid.UUID `json:"ids"`
+}
+
+// ReplaceSkillsRequest replaces the whole skills list in the given order.
+// Skills with an ID of an existing skill update it, others are created.
+type ReplaceSkillsRequest struct {
+	Skills []*Skill `json:"skills"`
+}
+
+// AnalyzeJobRequest for job description analysis
+type AnalyzeJobRequest struct {
+	JobDescription string     `json:"job_description" validate:"required"`
+	JobTitle       string     `json:"job_title,omitempty"`
+	DocumentID     *uuid.UUID `json:"document_id,omitempty"` // store the analysis on this document
+	Enrich         bool       `json:"enrich,omitempty"`      // ask the LLM to add what text processing missed
+}
+
+// ATSScoreRequest selects what is scored against the job description
+type ATSScoreRequest struct {
+	Source         string `json:"source,omitempty"`          // document (default) or profile
+	JobDescription string `json:"job_description,omitempty"` // overrides the document's job description
+}
+
+// SkillGapRequest names the job to compare the profile with, either by
+// description or by a document that has one
+type SkillGapRequest struct {
+	JobDescription string     `json:"job_description,omitempty"`
+	JobTitle       string     `json:"job_title,omitempty"`
+	DocumentID     *uuid.UUID `json:"document_id,omitempty"`
+}
+
+// RegenerateSectionRequest for rewriting one section of a document
+type RegenerateSectionRequest struct {
+	Section      string `json:"section" validate:"required"` // summary, experience, skills (resume); opening, body1, body2, closing (cover letter)
+	Index        *int   `json:"index,omitempty"`             // experience entry whose highlights are rewritten
+	Instructions string `json:"instructions,omitempty"`
+	Fresh        bool   `json:"fresh,omitempty"` // ask the model again instead of reusing a cached response
+}
+
+// RefineDocumentRequest for a chat instruction such as "make it more concise"
+type RefineDocumentRequest struct {
+	Message string `json:"message" validate:"required"`
+}
+
+// RefineDocumentResponse returns the new chat turns and the updated document
+type RefineDocumentResponse struct {
+	Messages []*DocumentMessage `json:"messages"`
+	Document *Document          `json:"document"`
 }
 
 // GenerateResponse after starting generation
@@ -136,6 +578,63 @@ type GenerateResponse struct {
 	EstimatedTime int       `json:"estimated_time"` // in seconds
 }
 
+// DocumentDiff lists the content changes between two document versions
+type DocumentDiff struct {
+	FromVersion int             `json:"from_version"`
+	ToVersion   int             `json:"to_version"` // 0 means the current document
+	TitleFrom   string          `json:"title_from,omitempty"`
+	TitleTo     string          `json:"title_to,omitempty"`
+	Changes     []ContentChange `json:"changes"`
+}
+
+// ContentChange is a single difference inside Document.Content
+type ContentChange struct {
+	Path     string      `json:"path"` // e.g. experience[0].highlights[1]
+	Type     string      `json:"type"` // added, removed, changed
+	OldValue interface{} `json:"old_value,omitempty"`
+	NewValue interface{} `json:"new_value,omitempty"`
+}
+
+// ApplicationFilter narrows the applications list. Zero fields don't filter.
+type ApplicationFilter struct {
+	Statuses    []string
+	Company     string // case-insensitive substring of the company name
+	AppliedFrom NullDate
+	AppliedTo   NullDate
+}
+
+// ApplicationStatusRequest moves an application to another status
+type ApplicationStatusRequest struct {
+	Status string `json:"status"`
+	Note   string `json:"note,omitempty"`
+}
+
+// ParseJobPostingRequest holds a job posting pasted as HTML or text
+type ParseJobPostingRequest struct {
+	Content string `json:"content"`
+}
+
+// FollowUpRulesRequest replaces the follow-up rules of an application
+type FollowUpRulesRequest struct {
+	Rules []FollowUpRule `json:"rules"`
+}
+
+// DocumentSignalRequest reports a client-side signal on a document:
+// downloaded, thumbs_up or thumbs_down
+type DocumentSignalRequest struct {
+	Signal string `json:"signal"`
+}
+
+// FeedbackRequest rates a document, or one section of it: the section names
+// are those of RegenerateSectionRequest
+type FeedbackRequest struct {
+	Rating  string   `json:"rating" validate:"required"` // up, down
+	Reasons []string `json:"reasons,omitempty"`
+	Comment string   `json:"comment,omitempty"`
+	Section string   `json:"section,omitempty"`
+	Index   *int     `json:"index,omitempty"` // experience entry
+}
+
 // ErrorResponse for API errors
 type ErrorResponse struct {
 	Error ErrorDetail `json:"error"`
@@ -0,0 +1,151 @@
+// Package pricing prices model usage per provider and model.
+//
+// Prices are in USD per million tokens. The built-in table covers the OpenAI
+// models; a JSON file adds models or overrides built-in prices:
+//
+//	[
+//	  {"provider": "openai", "model": "gpt-4o", "input": 2.5, "cached_input": 1.25, "output": 10}
+//	]
+//
+// Cached input is prompt tokens the provider served from its prompt cache;
+// without a cached_input price they are charged as input. A model name
+// followed by a dated snapshot, e.g. gpt-4o-mini-2024-07-18, gets the price
+// of the longest model name it starts with.
+package pricing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// OpenAI is the provider name of OpenAI models
+const OpenAI = "openai"
+
+var ErrUnknownModel = errors.New("no price for model")
+
+// Price is what a model costs per million tokens
+type Price struct {
+	Provider    string  `json:"provider"`
+	Model       string  `json:"model"`
+	Input       float64 `json:"input"`
+	CachedInput float64 `json:"cached_input,omitempty"`
+	Output      float64 `json:"output"`
+}
+
+// Usage is the tokens of one model call; CachedTokens are part of
+// PromptTokens
+type Usage struct {
+	PromptTokens     int
+	CachedTokens     int
+	CompletionTokens int
+}
+
+// builtin are list prices as of 2025
+var builtin = []*Price{
+	{Provider: OpenAI, Model: "gpt-4o-mini", Input: 0.15, CachedInput: 0.075, Output: 0.60},
+	{Provider: OpenAI, Model: "gpt-4o", Input: 2.50, CachedInput: 1.25, Output: 10.00},
+	{Provider: OpenAI, Model: "gpt-4.1", Input: 2.00, CachedInput: 0.50, Output: 8.00},
+	{Provider: OpenAI, Model: "gpt-4.1-mini", Input: 0.40, CachedInput: 0.10, Output: 1.60},
+	{Provider: OpenAI, Model: "gpt-4.1-nano", Input: 0.10, CachedInput: 0.025, Output: 0.40},
+	{Provider: OpenAI, Model: "gpt-4-turbo", Input: 10.00, Output: 30.00},
+	{Provider: OpenAI, Model: "gpt-3.5-turbo", Input: 0.50, Output: 1.50},
+}
+
+// Table holds the prices of the known models
+type Table struct {
+	prices []*Price
+}
+
+// Load returns the built-in prices with those of a JSON file added. An
+// empty path means the built-in prices only.
+func Load(path string) (*Table, error) {
+	prices := append([]*Price(nil), builtin...)
+	if path == "" {
+		return New(prices)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pricing: %w", err)
+	}
+
+	var overrides []*Price
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse pricing: %w", err)
+	}
+	if _, err := New(overrides); err != nil {
+		return nil, err
+	}
+
+	for _, override := range overrides {
+		replaced := false
+		for i, p := range prices {
+			if p.Provider == override.Provider && p.Model == override.Model {
+				prices[i] = override
+				replaced = true
+			}
+		}
+		if !replaced {
+			prices = append(prices, override)
+		}
+	}
+
+	return New(prices)
+}
+
+// New checks the prices and builds a table from them
+func New(prices []*Price) (*Table, error) {
+	seen := make(map[string]bool, len(prices))
+	for _, p := range prices {
+		if p.Provider == "" || p.Model == "" {
+			return nil, errors.New("prices need a provider and a model")
+		}
+		if p.Input < 0 || p.CachedInput < 0 || p.Output < 0 {
+			return nil, fmt.Errorf("price of %s/%s is negative", p.Provider, p.Model)
+		}
+		key := p.Provider + "/" + p.Model
+		if seen[key] {
+			return nil, fmt.Errorf("price of %s is defined twice", key)
+		}
+		seen[key] = true
+	}
+
+	return &Table{prices: prices}, nil
+}
+
+// Lookup returns the price of a model: an exact match, else the longest
+// model name the model starts with followed by a dash
+func (t *Table) Lookup(provider, model string) (*Price, error) {
+	var best *Price
+	for _, p := range t.prices {
+		if p.Provider != provider {
+			continue
+		}
+		if p.Model == model {
+			return p, nil
+		}
+		if strings.HasPrefix(model, p.Model+"-") && (best == nil || len(p.Model) > len(best.Model)) {
+			best = p
+		}
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("%w: %s/%s", ErrUnknownModel, provider, model)
+	}
+	return best, nil
+}
+
+// Cost returns what the usage costs in USD
+func (p *Price) Cost(usage Usage) float64 {
+	cachedPrice := p.CachedInput
+	if cachedPrice == 0 {
+		cachedPrice = p.Input
+	}
+
+	uncached := usage.PromptTokens - usage.CachedTokens
+	cost := float64(uncached)*p.Input + float64(usage.CachedTokens)*cachedPrice + float64(usage.CompletionTokens)*p.Output
+	return cost / 1_000_000
+}
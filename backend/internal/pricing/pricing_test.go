@@ -0,0 +1,102 @@
+package pricing
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	table, err := Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	tests := []struct {
+		provider string
+		model    string
+		want     string
+	}{
+		{OpenAI, "gpt-4o-mini", "gpt-4o-mini"},
+		{OpenAI, "gpt-4o", "gpt-4o"},
+		{OpenAI, "gpt-4o-mini-2024-07-18", "gpt-4o-mini"},
+		{OpenAI, "gpt-4o-2024-08-06", "gpt-4o"},
+		{OpenAI, "gpt-4.1-mini-2025-04-14", "gpt-4.1-mini"},
+		{OpenAI, "gpt-4", ""},
+		{OpenAI, "o1", ""},
+		{"anthropic", "gpt-4o", ""},
+	}
+	for _, tt := range tests {
+		price, err := table.Lookup(tt.provider, tt.model)
+		if tt.want == "" {
+			if !errors.Is(err, ErrUnknownModel) {
+				t.Errorf("Lookup(%s, %s) = %v, %v, want ErrUnknownModel", tt.provider, tt.model, price, err)
+			}
+			continue
+		}
+		if err != nil || price.Model != tt.want {
+			t.Errorf("Lookup(%s, %s) = %v, %v, want %s", tt.provider, tt.model, price, err, tt.want)
+		}
+	}
+}
+
+func TestCost(t *testing.T) {
+	price := &Price{Provider: OpenAI, Model: "gpt-4o-mini", Input: 0.15, CachedInput: 0.075, Output: 0.60}
+
+	tests := []struct {
+		name  string
+		price *Price
+		usage Usage
+		want  float64
+	}{
+		{"input and output", price, Usage{PromptTokens: 1_000_000, CompletionTokens: 500_000}, 0.45},
+		{"cached input", price, Usage{PromptTokens: 1_000_000, CachedTokens: 400_000, CompletionTokens: 0}, 0.12},
+		{"no cached price", &Price{Input: 10, Output: 30}, Usage{PromptTokens: 1000, CachedTokens: 500, CompletionTokens: 1000}, 0.04},
+		{"nothing", price, Usage{}, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.price.Cost(tt.usage); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("%s: Cost = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pricing.json")
+	data := `[
+		{"provider": "openai", "model": "gpt-4o", "input": 5, "output": 15},
+		{"provider": "azure", "model": "gpt-4o", "input": 2.75, "cached_input": 1.375, "output": 11}
+	]`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	table, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if price, _ := table.Lookup(OpenAI, "gpt-4o"); price == nil || price.Input != 5 {
+		t.Errorf("overridden price = %+v, want input 5", price)
+	}
+	if price, _ := table.Lookup("azure", "gpt-4o"); price == nil || price.Output != 11 {
+		t.Errorf("added price = %+v, want output 11", price)
+	}
+	if price, _ := table.Lookup(OpenAI, "gpt-4o-mini"); price == nil || price.Input != 0.15 {
+		t.Errorf("built-in price = %+v, want input 0.15", price)
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	tests := map[string][]*Price{
+		"no model": {{Provider: OpenAI, Input: 1, Output: 1}},
+		"negative": {{Provider: OpenAI, Model: "m", Input: -1, Output: 1}},
+		"twice":    {{Provider: OpenAI, Model: "m"}, {Provider: OpenAI, Model: "m"}},
+	}
+	for name, prices := range tests {
+		if _, err := New(prices); err == nil {
+			t.Errorf("%s: New succeeded", name)
+		}
+	}
+}
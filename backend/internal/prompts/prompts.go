@@ -0,0 +1,175 @@
+// Package prompts holds the LLM prompts as versioned text/template files.
+//
+// Each file is named <kind>[.<template>][.<locale>].tmpl, for example
+// resume.tmpl, resume.modern.tmpl or cover_letter.de.tmpl, starts with a
+// {{/* version: N */}} comment and defines the "system" and "user" templates
+// (refine also defines "context"). The files are embedded in the binary; a
+// file with the same name in the override directory replaces the embedded one.
+package prompts
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// Prompt kinds
+const (
+	Resume      = "resume"
+	CoverLetter = "cover_letter"
+	AnalyzeJob  = "analyze_job"
+	Section     = "section"
+	Refine      = "refine"
+	Translate   = "translate"
+)
+
+var ErrPromptNotFound = errors.New("prompt not found")
+
+//go:embed templates/*.tmpl
+var embedded embed.FS
+
+var versionComment = regexp.MustCompile(`^\{\{/\*\s*version:\s*([\w.-]+)\s*\*/\}\}`)
+
+// Prompt is one parsed prompt file
+type Prompt struct {
+	name     string
+	version  string
+	override bool
+	tmpl     *template.Template
+}
+
+// Version identifies the prompt file and its version, e.g. resume.modern@2.
+// Prompts overridden from disk are marked +local.
+func (p *Prompt) Version() string {
+	version := p.name + "@" + p.version
+	if p.override {
+		version += "+local"
+	}
+	return version
+}
+
+// Render executes one of the prompt's templates, such as "system" or "user"
+func (p *Prompt) Render(part string, data interface{}) (string, error) {
+	var b strings.Builder
+	if err := p.tmpl.ExecuteTemplate(&b, part, data); err != nil {
+		return "", fmt.Errorf("failed to render %s prompt %s: %w", p.Version(), part, err)
+	}
+	return b.String(), nil
+}
+
+// Registry looks prompts up by kind, template and locale
+type Registry struct {
+	prompts map[string]*Prompt
+}
+
+// Load reads the embedded prompts and, if overrideDir is set, the prompt
+// files in it
+func Load(overrideDir string) (*Registry, error) {
+	var overrides fs.FS
+	if overrideDir != "" {
+		overrides = os.DirFS(overrideDir)
+	}
+	templates, _ := fs.Sub(embedded, "templates")
+	return load(templates, overrides)
+}
+
+func load(base, overrides fs.FS) (*Registry, error) {
+	r := &Registry{prompts: make(map[string]*Prompt)}
+	if err := r.add(base, false); err != nil {
+		return nil, err
+	}
+	if overrides != nil {
+		if err := r.add(overrides, true); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func (r *Registry) add(fsys fs.FS, override bool) error {
+	files, err := fs.Glob(fsys, "*.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to list prompts: %w", err)
+	}
+
+	for _, file := range files {
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return fmt.Errorf("failed to read prompt %s: %w", file, err)
+		}
+
+		match := versionComment.FindSubmatch(content)
+		if match == nil {
+			return fmt.Errorf("prompt %s must start with a {{/* version: N */}} comment", file)
+		}
+
+		name := strings.TrimSuffix(path.Base(file), ".tmpl")
+		tmpl, err := template.New(name).Option("missingkey=error").Parse(string(content))
+		if err != nil {
+			return fmt.Errorf("failed to parse prompt %s: %w", file, err)
+		}
+		for _, part := range []string{"system", "user"} {
+			if tmpl.Lookup(part) == nil {
+				return fmt.Errorf("prompt %s must define a %q template", file, part)
+			}
+		}
+
+		r.prompts[name] = &Prompt{name: name, version: string(match[1]), override: override, tmpl: tmpl}
+	}
+
+	return nil
+}
+
+// Lookup returns the most specific prompt of a kind for a document template
+// and locale. The locale is preferred over the template, since it decides the
+// output language: for kind resume, template modern and locale de-AT it tries
+// resume.modern.de-at, resume.modern.de, resume.de-at, resume.de,
+// resume.modern and resume.
+func (r *Registry) Lookup(kind, templateID, locale string) (*Prompt, error) {
+	templateID = strings.ToLower(templateID)
+	locale = strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+
+	var locales []string
+	if locale != "" {
+		locales = append(locales, locale)
+		if language, _, found := strings.Cut(locale, "-"); found {
+			locales = append(locales, language)
+		}
+	}
+
+	var names []string
+	if templateID != "" {
+		for _, l := range locales {
+			names = append(names, kind+"."+templateID+"."+l)
+		}
+	}
+	for _, l := range locales {
+		names = append(names, kind+"."+l)
+	}
+	if templateID != "" {
+		names = append(names, kind+"."+templateID)
+	}
+	names = append(names, kind)
+
+	for _, name := range names {
+		if prompt, ok := r.prompts[name]; ok {
+			return prompt, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrPromptNotFound, kind)
+}
+
+// Get returns a prompt by file name without .tmpl, e.g. resume.concise
+func (r *Registry) Get(name string) (*Prompt, error) {
+	if prompt, ok := r.prompts[name]; ok {
+		return prompt, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrPromptNotFound, name)
+}
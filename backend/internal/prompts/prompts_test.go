@@ -0,0 +1,137 @@
+package prompts
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func promptFile(version, user string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(`{{/* version: ` + version + ` */}}
+{{define "system"}}system{{end}}
+{{define "user"}}` + user + `{{end}}`)}
+}
+
+func TestLoadEmbedded(t *testing.T) {
+	registry, err := Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	versions := map[string]string{Resume: "3", CoverLetter: "3", AnalyzeJob: "1", Section: "2", Refine: "2", Translate: "1"}
+	for kind, version := range versions {
+		prompt, err := registry.Lookup(kind, "classic", "en-US")
+		if err != nil {
+			t.Fatalf("Lookup(%s): %v", kind, err)
+		}
+		if prompt.Version() != kind+"@"+version {
+			t.Errorf("Lookup(%s).Version() = %q", kind, prompt.Version())
+		}
+	}
+
+	prompt, _ := registry.Lookup(Resume, "", "")
+	user, err := prompt.Render("user", map[string]string{
+		"Profile": `{"full_name": "Jo"}`, "JobDescription": "We need Go", "KeyRequirements": "", "OptionalSections": "", "Language": "", "Convention": "",
+	})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if !strings.Contains(user, "CANDIDATE PROFILE:\n{\"full_name\": \"Jo\"}") || !strings.Contains(user, "JOB DESCRIPTION:\nWe need Go") {
+		t.Errorf("rendered resume prompt:\n%s", user)
+	}
+	if strings.Contains(user, "Write all text in") {
+		t.Errorf("resume prompt without a language asks for one:\n%s", user)
+	}
+
+	user, err = prompt.Render("user", map[string]string{
+		"Profile": "{}", "JobDescription": "", "KeyRequirements": "", "OptionalSections": "", "Language": "Russian", "Convention": "",
+	})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if !strings.Contains(user, "Write all text in Russian") {
+		t.Errorf("resume prompt in Russian:\n%s", user)
+	}
+
+	// Missing data is an error rather than "<no value>" in the prompt
+	if _, err := prompt.Render("user", map[string]string{}); err == nil {
+		t.Error("Render with missing data succeeded, want an error")
+	}
+}
+
+func TestLookupPrefersLocaleThenTemplate(t *testing.T) {
+	registry, err := load(fstest.MapFS{
+		"resume.tmpl":           promptFile("1", "default"),
+		"resume.modern.tmpl":    promptFile("1", "modern"),
+		"resume.de.tmpl":        promptFile("2", "german"),
+		"resume.modern.de.tmpl": promptFile("1", "modern german"),
+		"resume.fr-ca.tmpl":     promptFile("1", "canadian french"),
+	}, nil)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	tests := []struct {
+		templateID, locale string
+		want               string
+	}{
+		{"", "", "resume@1"},
+		{"classic", "", "resume@1"},
+		{"Modern", "", "resume.modern@1"},
+		{"classic", "de", "resume.de@2"},
+		{"classic", "de-AT", "resume.de@2"},
+		{"modern", "de_DE", "resume.modern.de@1"},
+		{"modern", "fr-CA", "resume.fr-ca@1"},
+		{"modern", "fr", "resume.modern@1"},
+	}
+	for _, tt := range tests {
+		prompt, err := registry.Lookup(Resume, tt.templateID, tt.locale)
+		if err != nil {
+			t.Fatalf("Lookup(%q, %q): %v", tt.templateID, tt.locale, err)
+		}
+		if prompt.Version() != tt.want {
+			t.Errorf("Lookup(%q, %q) = %s, want %s", tt.templateID, tt.locale, prompt.Version(), tt.want)
+		}
+	}
+
+	if _, err := registry.Lookup(CoverLetter, "", ""); !errors.Is(err, ErrPromptNotFound) {
+		t.Errorf("Lookup of a missing kind = %v, want ErrPromptNotFound", err)
+	}
+}
+
+func TestOverrides(t *testing.T) {
+	registry, err := load(
+		fstest.MapFS{"resume.tmpl": promptFile("1", "embedded"), "cover_letter.tmpl": promptFile("1", "embedded")},
+		fstest.MapFS{"resume.tmpl": promptFile("2", "override"), "resume.de.tmpl": promptFile("1", "override")},
+	)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	prompt, _ := registry.Lookup(Resume, "", "")
+	if user, _ := prompt.Render("user", nil); user != "override" || prompt.Version() != "resume@2+local" {
+		t.Errorf("overridden resume = %q, %s", user, prompt.Version())
+	}
+	if prompt, _ = registry.Lookup(Resume, "", "de"); prompt.Version() != "resume.de@1+local" {
+		t.Errorf("added prompt = %s", prompt.Version())
+	}
+	if prompt, _ = registry.Lookup(CoverLetter, "", ""); prompt.Version() != "cover_letter@1" {
+		t.Errorf("embedded cover letter = %s", prompt.Version())
+	}
+}
+
+func TestLoadRejectsInvalidPrompts(t *testing.T) {
+	tests := map[string]string{
+		"no version":   `{{define "system"}}s{{end}}{{define "user"}}u{{end}}`,
+		"no user":      `{{/* version: 1 */}}{{define "system"}}s{{end}}`,
+		"syntax error": `{{/* version: 1 */}}{{define "system"}}s{{end}}{{define "user"}}{{.Profile{{end}}`,
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := load(fstest.MapFS{"resume.tmpl": {Data: []byte(content)}}, nil); err == nil {
+				t.Error("load succeeded, want an error")
+			}
+		})
+	}
+}
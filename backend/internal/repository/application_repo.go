@@ -0,0 +1,343 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrApplicationNotFound      = errors.New("application not found")
+	ErrApplicationStatusChanged = errors.New("application status was changed by another request")
+)
+
+// applicationColumns is the column list read by scanApplication
+const applicationColumns = "id, user_id, company_name, job_title, job_url, status, applied_date, notes, contacts, resume_document_id, cover_letter_document_id, status_changed_at, created_at, updated_at"
+
+type ApplicationRepository struct {
+	db *sql.DB
+}
+
+func NewApplicationRepository(db *sql.DB) *ApplicationRepository {
+	return &ApplicationRepository{db: db}
+}
+
+// CreateApplication saves an application together with the first entry of
+// its status history
+func (r *ApplicationRepository) CreateApplication(app *models.Application) error {
+	contactsJSON, err := marshalContacts(app.Contacts)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO applications (id, user_id, company_name, job_title, job_url, status, applied_date, notes, contacts,
+		                          resume_document_id, cover_letter_document_id, status_changed_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW(), NOW(), NOW())
+		RETURNING status_changed_at, created_at, updated_at
+	`
+
+	err = tx.QueryRow(
+		query,
+		app.ID,
+		app.UserID,
+		app.CompanyName,
+		app.JobTitle,
+		app.JobURL,
+		app.Status,
+		app.AppliedDate,
+		app.Notes,
+		contactsJSON,
+		app.ResumeDocumentID,
+		app.CoverLetterDocumentID,
+	).Scan(&app.StatusChangedAt, &app.CreatedAt, &app.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create application: %w", err)
+	}
+
+	historyQuery := `
+		INSERT INTO application_status_history (id, application_id, from_status, to_status, changed_at)
+		VALUES ($1, $2, NULL, $3, $4)
+	`
+	if _, err := tx.Exec(historyQuery, uuid.New(), app.ID, app.Status, app.StatusChangedAt); err != nil {
+		return fmt.Errorf("failed to record application status: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetApplicationByID retrieves one of a user's applications
+func (r *ApplicationRepository) GetApplicationByID(id, userID uuid.UUID) (*models.Application, error) {
+	query := `
+		SELECT ` + applicationColumns + `
+		FROM applications
+		WHERE id = $1 AND user_id = $2
+	`
+
+	app, err := scanApplication(r.db.QueryRow(query, id, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrApplicationNotFound
+		}
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+
+	return app, nil
+}
+
+// GetApplications lists a user's applications matching filter, most
+// recently applied first
+func (r *ApplicationRepository) GetApplications(userID uuid.UUID, filter models.ApplicationFilter) ([]*models.Application, error) {
+	conditions := []string{"user_id = $1"}
+	args := []interface{}{userID}
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if len(filter.Statuses) > 0 {
+		addCondition("status = ANY($%d)", pq.Array(filter.Statuses))
+	}
+	if filter.Company != "" {
+		addCondition("company_name ILIKE '%%' || $%d || '%%'", escapeLike(filter.Company))
+	}
+	if filter.AppliedFrom.Valid {
+		addCondition("applied_date >= $%d", filter.AppliedFrom)
+	}
+	if filter.AppliedTo.Valid {
+		addCondition("applied_date <= $%d", filter.AppliedTo)
+	}
+
+	query := `
+		SELECT ` + applicationColumns + `
+		FROM applications
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY applied_date DESC, created_at DESC
+	`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applications: %w", err)
+	}
+	defer rows.Close()
+
+	var applications []*models.Application
+	for rows.Next() {
+		app, err := scanApplication(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan application: %w", err)
+		}
+		applications = append(applications, app)
+	}
+
+	return applications, rows.Err()
+}
+
+// UpdateApplication updates everything but the status, which only changes
+// through ChangeApplicationStatus
+func (r *ApplicationRepository) UpdateApplication(app *models.Application) error {
+	contactsJSON, err := marshalContacts(app.Contacts)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE applications
+		SET company_name = $1, job_title = $2, job_url = $3, applied_date = $4, notes = $5, contacts = $6,
+		    resume_document_id = $7, cover_letter_document_id = $8
+		WHERE id = $9 AND user_id = $10
+	`
+
+	result, err := r.db.Exec(query,
+		app.CompanyName,
+		app.JobTitle,
+		app.JobURL,
+		app.AppliedDate,
+		app.Notes,
+		contactsJSON,
+		app.ResumeDocumentID,
+		app.CoverLetterDocumentID,
+		app.ID,
+		app.UserID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update application: %w", err)
+	}
+
+	return expectApplicationRow(result)
+}
+
+// ChangeApplicationStatus moves an application from change.FromStatus to
+// change.ToStatus and records the change in its history. It returns
+// ErrApplicationStatusChanged if the application is no longer in FromStatus.
+func (r *ApplicationRepository) ChangeApplicationStatus(userID uuid.UUID, change *models.ApplicationStatusChange) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE applications
+		SET status = $1, status_changed_at = NOW()
+		WHERE id = $2 AND user_id = $3 AND status = $4
+		RETURNING status_changed_at
+	`
+
+	err = tx.QueryRow(query, change.ToStatus, change.ApplicationID, userID, change.FromStatus).Scan(&change.ChangedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrApplicationStatusChanged
+		}
+		return fmt.Errorf("failed to change application status: %w", err)
+	}
+
+	historyQuery := `
+		INSERT INTO application_status_history (id, application_id, from_status, to_status, note, changed_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err = tx.Exec(historyQuery, change.ID, change.ApplicationID, change.FromStatus, change.ToStatus, change.Note, change.ChangedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record application status: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetApplicationHistory lists the status changes of one of a user's
+// applications, oldest first
+func (r *ApplicationRepository) GetApplicationHistory(id, userID uuid.UUID) ([]*models.ApplicationStatusChange, error) {
+	query := `
+		SELECT h.id, h.application_id, h.from_status, h.to_status, h.note, h.changed_at
+		FROM application_status_history h
+		JOIN applications a ON a.id = h.application_id
+		WHERE h.application_id = $1 AND a.user_id = $2
+		ORDER BY h.changed_at, h.from_status NULLS FIRST
+	`
+
+	rows, err := r.db.Query(query, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []*models.ApplicationStatusChange
+	for rows.Next() {
+		change := &models.ApplicationStatusChange{}
+		var fromStatus, note sql.NullString
+		if err := rows.Scan(&change.ID, &change.ApplicationID, &fromStatus, &change.ToStatus, &note, &change.ChangedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan application status: %w", err)
+		}
+		change.FromStatus = fromStatus.String
+		change.Note = note.String
+		history = append(history, change)
+	}
+
+	return history, rows.Err()
+}
+
+// DeleteApplication deletes an application with its history
+func (r *ApplicationRepository) DeleteApplication(id, userID uuid.UUID) error {
+	result, err := r.db.Exec(`DELETE FROM applications WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete application: %w", err)
+	}
+
+	return expectApplicationRow(result)
+}
+
+func expectApplicationRow(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrApplicationNotFound
+	}
+
+	return nil
+}
+
+// escapeLike escapes the LIKE wildcards in s so it matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// marshalContacts returns contacts as JSON, or nil for none
+func marshalContacts(contacts []models.ApplicationContact) (interface{}, error) {
+	if len(contacts) == 0 {
+		return nil, nil
+	}
+	contactsJSON, err := json.Marshal(contacts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal contacts: %w", err)
+	}
+	return contactsJSON, nil
+}
+
+// scanApplication reads an applications row selected in the standard column
+// order
+func scanApplication(row rowScanner) (*models.Application, error) {
+	app := &models.Application{}
+	var jobURL, notes sql.NullString
+	var contactsJSON []byte
+	var resumeID, coverLetterID uuid.NullUUID
+
+	err := row.Scan(
+		&app.ID,
+		&app.UserID,
+		&app.CompanyName,
+		&app.JobTitle,
+		&jobURL,
+		&app.Status,
+		&app.AppliedDate,
+		&notes,
+		&contactsJSON,
+		&resumeID,
+		&coverLetterID,
+		&app.StatusChangedAt,
+		&app.CreatedAt,
+		&app.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	app.JobURL = jobURL.String
+	app.Notes = notes.String
+	if resumeID.Valid {
+		app.ResumeDocumentID = &resumeID.UUID
+	}
+	if coverLetterID.Valid {
+		app.CoverLetterDocumentID = &coverLetterID.UUID
+	}
+
+	if contactsJSON != nil {
+		if err := json.Unmarshal(contactsJSON, &app.Contacts); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal contacts: %w", err)
+		}
+	}
+
+	return app, nil
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/google/uuid"
+)
+
+func newTestApplication(userID uuid.UUID, company string, applied models.Date) *models.Application {
+	return &models.Application{
+		ID:          uuid.New(),
+		UserID:      userID,
+		CompanyName: company,
+		JobTitle:    "Backend Engineer",
+		Status:      models.ApplicationApplied,
+		AppliedDate: applied,
+	}
+}
+
+func TestApplicationRepository_CreateAndGet(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewApplicationRepository(db)
+	user, _ := createTestUser(t, db, "apps@example.com")
+
+	resume := newTestDocument(user.ID, "Acme - Backend Engineer")
+	if err := NewDocumentRepository(db).CreateDocument(resume); err != nil {
+		t.Fatalf("CreateDocument: %v", err)
+	}
+
+	app := newTestApplication(user.ID, "Acme", date(2024, time.March, 4))
+	app.JobURL = "https://acme.example/jobs/1"
+	app.Contacts = []models.ApplicationContact{{Name: "Jo Recruiter", Role: "recruiter", Email: "jo@acme.example"}}
+	app.ResumeDocumentID = &resume.ID
+	if err := repo.CreateApplication(app); err != nil {
+		t.Fatalf("CreateApplication: %v", err)
+	}
+	if app.CreatedAt.IsZero() || app.StatusChangedAt.IsZero() {
+		t.Fatal("CreateApplication did not populate timestamps")
+	}
+
+	got, err := repo.GetApplicationByID(app.ID, user.ID)
+	if err != nil {
+		t.Fatalf("GetApplicationByID: %v", err)
+	}
+	if got.CompanyName != "Acme" || got.JobURL != app.JobURL || got.Status != models.ApplicationApplied ||
+		!sameDay(got.AppliedDate.Time, app.AppliedDate.Time) {
+		t.Errorf("GetApplicationByID = %+v", got)
+	}
+	if len(got.Contacts) != 1 || got.Contacts[0].Email != "jo@acme.example" {
+		t.Errorf("contacts = %+v", got.Contacts)
+	}
+	if got.ResumeDocumentID == nil || *got.ResumeDocumentID != resume.ID || got.CoverLetterDocumentID != nil {
+		t.Errorf("documents = %v, %v", got.ResumeDocumentID, got.CoverLetterDocumentID)
+	}
+
+	history, err := repo.GetApplicationHistory(app.ID, user.ID)
+	if err != nil {
+		t.Fatalf("GetApplicationHistory: %v", err)
+	}
+	if len(history) != 1 || history[0].FromStatus != "" || history[0].ToStatus != models.ApplicationApplied {
+		t.Errorf("initial history = %+v", history)
+	}
+
+	if _, err := repo.GetApplicationByID(app.ID, uuid.New()); err != ErrApplicationNotFound {
+		t.Errorf("GetApplicationByID other user = %v, want ErrApplicationNotFound", err)
+	}
+}
+
+func TestApplicationRepository_NullColumns(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewApplicationRepository(db)
+	user, _ := createTestUser(t, db, "appnull@example.com")
+
+	_, err := db.Exec(`
+		INSERT INTO applications (user_id, company_name, job_title, applied_date)
+		VALUES ($1, 'Initech', 'Analyst', '2024-01-15')
+	`, user.ID)
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	applications, err := repo.GetApplications(user.ID, models.ApplicationFilter{})
+	if err != nil {
+		t.Fatalf("GetApplications: %v", err)
+	}
+	if len(applications) != 1 {
+		t.Fatalf("GetApplications returned %d rows, want 1", len(applications))
+	}
+	app := applications[0]
+	if app.Status != models.ApplicationApplied || app.JobURL != "" || app.Notes != "" || app.Contacts != nil || app.ResumeDocumentID != nil {
+		t.Errorf("NULL columns not mapped to zero values: %+v", app)
+	}
+}
+
+func TestApplicationRepository_GetApplicationsFilter(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewApplicationRepository(db)
+	user, _ := createTestUser(t, db, "appfilter@example.com")
+	other, _ := createTestUser(t, db, "appfilterother@example.com")
+
+	acme := newTestApplication(user.ID, "Acme Corp", date(2024, time.January, 10))
+	globex := newTestApplication(user.ID, "Globex", date(2024, time.February, 10))
+	globex.Status = models.ApplicationInterview
+	wildcard := newTestApplication(user.ID, "100% Remote_Co", date(2024, time.March, 10))
+	foreign := newTestApplication(other.ID, "Acme Corp", date(2024, time.January, 10))
+	for _, app := range []*models.Application{acme, globex, wildcard, foreign} {
+		if err := repo.CreateApplication(app); err != nil {
+			t.Fatalf("CreateApplication: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter models.ApplicationFilter
+		want   []uuid.UUID
+	}{
+		{"all, latest first", models.ApplicationFilter{}, []uuid.UUID{wildcard.ID, globex.ID, acme.ID}},
+		{"status", models.ApplicationFilter{Statuses: []string{"interview"}}, []uuid.UUID{globex.ID}},
+		{"statuses", models.ApplicationFilter{Statuses: []string{"applied", "offer"}}, []uuid.UUID{wildcard.ID, acme.ID}},
+		{"company ignores case", models.ApplicationFilter{Company: "acme"}, []uuid.UUID{acme.ID}},
+		{"company wildcards are literal", models.ApplicationFilter{Company: "0%"}, []uuid.UUID{wildcard.ID}},
+		{"company underscore is literal", models.ApplicationFilter{Company: "e_c"}, []uuid.UUID{wildcard.ID}},
+		{"date range", models.ApplicationFilter{AppliedFrom: nullDate(2024, time.February, 1), AppliedTo: nullDate(2024, time.March, 10)}, []uuid.UUID{wildcard.ID, globex.ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applications, err := repo.GetApplications(user.ID, tt.filter)
+			if err != nil {
+				t.Fatalf("GetApplications: %v", err)
+			}
+			if len(applications) != len(tt.want) {
+				t.Fatalf("GetApplications returned %d rows, want %d", len(applications), len(tt.want))
+			}
+			for i, app := range applications {
+				if app.ID != tt.want[i] {
+					t.Errorf("applications[%d] = %s, want %s", i, app.CompanyName, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestApplicationRepository_ChangeApplicationStatus(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewApplicationRepository(db)
+	user, _ := createTestUser(t, db, "appstatus@example.com")
+
+	app := newTestApplication(user.ID, "Acme", date(2024, time.March, 4))
+	if err := repo.CreateApplication(app); err != nil {
+		t.Fatalf("CreateApplication: %v", err)
+	}
+
+	change := &models.ApplicationStatusChange{
+		ID:            uuid.New(),
+		ApplicationID: app.ID,
+		FromStatus:    models.ApplicationApplied,
+		ToStatus:      models.ApplicationInterview,
+		Note:          "Phone screen on Monday",
+	}
+	if err := repo.ChangeApplicationStatus(user.ID, change); err != nil {
+		t.Fatalf("ChangeApplicationStatus: %v", err)
+	}
+
+	got, _ := repo.GetApplicationByID(app.ID, user.ID)
+	if got.Status != models.ApplicationInterview || got.StatusChangedAt.Before(app.StatusChangedAt) {
+		t.Errorf("after status change = %+v", got)
+	}
+
+	history, err := repo.GetApplicationHistory(app.ID, user.ID)
+	if err != nil {
+		t.Fatalf("GetApplicationHistory: %v", err)
+	}
+	if len(history) != 2 || history[1].FromStatus != models.ApplicationApplied || history[1].ToStatus != models.ApplicationInterview ||
+		history[1].Note != "Phone screen on Monday" {
+		t.Errorf("history = %+v", history)
+	}
+
+	// A change from a status the application is no longer in is rejected
+	stale := &models.ApplicationStatusChange{ID: uuid.New(), ApplicationID: app.ID, FromStatus: models.ApplicationApplied, ToStatus: models.ApplicationRejected}
+	if err := repo.ChangeApplicationStatus(user.ID, stale); err != ErrApplicationStatusChanged {
+		t.Errorf("stale ChangeApplicationStatus = %v, want ErrApplicationStatusChanged", err)
+	}
+	if history, _ = repo.GetApplicationHistory(app.ID, user.ID); len(history) != 2 {
+		t.Errorf("stale change recorded history: %+v", history)
+	}
+}
+
+func TestApplicationRepository_UpdateAndDelete(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewApplicationRepository(db)
+	docRepo := NewDocumentRepository(db)
+	user, _ := createTestUser(t, db, "appupdate@example.com")
+
+	app := newTestApplication(user.ID, "Acme", date(2024, time.March, 4))
+	if err := repo.CreateApplication(app); err != nil {
+		t.Fatalf("CreateApplication: %v", err)
+	}
+
+	letter := newTestDocument(user.ID, "Acme - Cover Letter")
+	letter.Type = "cover_letter"
+	if err := docRepo.CreateDocument(letter); err != nil {
+		t.Fatalf("CreateDocument: %v", err)
+	}
+
+	app.Notes = "Referred by Sam"
+	app.CoverLetterDocumentID = &letter.ID
+	app.Status = models.ApplicationOffer // ignored, status only changes through ChangeApplicationStatus
+	if err := repo.UpdateApplication(app); err != nil {
+		t.Fatalf("UpdateApplication: %v", err)
+	}
+	got, _ := repo.GetApplicationByID(app.ID, user.ID)
+	if got.Notes != "Referred by Sam" || got.CoverLetterDocumentID == nil || got.Status != models.ApplicationApplied {
+		t.Errorf("after update = %+v", got)
+	}
+
+	// Deleting a linked document unlinks it
+	if err := docRepo.DeleteDocument(letter.ID, user.ID); err != nil {
+		t.Fatalf("DeleteDocument: %v", err)
+	}
+	if got, _ = repo.GetApplicationByID(app.ID, user.ID); got.CoverLetterDocumentID != nil {
+		t.Errorf("cover letter after document delete = %v, want nil", got.CoverLetterDocumentID)
+	}
+
+	foreign := *app
+	foreign.UserID = uuid.New()
+	if err := repo.UpdateApplication(&foreign); err != ErrApplicationNotFound {
+		t.Errorf("UpdateApplication other user = %v, want ErrApplicationNotFound", err)
+	}
+	if err := repo.DeleteApplication(app.ID, uuid.New()); err != ErrApplicationNotFound {
+		t.Errorf("DeleteApplication other user = %v, want ErrApplicationNotFound", err)
+	}
+	if err := repo.DeleteApplication(app.ID, user.ID); err != nil {
+		t.Fatalf("DeleteApplication: %v", err)
+	}
+	if _, err := repo.GetApplicationByID(app.ID, user.ID); err != ErrApplicationNotFound {
+		t.Errorf("GetApplicationByID after delete = %v, want ErrApplicationNotFound", err)
+	}
+}
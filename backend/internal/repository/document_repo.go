@@ -3,12 +3,18 @@ package repository
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/feijoa-master/ai-resume-builder/internal/models"
 	"github.com/google/uuid"
 )
 
+var ErrDocumentVersionNotFound = errors.New("document version not found")
+
+// documentColumns is the column list read by scanDocument
+const documentColumns = "id, user_id, profile_id, job_posting_id, source_id, type, title, content, template_id, job_title, company_name, job_description, job_analysis, language, convention, status, created_at, updated_at"
+
 type DocumentRepository struct {
 	db *sql.DB
 }
@@ -24,9 +30,14 @@ func (r *DocumentRepository) CreateDocument(doc *models.Document) error {
 		return fmt.Errorf("failed to marshal content: %w", err)
 	}
 
+	analysisJSON, err := marshalJobAnalysis(doc.JobAnalysis)
+	if err != nil {
+		return err
+	}
+
 	query := `
-		INSERT INTO documents (id, user_id, type, title, content, template_id, job_title, company_name, job_description, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW(), NOW())
+		INSERT INTO documents (id, user_id, profile_id, job_posting_id, source_id, type, title, content, template_id, job_title, company_name, job_description, job_analysis, language, convention, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, NOW(), NOW())
 		RETURNING id, created_at, updated_at
 	`
 
@@ -34,6 +45,9 @@ func (r *DocumentRepository) CreateDocument(doc *models.Document) error {
 		query,
 		doc.ID,
 		doc.UserID,
+		doc.ProfileID,
+		doc.JobPostingID,
+		doc.SourceID,
 		doc.Type,
 		doc.Title,
 		contentJSON,
@@ -41,6 +55,9 @@ func (r *DocumentRepository) CreateDocument(doc *models.Document) error {
 		doc.JobTitle,
 		doc.CompanyName,
 		doc.JobDescription,
+		analysisJSON,
+		doc.Language,
+		doc.Convention,
 		doc.Status,
 	).Scan(&doc.ID, &doc.CreatedAt, &doc.UpdatedAt)
 
@@ -54,29 +71,12 @@ func (r *DocumentRepository) CreateDocument(doc *models.Document) error {
 // GetDocumentByID retrieves a document by ID
 func (r *DocumentRepository) GetDocumentByID(id, userID uuid.UUID) (*models.Document, error) {
 	query := `
-		SELECT id, user_id, type, title, content, template_id, job_title, company_name, job_description, status, created_at, updated_at
+		SELECT ` + documentColumns + `
 		FROM documents
 		WHERE id = $1 AND user_id = $2
 	`
 
-	doc := &models.Document{}
-	var contentJSON []byte
-
-	err := r.db.QueryRow(query, id, userID).Scan(
-		&doc.ID,
-		&doc.UserID,
-		&doc.Type,
-		&doc.Title,
-		&contentJSON,
-		&doc.TemplateID,
-		&doc.JobTitle,
-		&doc.CompanyName,
-		&doc.JobDescription,
-		&doc.Status,
-		&doc.CreatedAt,
-		&doc.UpdatedAt,
-	)
-
+	doc, err := scanDocument(r.db.QueryRow(query, id, userID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, ErrUserNotFound
@@ -84,9 +84,30 @@ func (r *DocumentRepository) GetDocumentByID(id, userID uuid.UUID) (*models.Docu
 		return nil, fmt.Errorf("failed to get document: %w", err)
 	}
 
-	// Unmarshal content
-	if err := json.Unmarshal(contentJSON, &doc.Content); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal content: %w", err)
+	return doc, nil
+}
+
+// GetDocumentByCacheKey retrieves the user's document most recently saved
+// from a generation with the given response cache key, as long as it hasn't
+// been changed since it was saved
+func (r *DocumentRepository) GetDocumentByCacheKey(userID uuid.UUID, cacheKey string) (*models.Document, error) {
+	query := `
+		SELECT ` + documentColumns + `
+		FROM documents
+		WHERE user_id = $1 AND updated_at = created_at AND id = (
+		    SELECT document_id FROM generation_history
+		    WHERE user_id = $1 AND cache_key = $2 AND document_id IS NOT NULL
+		    ORDER BY created_at DESC
+		    LIMIT 1
+		)
+	`
+
+	doc, err := scanDocument(r.db.QueryRow(query, userID, cacheKey))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get document: %w", err)
 	}
 
 	return doc, nil
@@ -95,7 +116,7 @@ func (r *DocumentRepository) GetDocumentByID(id, userID uuid.UUID) (*models.Docu
 // GetDocuments retrieves all documents for a user
 func (r *DocumentRepository) GetDocuments(userID uuid.UUID) ([]*models.Document, error) {
 	query := `
-		SELECT id, user_id, type, title, content, template_id, job_title, company_name, job_description, status, created_at, updated_at
+		SELECT ` + documentColumns + `
 		FROM documents
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -109,43 +130,43 @@ func (r *DocumentRepository) GetDocuments(userID uuid.UUID) ([]*models.Document,
 
 	var documents []*models.Document
 	for rows.Next() {
-		doc := &models.Document{}
-		var contentJSON []byte
-
-		err := rows.Scan(
-			&doc.ID,
-			&doc.UserID,
-			&doc.Type,
-			&doc.Title,
-			&contentJSON,
-			&doc.TemplateID,
-			&doc.JobTitle,
-			&doc.CompanyName,
-			&doc.JobDescription,
-			&doc.Status,
-			&doc.CreatedAt,
-			&doc.UpdatedAt,
-		)
+		doc, err := scanDocument(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan document: %w", err)
 		}
-
-		// Unmarshal content
-		if err := json.Unmarshal(contentJSON, &doc.Content); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal content: %w", err)
-		}
-
 		documents = append(documents, doc)
 	}
 
 	return documents, nil
 }
 
-// UpdateDocument updates a document
+// UpdateDocument updates a document, snapshotting the previous title, content
+// and status into document_versions first
 func (r *DocumentRepository) UpdateDocument(doc *models.Document) error {
-	// First, get the existing document to preserve fields not being updated
-	existing, err := r.GetDocumentByID(doc.ID, doc.UserID)
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// First, get the existing document to preserve fields not being updated.
+	// The row lock serialises concurrent updates so version numbers stay unique.
+	query := `
+		SELECT ` + documentColumns + `
+		FROM documents
+		WHERE id = $1 AND user_id = $2
+		FOR UPDATE
+	`
+
+	existing, err := scanDocument(tx.QueryRow(query, doc.ID, doc.UserID))
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to get document: %w", err)
+	}
+
+	if err := r.createVersion(tx, existing); err != nil {
 		return err
 	}
 
@@ -167,13 +188,13 @@ func (r *DocumentRepository) UpdateDocument(doc *models.Document) error {
 		return fmt.Errorf("failed to marshal content: %w", err)
 	}
 
-	query := `
+	query = `
 		UPDATE documents
 		SET title = $1, content = $2, status = $3, updated_at = NOW()
 		WHERE id = $4 AND user_id = $5
 	`
 
-	result, err := r.db.Exec(query, existing.Title, contentJSON, existing.Status, doc.ID, doc.UserID)
+	result, err := tx.Exec(query, existing.Title, contentJSON, existing.Status, doc.ID, doc.UserID)
 	if err != nil {
 		return fmt.Errorf("failed to update document: %w", err)
 	}
@@ -187,6 +208,106 @@ func (r *DocumentRepository) UpdateDocument(doc *models.Document) error {
 		return ErrUserNotFound
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit document update: %w", err)
+	}
+
+	return nil
+}
+
+// createVersion stores a snapshot of doc as its next version number
+func (r *DocumentRepository) createVersion(tx *sql.Tx, doc *models.Document) error {
+	contentJSON, err := json.Marshal(doc.Content)
+	if err != nil {
+		return fmt.Errorf("failed to marshal content: %w", err)
+	}
+
+	query := `
+		INSERT INTO document_versions (id, document_id, version_number, title, content, status, created_at)
+		SELECT $1, $2, COALESCE(MAX(version_number), 0) + 1, $3, $4, $5, NOW()
+		FROM document_versions
+		WHERE document_id = $2
+	`
+
+	if _, err := tx.Exec(query, uuid.New(), doc.ID, doc.Title, contentJSON, doc.Status); err != nil {
+		return fmt.Errorf("failed to create document version: %w", err)
+	}
+
+	return nil
+}
+
+// GetDocumentVersions lists a document's saved versions, newest first
+func (r *DocumentRepository) GetDocumentVersions(documentID, userID uuid.UUID) ([]*models.DocumentVersion, error) {
+	query := `
+		SELECT v.id, v.document_id, v.version_number, v.title, v.content, v.status, v.created_at
+		FROM document_versions v
+		JOIN documents d ON d.id = v.document_id
+		WHERE v.document_id = $1 AND d.user_id = $2
+		ORDER BY v.version_number DESC
+	`
+
+	rows, err := r.db.Query(query, documentID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []*models.DocumentVersion
+	for rows.Next() {
+		version, err := scanDocumentVersion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan document version: %w", err)
+		}
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+// GetDocumentVersion retrieves a single version of a document
+func (r *DocumentRepository) GetDocumentVersion(documentID, userID uuid.UUID, versionNumber int) (*models.DocumentVersion, error) {
+	query := `
+		SELECT v.id, v.document_id, v.version_number, v.title, v.content, v.status, v.created_at
+		FROM document_versions v
+		JOIN documents d ON d.id = v.document_id
+		WHERE v.document_id = $1 AND d.user_id = $2 AND v.version_number = $3
+	`
+
+	version, err := scanDocumentVersion(r.db.QueryRow(query, documentID, userID, versionNumber))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrDocumentVersionNotFound
+		}
+		return nil, fmt.Errorf("failed to get document version: %w", err)
+	}
+
+	return version, nil
+}
+
+// UpdateDocumentJobAnalysis stores the job description analysis of a document.
+// This is metadata, so no version is recorded.
+func (r *DocumentRepository) UpdateDocumentJobAnalysis(id, userID uuid.UUID, analysis *models.JobAnalysis) error {
+	analysisJSON, err := marshalJobAnalysis(analysis)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE documents SET job_analysis = $1, updated_at = NOW() WHERE id = $2 AND user_id = $3`
+
+	result, err := r.db.Exec(query, analysisJSON, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update job analysis: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
 	return nil
 }
 
@@ -214,8 +335,9 @@ func (r *DocumentRepository) DeleteDocument(id, userID uuid.UUID) error {
 // CreateGenerationHistory saves generation metadata
 func (r *DocumentRepository) CreateGenerationHistory(history *models.GenerationHistory) error {
 	query := `
-		INSERT INTO generation_history (id, user_id, document_id, prompt_tokens, completion_tokens, total_cost, generation_time_ms, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		INSERT INTO generation_history (id, user_id, document_id, prompt_tokens, completion_tokens, cached_tokens, total_cost, generation_time_ms,
+		                                prompt_version, experiment, variant, provider, model, cached, cache_key, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), NULLIF($10, ''), NULLIF($11, ''), NULLIF($12, ''), NULLIF($13, ''), $14, NULLIF($15, ''), NOW())
 	`
 
 	_, err := r.db.Exec(
@@ -225,8 +347,16 @@ func (r *DocumentRepository) CreateGenerationHistory(history *models.GenerationH
 		history.DocumentID,
 		history.PromptTokens,
 		history.CompletionTokens,
+		history.CachedTokens,
 		history.TotalCost,
 		history.GenerationTimeMs,
+		history.PromptVersion,
+		history.Experiment,
+		history.Variant,
+		history.Provider,
+		history.Model,
+		history.Cached,
+		history.CacheKey,
 	)
 
 	if err != nil {
@@ -235,3 +365,176 @@ func (r *DocumentRepository) CreateGenerationHistory(history *models.GenerationH
 
 	return nil
 }
+
+// CreateDocumentMessage saves a refinement chat message
+func (r *DocumentRepository) CreateDocumentMessage(msg *models.DocumentMessage) error {
+	query := `
+		INSERT INTO document_messages (id, document_id, role, content, generation_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		msg.ID,
+		msg.DocumentID,
+		msg.Role,
+		msg.Content,
+		msg.GenerationID,
+	).Scan(&msg.ID, &msg.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create document message: %w", err)
+	}
+
+	return nil
+}
+
+// GetDocumentMessages retrieves a document's refinement chat, oldest first
+func (r *DocumentRepository) GetDocumentMessages(documentID, userID uuid.UUID) ([]*models.DocumentMessage, error) {
+	query := `
+		SELECT m.id, m.document_id, m.role, m.content, m.generation_id, m.created_at
+		FROM document_messages m
+		JOIN documents d ON d.id = m.document_id
+		WHERE m.document_id = $1 AND d.user_id = $2
+		ORDER BY m.created_at, m.id
+	`
+
+	rows, err := r.db.Query(query, documentID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []*models.DocumentMessage
+	for rows.Next() {
+		msg := &models.DocumentMessage{}
+		var generationID uuid.NullUUID
+		err := rows.Scan(
+			&msg.ID,
+			&msg.DocumentID,
+			&msg.Role,
+			&msg.Content,
+			&generationID,
+			&msg.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan document message: %w", err)
+		}
+		if generationID.Valid {
+			msg.GenerationID = &generationID.UUID
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanDocument reads a documents row selected in the standard column order
+func scanDocument(row rowScanner) (*models.Document, error) {
+	doc := &models.Document{}
+	var contentJSON, analysisJSON []byte
+	var profileID, jobPostingID, sourceID uuid.NullUUID
+
+	// Use sql.NullString for nullable fields
+	var templateID, jobTitle, companyName, jobDescription, status sql.NullString
+
+	err := row.Scan(
+		&doc.ID,
+		&doc.UserID,
+		&profileID,
+		&jobPostingID,
+		&sourceID,
+		&doc.Type,
+		&doc.Title,
+		&contentJSON,
+		&templateID,
+		&jobTitle,
+		&companyName,
+		&jobDescription,
+		&analysisJSON,
+		&doc.Language,
+		&doc.Convention,
+		&status,
+		&doc.CreatedAt,
+		&doc.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if profileID.Valid {
+		doc.ProfileID = &profileID.UUID
+	}
+	if jobPostingID.Valid {
+		doc.JobPostingID = &jobPostingID.UUID
+	}
+	if sourceID.Valid {
+		doc.SourceID = &sourceID.UUID
+	}
+	doc.TemplateID = templateID.String
+	doc.JobTitle = jobTitle.String
+	doc.CompanyName = companyName.String
+	doc.JobDescription = jobDescription.String
+	doc.Status = status.String
+
+	// Unmarshal content
+	if err := json.Unmarshal(contentJSON, &doc.Content); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal content: %w", err)
+	}
+
+	if analysisJSON != nil {
+		doc.JobAnalysis = &models.JobAnalysis{}
+		if err := json.Unmarshal(analysisJSON, doc.JobAnalysis); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal job analysis: %w", err)
+		}
+	}
+
+	return doc, nil
+}
+
+// marshalJobAnalysis encodes an analysis for a nullable JSONB column.
+// It returns an untyped nil for a nil analysis so the driver sends NULL.
+func marshalJobAnalysis(analysis *models.JobAnalysis) (interface{}, error) {
+	if analysis == nil {
+		return nil, nil
+	}
+	analysisJSON, err := json.Marshal(analysis)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal job analysis: %w", err)
+	}
+	return analysisJSON, nil
+}
+
+// scanDocumentVersion reads a document_versions row
+func scanDocumentVersion(row rowScanner) (*models.DocumentVersion, error) {
+	version := &models.DocumentVersion{}
+	var contentJSON []byte
+	var status sql.NullString
+
+	err := row.Scan(
+		&version.ID,
+		&version.DocumentID,
+		&version.Version,
+		&version.Title,
+		&contentJSON,
+		&status,
+		&version.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	version.Status = status.String
+
+	if err := json.Unmarshal(contentJSON, &version.Content); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal content: %w", err)
+	}
+
+	return version, nil
+}
@@ -0,0 +1,513 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/google/uuid"
+)
+
+func newTestDocument(userID uuid.UUID, title string) *models.Document {
+	return &models.Document{
+		ID:     uuid.New(),
+		UserID: userID,
+		Type:   "resume",
+		Title:  title,
+		Content: map[string]interface{}{
+			"summary": "Experienced engineer",
+			"skills":  []interface{}{"Go", "SQL"},
+		},
+		TemplateID:     "classic",
+		JobTitle:       "Backend Engineer",
+		CompanyName:    "Acme",
+		JobDescription: "We need Go",
+		Status:         "final",
+	}
+}
+
+func TestDocumentRepository_CreateAndGet(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewDocumentRepository(db)
+	user, _ := createTestUser(t, db, "docs@example.com")
+
+	doc := newTestDocument(user.ID, "Acme - Backend Engineer")
+	if err := repo.CreateDocument(doc); err != nil {
+		t.Fatalf("CreateDocument: %v", err)
+	}
+	if doc.CreatedAt.IsZero() {
+		t.Fatal("CreateDocument did not populate created_at")
+	}
+
+	got, err := repo.GetDocumentByID(doc.ID, user.ID)
+	if err != nil {
+		t.Fatalf("GetDocumentByID: %v", err)
+	}
+	if got.Title != doc.Title || got.TemplateID != "classic" || got.JobDescription != "We need Go" || got.Status != "final" {
+		t.Errorf("GetDocumentByID = %+v", got)
+	}
+	if got.Content["summary"] != "Experienced engineer" {
+		t.Errorf("content = %v", got.Content)
+	}
+
+	// Another user must not see the document
+	if _, err := repo.GetDocumentByID(doc.ID, uuid.New()); err != ErrUserNotFound {
+		t.Errorf("GetDocumentByID other user = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestDocumentRepository_ProfileID(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewDocumentRepository(db)
+	profileRepo := NewProfileRepository(db)
+	user, _ := createTestUser(t, db, "docprofile@example.com")
+
+	persona := &models.Profile{ID: uuid.New(), UserID: user.ID, Name: "Manager"}
+	if err := profileRepo.CreateProfile(persona); err != nil {
+		t.Fatalf("CreateProfile: %v", err)
+	}
+
+	doc := newTestDocument(user.ID, "Acme - Engineering Manager")
+	doc.ProfileID = &persona.ID
+	if err := repo.CreateDocument(doc); err != nil {
+		t.Fatalf("CreateDocument: %v", err)
+	}
+	got, err := repo.GetDocumentByID(doc.ID, user.ID)
+	if err != nil {
+		t.Fatalf("GetDocumentByID: %v", err)
+	}
+	if got.ProfileID == nil || *got.ProfileID != persona.ID {
+		t.Errorf("ProfileID = %v, want %s", got.ProfileID, persona.ID)
+	}
+
+	// Deleting the profile keeps the document
+	if err := profileRepo.DeleteProfile(persona.ID, user.ID); err != nil {
+		t.Fatalf("DeleteProfile: %v", err)
+	}
+	got, err = repo.GetDocumentByID(doc.ID, user.ID)
+	if err != nil {
+		t.Fatalf("GetDocumentByID after profile delete: %v", err)
+	}
+	if got.ProfileID != nil {
+		t.Errorf("ProfileID after profile delete = %v, want nil", got.ProfileID)
+	}
+}
+
+func TestDocumentRepository_GetDocuments(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewDocumentRepository(db)
+	user, _ := createTestUser(t, db, "list@example.com")
+	other, _ := createTestUser(t, db, "list-other@example.com")
+
+	first := newTestDocument(user.ID, "First")
+	second := newTestDocument(user.ID, "Second")
+	for _, doc := range []*models.Document{first, second, newTestDocument(other.ID, "Other")} {
+		if err := repo.CreateDocument(doc); err != nil {
+			t.Fatalf("CreateDocument: %v", err)
+		}
+	}
+	// Make ordering deterministic regardless of clock resolution
+	if _, err := db.Exec(`UPDATE documents SET created_at = created_at - INTERVAL '1 hour' WHERE id = $1`, first.ID); err != nil {
+		t.Fatalf("backdate: %v", err)
+	}
+
+	docs, err := repo.GetDocuments(user.ID)
+	if err != nil {
+		t.Fatalf("GetDocuments: %v", err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("GetDocuments returned %d documents, want 2", len(docs))
+	}
+	if docs[0].ID != second.ID || docs[1].ID != first.ID {
+		t.Errorf("GetDocuments order = %s, %s", docs[0].Title, docs[1].Title)
+	}
+
+	empty, err := repo.GetDocuments(uuid.New())
+	if err != nil {
+		t.Fatalf("GetDocuments unknown user: %v", err)
+	}
+	if len(empty) != 0 {
+		t.Errorf("GetDocuments unknown user returned %d documents", len(empty))
+	}
+}
+
+func TestDocumentRepository_NullColumns(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewDocumentRepository(db)
+	user, _ := createTestUser(t, db, "docnull@example.com")
+
+	id := uuid.New()
+	_, err := db.Exec(`
+		INSERT INTO documents (id, user_id, type, title, content, template_id, job_title, company_name, job_description, status)
+		VALUES ($1, $2, 'cover_letter', 'Bare', '{}', NULL, NULL, NULL, NULL, NULL)
+	`, id, user.ID)
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	got, err := repo.GetDocumentByID(id, user.ID)
+	if err != nil {
+		t.Fatalf("GetDocumentByID: %v", err)
+	}
+	if got.TemplateID != "" || got.JobTitle != "" || got.CompanyName != "" || got.JobDescription != "" || got.Status != "" {
+		t.Errorf("NULL columns not mapped to empty strings: %+v", got)
+	}
+
+	docs, err := repo.GetDocuments(user.ID)
+	if err != nil {
+		t.Fatalf("GetDocuments: %v", err)
+	}
+	if len(docs) != 1 {
+		t.Errorf("GetDocuments returned %d documents, want 1", len(docs))
+	}
+}
+
+func TestDocumentRepository_UpdateDocument(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewDocumentRepository(db)
+	user, _ := createTestUser(t, db, "docupdate@example.com")
+
+	doc := newTestDocument(user.ID, "Original")
+	if err := repo.CreateDocument(doc); err != nil {
+		t.Fatalf("CreateDocument: %v", err)
+	}
+
+	// A title-only update must keep the existing content and status
+	if err := repo.UpdateDocument(&models.Document{ID: doc.ID, UserID: user.ID, Title: "Renamed"}); err != nil {
+		t.Fatalf("UpdateDocument: %v", err)
+	}
+	got, _ := repo.GetDocumentByID(doc.ID, user.ID)
+	if got.Title != "Renamed" || got.Status != "final" || got.Content["summary"] != "Experienced engineer" {
+		t.Errorf("after title update = %+v", got)
+	}
+
+	content := map[string]interface{}{"summary": "Rewritten"}
+	if err := repo.UpdateDocument(&models.Document{ID: doc.ID, UserID: user.ID, Content: content, Status: "draft"}); err != nil {
+		t.Fatalf("UpdateDocument: %v", err)
+	}
+	got, _ = repo.GetDocumentByID(doc.ID, user.ID)
+	if got.Title != "Renamed" || got.Status != "draft" || got.Content["summary"] != "Rewritten" {
+		t.Errorf("after content update = %+v", got)
+	}
+
+	if err := repo.UpdateDocument(&models.Document{ID: doc.ID, UserID: uuid.New(), Title: "Hijack"}); err != ErrUserNotFound {
+		t.Errorf("UpdateDocument other user = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestDocumentRepository_DeleteDocument(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewDocumentRepository(db)
+	user, _ := createTestUser(t, db, "docdelete@example.com")
+
+	doc := newTestDocument(user.ID, "To delete")
+	if err := repo.CreateDocument(doc); err != nil {
+		t.Fatalf("CreateDocument: %v", err)
+	}
+
+	if err := repo.DeleteDocument(doc.ID, uuid.New()); err != ErrUserNotFound {
+		t.Errorf("DeleteDocument other user = %v, want ErrUserNotFound", err)
+	}
+	if err := repo.DeleteDocument(doc.ID, user.ID); err != nil {
+		t.Fatalf("DeleteDocument: %v", err)
+	}
+	if _, err := repo.GetDocumentByID(doc.ID, user.ID); err != ErrUserNotFound {
+		t.Errorf("GetDocumentByID after delete = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestDocumentRepository_CreateGenerationHistory(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewDocumentRepository(db)
+	user, _ := createTestUser(t, db, "history@example.com")
+
+	doc := newTestDocument(user.ID, "With history")
+	if err := repo.CreateDocument(doc); err != nil {
+		t.Fatalf("CreateDocument: %v", err)
+	}
+
+	history := &models.GenerationHistory{
+		ID:               uuid.New(),
+		UserID:           user.ID,
+		DocumentID:       &doc.ID,
+		PromptTokens:     1200,
+		CompletionTokens: 800,
+		CachedTokens:     1024,
+		TotalCost:        0.0007,
+		GenerationTimeMs: 4200,
+		PromptVersion:    "resume@1",
+		Provider:         "openai",
+		Model:            "gpt-4o-mini",
+		CacheKey:         "resume-key",
+	}
+	if err := repo.CreateGenerationHistory(history); err != nil {
+		t.Fatalf("CreateGenerationHistory: %v", err)
+	}
+
+	var promptTokens, completionTokens, cachedTokens int
+	var cost float64
+	var promptVersion, provider, model string
+	err := db.QueryRow(`SELECT prompt_tokens, completion_tokens, cached_tokens, total_cost, prompt_version, provider, model FROM generation_history WHERE id = $1`, history.ID).
+		Scan(&promptTokens, &completionTokens, &cachedTokens, &cost, &promptVersion, &provider, &model)
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if promptTokens != 1200 || completionTokens != 800 || cachedTokens != 1024 || cost != 0.0007 ||
+		promptVersion != "resume@1" || provider != "openai" || model != "gpt-4o-mini" {
+		t.Errorf("history row = %d, %d, %d, %v, %q, %q, %q", promptTokens, completionTokens, cachedTokens, cost, promptVersion, provider, model)
+	}
+
+	// Calls not tied to a document, like an enriched job analysis, are kept too
+	standalone := &models.GenerationHistory{ID: uuid.New(), UserID: user.ID, PromptVersion: "analyze_job@1"}
+	if err := repo.CreateGenerationHistory(standalone); err != nil {
+		t.Fatalf("CreateGenerationHistory without document: %v", err)
+	}
+	var documentID *uuid.UUID
+	if err := db.QueryRow(`SELECT document_id FROM generation_history WHERE id = $1`, standalone.ID).Scan(&documentID); err != nil || documentID != nil {
+		t.Errorf("history without document = %v, %v, want NULL", documentID, err)
+	}
+
+	// A cached response finds the document its first generation saved
+	if cached, err := repo.GetDocumentByCacheKey(user.ID, "resume-key"); err != nil || cached.ID != doc.ID {
+		t.Errorf("GetDocumentByCacheKey = %+v, %v, want the document", cached, err)
+	}
+	if _, err := repo.GetDocumentByCacheKey(uuid.New(), "resume-key"); err != ErrUserNotFound {
+		t.Errorf("GetDocumentByCacheKey other user = %v, want ErrUserNotFound", err)
+	}
+
+	// Once the user edits the document it's no longer the cached result
+	doc.Title = "Edited"
+	if err := repo.UpdateDocument(doc); err != nil {
+		t.Fatalf("UpdateDocument: %v", err)
+	}
+	if _, err := repo.GetDocumentByCacheKey(user.ID, "resume-key"); err != ErrUserNotFound {
+		t.Errorf("GetDocumentByCacheKey after edit = %v, want ErrUserNotFound", err)
+	}
+
+	// History rows go away with their document
+	if err := repo.DeleteDocument(doc.ID, user.ID); err != nil {
+		t.Fatalf("DeleteDocument: %v", err)
+	}
+	if _, err := repo.GetDocumentByCacheKey(user.ID, "resume-key"); err != ErrUserNotFound {
+		t.Errorf("GetDocumentByCacheKey after delete = %v, want ErrUserNotFound", err)
+	}
+	var count int
+	db.QueryRow(`SELECT COUNT(*) FROM generation_history WHERE document_id = $1`, doc.ID).Scan(&count)
+	if count != 0 {
+		t.Errorf("generation_history rows after delete = %d, want 0", count)
+	}
+}
+
+func TestDocumentRepository_UpdateDocumentSnapshotsVersions(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewDocumentRepository(db)
+	user, _ := createTestUser(t, db, "versions@example.com")
+
+	doc := newTestDocument(user.ID, "v1 title")
+	if err := repo.CreateDocument(doc); err != nil {
+		t.Fatalf("CreateDocument: %v", err)
+	}
+
+	updates := []*models.Document{
+		{ID: doc.ID, UserID: user.ID, Title: "v2 title", Content: map[string]interface{}{"summary": "second"}},
+		{ID: doc.ID, UserID: user.ID, Content: map[string]interface{}{"summary": "third"}, Status: "draft"},
+	}
+	for _, update := range updates {
+		if err := repo.UpdateDocument(update); err != nil {
+			t.Fatalf("UpdateDocument: %v", err)
+		}
+	}
+
+	versions, err := repo.GetDocumentVersions(doc.ID, user.ID)
+	if err != nil {
+		t.Fatalf("GetDocumentVersions: %v", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("GetDocumentVersions returned %d versions, want 2", len(versions))
+	}
+	// Newest first, each holding the state before the matching update
+	if versions[0].Version != 2 || versions[0].Content["summary"] != "second" || versions[0].Title != "v2 title" {
+		t.Errorf("version 2 = %+v", versions[0])
+	}
+	if versions[1].Version != 1 || versions[1].Content["summary"] != "Experienced engineer" || versions[1].Status != "final" {
+		t.Errorf("version 1 = %+v", versions[1])
+	}
+
+	v1, err := repo.GetDocumentVersion(doc.ID, user.ID, 1)
+	if err != nil {
+		t.Fatalf("GetDocumentVersion: %v", err)
+	}
+	if v1.Title != "v1 title" {
+		t.Errorf("GetDocumentVersion title = %q", v1.Title)
+	}
+
+	if _, err := repo.GetDocumentVersion(doc.ID, user.ID, 3); err != ErrDocumentVersionNotFound {
+		t.Errorf("GetDocumentVersion missing = %v, want ErrDocumentVersionNotFound", err)
+	}
+	if _, err := repo.GetDocumentVersion(doc.ID, uuid.New(), 1); err != ErrDocumentVersionNotFound {
+		t.Errorf("GetDocumentVersion other user = %v, want ErrDocumentVersionNotFound", err)
+	}
+	others, err := repo.GetDocumentVersions(doc.ID, uuid.New())
+	if err != nil || len(others) != 0 {
+		t.Errorf("GetDocumentVersions other user = %d versions, %v", len(others), err)
+	}
+}
+
+func TestDocumentRepository_FailedUpdateLeavesNoVersion(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewDocumentRepository(db)
+	user, _ := createTestUser(t, db, "noversion@example.com")
+
+	doc := newTestDocument(user.ID, "Untouched")
+	if err := repo.CreateDocument(doc); err != nil {
+		t.Fatalf("CreateDocument: %v", err)
+	}
+
+	// Title longer than VARCHAR(255) makes the UPDATE fail after the snapshot
+	long := make([]byte, 300)
+	for i := range long {
+		long[i] = 'x'
+	}
+	if err := repo.UpdateDocument(&models.Document{ID: doc.ID, UserID: user.ID, Title: string(long)}); err == nil {
+		t.Fatal("UpdateDocument with oversized title returned nil error")
+	}
+
+	versions, err := repo.GetDocumentVersions(doc.ID, user.ID)
+	if err != nil {
+		t.Fatalf("GetDocumentVersions: %v", err)
+	}
+	if len(versions) != 0 {
+		t.Errorf("failed update left %d versions behind", len(versions))
+	}
+}
+
+func TestDocumentRepository_DocumentMessages(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewDocumentRepository(db)
+	user, _ := createTestUser(t, db, "chat@example.com")
+
+	doc := newTestDocument(user.ID, "Chat")
+	if err := repo.CreateDocument(doc); err != nil {
+		t.Fatalf("CreateDocument: %v", err)
+	}
+
+	history := &models.GenerationHistory{ID: uuid.New(), UserID: user.ID, DocumentID: &doc.ID}
+	if err := repo.CreateGenerationHistory(history); err != nil {
+		t.Fatalf("CreateGenerationHistory: %v", err)
+	}
+
+	question := &models.DocumentMessage{ID: uuid.New(), DocumentID: doc.ID, Role: "user", Content: "make it more concise"}
+	if err := repo.CreateDocumentMessage(question); err != nil {
+		t.Fatalf("CreateDocumentMessage: %v", err)
+	}
+	answer := &models.DocumentMessage{ID: uuid.New(), DocumentID: doc.ID, Role: "assistant", Content: "Shortened the summary.", GenerationID: &history.ID}
+	if err := repo.CreateDocumentMessage(answer); err != nil {
+		t.Fatalf("CreateDocumentMessage: %v", err)
+	}
+
+	messages, err := repo.GetDocumentMessages(doc.ID, user.ID)
+	if err != nil {
+		t.Fatalf("GetDocumentMessages: %v", err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("GetDocumentMessages returned %d messages, want 2", len(messages))
+	}
+	if messages[0].Role != "user" || messages[0].GenerationID != nil {
+		t.Errorf("first message = %+v", messages[0])
+	}
+	if messages[1].Role != "assistant" || messages[1].GenerationID == nil || *messages[1].GenerationID != history.ID {
+		t.Errorf("second message = %+v", messages[1])
+	}
+
+	others, err := repo.GetDocumentMessages(doc.ID, uuid.New())
+	if err != nil || len(others) != 0 {
+		t.Errorf("GetDocumentMessages other user = %d messages, %v", len(others), err)
+	}
+}
+
+func TestDocumentRepository_JobAnalysis(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewDocumentRepository(db)
+	user, _ := createTestUser(t, db, "analysis@example.com")
+
+	// Documents without an analysis keep job_analysis NULL
+	doc := newTestDocument(user.ID, "No analysis")
+	if err := repo.CreateDocument(doc); err != nil {
+		t.Fatalf("CreateDocument: %v", err)
+	}
+	got, err := repo.GetDocumentByID(doc.ID, user.ID)
+	if err != nil {
+		t.Fatalf("GetDocumentByID: %v", err)
+	}
+	if got.JobAnalysis != nil {
+		t.Errorf("JobAnalysis = %+v, want nil", got.JobAnalysis)
+	}
+
+	analysis := &models.JobAnalysis{
+		RequiredSkills:   []string{"Go", "PostgreSQL"},
+		NiceToHaveSkills: []string{"Kafka"},
+		Seniority:        "senior",
+		YearsExperience:  5,
+		Responsibilities: []string{"Build services"},
+		Keywords:         []string{"Go", "backend"},
+	}
+	if err := repo.UpdateDocumentJobAnalysis(doc.ID, user.ID, analysis); err != nil {
+		t.Fatalf("UpdateDocumentJobAnalysis: %v", err)
+	}
+	got, _ = repo.GetDocumentByID(doc.ID, user.ID)
+	if got.JobAnalysis == nil || got.JobAnalysis.Seniority != "senior" || len(got.JobAnalysis.RequiredSkills) != 2 {
+		t.Errorf("JobAnalysis after update = %+v", got.JobAnalysis)
+	}
+
+	withAnalysis := newTestDocument(user.ID, "With analysis")
+	withAnalysis.JobAnalysis = analysis
+	if err := repo.CreateDocument(withAnalysis); err != nil {
+		t.Fatalf("CreateDocument: %v", err)
+	}
+	got, _ = repo.GetDocumentByID(withAnalysis.ID, user.ID)
+	if got.JobAnalysis == nil || got.JobAnalysis.NiceToHaveSkills[0] != "Kafka" {
+		t.Errorf("JobAnalysis on create = %+v", got.JobAnalysis)
+	}
+
+	if err := repo.UpdateDocumentJobAnalysis(doc.ID, uuid.New(), analysis); err != ErrUserNotFound {
+		t.Errorf("UpdateDocumentJobAnalysis other user = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestDocumentRepository_Translation(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewDocumentRepository(db)
+	user, _ := createTestUser(t, db, "translation@example.com")
+
+	original := newTestDocument(user.ID, "Acme - Backend Engineer")
+	if err := repo.CreateDocument(original); err != nil {
+		t.Fatalf("CreateDocument: %v", err)
+	}
+
+	translation := newTestDocument(user.ID, "Acme - Backend Engineer (German)")
+	translation.SourceID = &original.ID
+	translation.Language = "de"
+	translation.Convention = "de"
+	if err := repo.CreateDocument(translation); err != nil {
+		t.Fatalf("CreateDocument: %v", err)
+	}
+
+	got, err := repo.GetDocumentByID(translation.ID, user.ID)
+	if err != nil {
+		t.Fatalf("GetDocumentByID: %v", err)
+	}
+	if got.SourceID == nil || *got.SourceID != original.ID || got.Language != "de" || got.Convention != "de" {
+		t.Errorf("translation = source %v, language %q, convention %q", got.SourceID, got.Language, got.Convention)
+	}
+
+	// Deleting the original keeps the translation, unlinked
+	if err := repo.DeleteDocument(original.ID, user.ID); err != nil {
+		t.Fatalf("DeleteDocument: %v", err)
+	}
+	got, err = repo.GetDocumentByID(translation.ID, user.ID)
+	if err != nil {
+		t.Fatalf("GetDocumentByID after deleting the original: %v", err)
+	}
+	if got.SourceID != nil || got.Language != "de" {
+		t.Errorf("translation after delete = source %v, language %q", got.SourceID, got.Language)
+	}
+}
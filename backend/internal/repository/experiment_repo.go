@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+)
+
+type ExperimentRepository struct {
+	db *sql.DB
+}
+
+func NewExperimentRepository(db *sql.DB) *ExperimentRepository {
+	return &ExperimentRepository{db: db}
+}
+
+// CreateDocumentSignal records a signal on a document. A thumbs up replaces
+// an earlier thumbs down and the other way round, so only the latest rating
+// counts.
+func (r *ExperimentRepository) CreateDocumentSignal(signal *models.DocumentSignal) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var opposite string
+	switch signal.Signal {
+	case models.SignalThumbsUp:
+		opposite = models.SignalThumbsDown
+	case models.SignalThumbsDown:
+		opposite = models.SignalThumbsUp
+	}
+	if opposite != "" {
+		_, err := tx.Exec(`DELETE FROM document_signals WHERE document_id = $1 AND signal = $2`, signal.DocumentID, opposite)
+		if err != nil {
+			return fmt.Errorf("failed to create document signal: %w", err)
+		}
+	}
+
+	query := `
+		INSERT INTO document_signals (id, document_id, user_id, signal, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING created_at
+	`
+
+	err = tx.QueryRow(query, signal.ID, signal.DocumentID, signal.UserID, signal.Signal).Scan(&signal.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create document signal: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetVariantReports sums up the generations of an experiment per variant.
+// A document belongs to the variant that generated it; its signals count
+// from the variant's first generation on.
+func (r *ExperimentRepository) GetVariantReports(experiment string) ([]*models.VariantReport, error) {
+	query := `
+		SELECT variant, COUNT(*), COUNT(DISTINCT document_id),
+		       AVG(prompt_tokens), AVG(completion_tokens), AVG(generation_time_ms),
+		       COALESCE(SUM(total_cost), 0)
+		FROM generation_history
+		WHERE experiment = $1
+		GROUP BY variant
+		ORDER BY variant
+	`
+
+	rows, err := r.db.Query(query, experiment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get experiment report: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []*models.VariantReport
+	byVariant := make(map[string]*models.VariantReport)
+	for rows.Next() {
+		report := &models.VariantReport{Signals: map[string]int{}}
+		err := rows.Scan(
+			&report.Variant,
+			&report.Generations,
+			&report.Documents,
+			&report.AvgPromptTokens,
+			&report.AvgCompletionTokens,
+			&report.AvgGenerationTimeMs,
+			&report.TotalCost,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan experiment report: %w", err)
+		}
+		reports = append(reports, report)
+		byVariant[report.Variant] = report
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get experiment report: %w", err)
+	}
+
+	query = `
+		WITH assigned AS (
+			SELECT variant, document_id, MIN(created_at) AS generated_at
+			FROM generation_history
+			WHERE experiment = $1
+			GROUP BY variant, document_id
+		)
+		SELECT a.variant, s.signal, COUNT(DISTINCT s.document_id)
+		FROM assigned a
+		JOIN document_signals s ON s.document_id = a.document_id AND s.created_at >= a.generated_at
+		GROUP BY a.variant, s.signal
+	`
+
+	rows, err = r.db.Query(query, experiment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get experiment signals: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var variant, signal string
+		var documents int
+		if err := rows.Scan(&variant, &signal, &documents); err != nil {
+			return nil, fmt.Errorf("failed to scan experiment signal: %w", err)
+		}
+		if report, ok := byVariant[variant]; ok {
+			report.Signals[signal] = documents
+		}
+	}
+
+	return reports, rows.Err()
+}
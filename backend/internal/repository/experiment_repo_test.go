@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestExperimentRepository_VariantReports(t *testing.T) {
+	db := newTestDB(t)
+	documentRepo := NewDocumentRepository(db)
+	repo := NewExperimentRepository(db)
+	user, _ := createTestUser(t, db, "experiments@example.com")
+
+	generate := func(variant string, promptTokens int) uuid.UUID {
+		t.Helper()
+		doc := newTestDocument(user.ID, variant)
+		if err := documentRepo.CreateDocument(doc); err != nil {
+			t.Fatalf("CreateDocument: %v", err)
+		}
+		err := documentRepo.CreateGenerationHistory(&models.GenerationHistory{
+			ID:               uuid.New(),
+			UserID:           user.ID,
+			DocumentID:       &doc.ID,
+			PromptTokens:     promptTokens,
+			CompletionTokens: 500,
+			TotalCost:        0.001,
+			GenerationTimeMs: 3000,
+			Experiment:       "resume-concise",
+			Variant:          variant,
+		})
+		if err != nil {
+			t.Fatalf("CreateGenerationHistory: %v", err)
+		}
+		return doc.ID
+	}
+	signal := func(docID uuid.UUID, signal string) {
+		t.Helper()
+		err := repo.CreateDocumentSignal(&models.DocumentSignal{ID: uuid.New(), DocumentID: docID, UserID: user.ID, Signal: signal})
+		if err != nil {
+			t.Fatalf("CreateDocumentSignal: %v", err)
+		}
+	}
+
+	control1 := generate("control", 1000)
+	control2 := generate("control", 2000)
+	concise := generate("concise", 600)
+	signal(control1, models.SignalEdited)
+	signal(control1, models.SignalEdited)
+	signal(control2, models.SignalThumbsUp)
+	signal(concise, models.SignalThumbsUp)
+	signal(concise, models.SignalThumbsDown) // replaces the thumbs up
+
+	// Generations outside the experiment don't count
+	other := newTestDocument(user.ID, "other")
+	if err := documentRepo.CreateDocument(other); err != nil {
+		t.Fatalf("CreateDocument: %v", err)
+	}
+	documentRepo.CreateGenerationHistory(&models.GenerationHistory{ID: uuid.New(), UserID: user.ID, DocumentID: &other.ID})
+	signal(other.ID, models.SignalDownloaded)
+
+	reports, err := repo.GetVariantReports("resume-concise")
+	if err != nil {
+		t.Fatalf("GetVariantReports: %v", err)
+	}
+	if len(reports) != 2 {
+		t.Fatalf("got %d variants, want 2", len(reports))
+	}
+
+	// Variants come sorted by name
+	got, want := reports[0], &models.VariantReport{
+		Variant: "concise", Generations: 1, Documents: 1, AvgPromptTokens: 600,
+		Signals: map[string]int{models.SignalThumbsDown: 1},
+	}
+	if got.Variant != want.Variant || got.Generations != want.Generations || got.Documents != want.Documents ||
+		got.AvgPromptTokens != want.AvgPromptTokens || len(got.Signals) != 1 || got.Signals[models.SignalThumbsDown] != 1 {
+		t.Errorf("concise = %+v", got)
+	}
+
+	got = reports[1]
+	if got.Variant != "control" || got.Generations != 2 || got.Documents != 2 || got.AvgPromptTokens != 1500 ||
+		got.AvgCompletionTokens != 500 || got.TotalCost != 0.002 {
+		t.Errorf("control = %+v", got)
+	}
+	if got.Signals[models.SignalEdited] != 1 || got.Signals[models.SignalThumbsUp] != 1 || len(got.Signals) != 2 {
+		t.Errorf("control signals = %v", got.Signals)
+	}
+
+	if reports, err := repo.GetVariantReports("unknown"); err != nil || len(reports) != 0 {
+		t.Errorf("GetVariantReports(unknown) = %v, %v", reports, err)
+	}
+}
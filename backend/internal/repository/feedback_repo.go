@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+var ErrFeedbackNotFound = errors.New("feedback not found")
+
+// feedbackColumns is the column list read by scanFeedback
+const feedbackColumns = "id, user_id, document_id, generation_id, section, rating, reasons, comment, created_at, updated_at"
+
+// Feedback scopes for GetFeedbackSummaries
+const (
+	FeedbackScopeAll      = ""
+	FeedbackScopeDocument = "document"
+	FeedbackScopeSection  = "section"
+)
+
+type FeedbackRepository struct {
+	db *sql.DB
+}
+
+func NewFeedbackRepository(db *sql.DB) *FeedbackRepository {
+	return &FeedbackRepository{db: db}
+}
+
+// SaveFeedback stores a rating of one of a user's documents, replacing an
+// earlier rating of the same document or section. The rating is tied to the
+// document's latest generation.
+func (r *FeedbackRepository) SaveFeedback(feedback *models.GenerationFeedback) error {
+	query := `
+		INSERT INTO generation_feedback (id, user_id, document_id, generation_id, section, rating, reasons, comment, created_at, updated_at)
+		SELECT $1::uuid, d.user_id, d.id,
+		       (SELECT id FROM generation_history WHERE document_id = d.id ORDER BY created_at DESC LIMIT 1),
+		       $4::varchar, $5::varchar, $6::text[], NULLIF($7::text, ''), NOW(), NOW()
+		FROM documents d
+		WHERE d.id = $2 AND d.user_id = $3
+		ON CONFLICT (document_id, section) DO UPDATE
+		SET generation_id = EXCLUDED.generation_id,
+		    rating = EXCLUDED.rating,
+		    reasons = EXCLUDED.reasons,
+		    comment = EXCLUDED.comment
+		RETURNING ` + feedbackColumns
+
+	saved, err := scanFeedback(r.db.QueryRow(
+		query,
+		feedback.ID,
+		feedback.DocumentID,
+		feedback.UserID,
+		feedback.Section,
+		feedback.Rating,
+		pq.Array(feedback.Reasons),
+		feedback.Comment,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to save feedback: %w", err)
+	}
+
+	*feedback = *saved
+	return nil
+}
+
+// GetDocumentFeedback lists the ratings of one of a user's documents, the
+// whole-document rating first
+func (r *FeedbackRepository) GetDocumentFeedback(documentID, userID uuid.UUID) ([]*models.GenerationFeedback, error) {
+	query := `
+		SELECT ` + feedbackColumns + `
+		FROM generation_feedback
+		WHERE document_id = $1 AND user_id = $2
+		ORDER BY section
+	`
+
+	rows, err := r.db.Query(query, documentID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feedback: %w", err)
+	}
+	defer rows.Close()
+
+	var feedback []*models.GenerationFeedback
+	for rows.Next() {
+		f, err := scanFeedback(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan feedback: %w", err)
+		}
+		feedback = append(feedback, f)
+	}
+
+	return feedback, rows.Err()
+}
+
+// DeleteFeedback removes the rating of a document or section
+func (r *FeedbackRepository) DeleteFeedback(documentID, userID uuid.UUID, section string) error {
+	result, err := r.db.Exec(
+		`DELETE FROM generation_feedback WHERE document_id = $1 AND user_id = $2 AND section = $3`,
+		documentID, userID, section,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete feedback: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrFeedbackNotFound
+	}
+
+	return nil
+}
+
+// GetFeedbackSummaries aggregates all users' feedback by model, prompt
+// version and template, the most rated first. The scope limits it to
+// whole-document or to section ratings.
+func (r *FeedbackRepository) GetFeedbackSummaries(scope string) ([]*models.FeedbackSummary, error) {
+	var where string
+	switch scope {
+	case FeedbackScopeDocument:
+		where = "WHERE f.section = ''"
+	case FeedbackScopeSection:
+		where = "WHERE f.section <> ''"
+	}
+
+	from := `
+		FROM generation_feedback f
+		JOIN documents d ON d.id = f.document_id
+		LEFT JOIN generation_history g ON g.id = f.generation_id
+	`
+	group := "COALESCE(g.model, ''), COALESCE(g.prompt_version, ''), COALESCE(d.template_id, '')"
+
+	query := `
+		SELECT ` + group + `,
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE f.rating = 'up'),
+		       COUNT(*) FILTER (WHERE f.rating = 'down'),
+		       COUNT(f.comment)
+		` + from + where + `
+		GROUP BY 1, 2, 3
+		ORDER BY 4 DESC, 1, 2, 3
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feedback summaries: %w", err)
+	}
+	defer rows.Close()
+
+	var summaries []*models.FeedbackSummary
+	byKey := make(map[[3]string]*models.FeedbackSummary)
+	for rows.Next() {
+		s := &models.FeedbackSummary{Reasons: map[string]int{}}
+		if err := rows.Scan(&s.Model, &s.PromptVersion, &s.TemplateID, &s.Total, &s.Up, &s.Down, &s.Comments); err != nil {
+			return nil, fmt.Errorf("failed to scan feedback summary: %w", err)
+		}
+		summaries = append(summaries, s)
+		byKey[[3]string{s.Model, s.PromptVersion, s.TemplateID}] = s
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get feedback summaries: %w", err)
+	}
+
+	query = `
+		SELECT ` + group + `, reason, COUNT(*)
+		` + from + `
+		CROSS JOIN LATERAL unnest(f.reasons) AS reason
+		` + where + `
+		GROUP BY 1, 2, 3, 4
+	`
+
+	rows, err = r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feedback reasons: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key [3]string
+		var reason string
+		var count int
+		if err := rows.Scan(&key[0], &key[1], &key[2], &reason, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan feedback reason: %w", err)
+		}
+		if s, ok := byKey[key]; ok {
+			s.Reasons[reason] = count
+		}
+	}
+
+	return summaries, rows.Err()
+}
+
+// scanFeedback reads a generation_feedback row selected in feedbackColumns order
+func scanFeedback(row rowScanner) (*models.GenerationFeedback, error) {
+	feedback := &models.GenerationFeedback{}
+	var generationID uuid.NullUUID
+	var comment sql.NullString
+
+	err := row.Scan(
+		&feedback.ID,
+		&feedback.UserID,
+		&feedback.DocumentID,
+		&generationID,
+		&feedback.Section,
+		&feedback.Rating,
+		pq.Array(&feedback.Reasons),
+		&comment,
+		&feedback.CreatedAt,
+		&feedback.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if generationID.Valid {
+		feedback.GenerationID = &generationID.UUID
+	}
+	feedback.Comment = comment.String
+	if feedback.Reasons == nil {
+		feedback.Reasons = []string{}
+	}
+
+	return feedback, nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestFeedbackRepository_SaveAndSummarise(t *testing.T) {
+	db := newTestDB(t)
+	documentRepo := NewDocumentRepository(db)
+	repo := NewFeedbackRepository(db)
+	user, _ := createTestUser(t, db, "feedback@example.com")
+	other, _ := createTestUser(t, db, "other-feedback@example.com")
+
+	doc := newTestDocument(user.ID, "Rated")
+	if err := documentRepo.CreateDocument(doc); err != nil {
+		t.Fatalf("CreateDocument: %v", err)
+	}
+	generation := &models.GenerationHistory{ID: uuid.New(), UserID: user.ID, DocumentID: &doc.ID, Model: "gpt-4o-mini", PromptVersion: "resume@1"}
+	if err := documentRepo.CreateGenerationHistory(generation); err != nil {
+		t.Fatalf("CreateGenerationHistory: %v", err)
+	}
+
+	save := func(f *models.GenerationFeedback) *models.GenerationFeedback {
+		t.Helper()
+		f.ID = uuid.New()
+		f.UserID = user.ID
+		f.DocumentID = doc.ID
+		if err := repo.SaveFeedback(f); err != nil {
+			t.Fatalf("SaveFeedback: %v", err)
+		}
+		return f
+	}
+
+	first := save(&models.GenerationFeedback{Rating: models.RatingUp, Reasons: []string{"accurate"}})
+	if first.GenerationID == nil || *first.GenerationID != generation.ID {
+		t.Errorf("GenerationID = %v, want %s", first.GenerationID, generation.ID)
+	}
+
+	// Rating the document again replaces the first rating
+	second := save(&models.GenerationFeedback{Rating: models.RatingDown, Reasons: []string{"made_up", "too_long"}, Comment: "Invented a degree"})
+	if second.ID != first.ID || second.Rating != models.RatingDown || second.Comment != "Invented a degree" {
+		t.Errorf("re-rating = %+v", second)
+	}
+	save(&models.GenerationFeedback{Section: "summary", Rating: models.RatingDown, Reasons: []string{"too_long"}})
+
+	feedback, err := repo.GetDocumentFeedback(doc.ID, user.ID)
+	if err != nil {
+		t.Fatalf("GetDocumentFeedback: %v", err)
+	}
+	if len(feedback) != 2 || feedback[0].Section != "" || feedback[1].Section != "summary" {
+		t.Fatalf("feedback = %+v", feedback)
+	}
+	if !reflect.DeepEqual(feedback[0].Reasons, []string{"made_up", "too_long"}) {
+		t.Errorf("reasons = %v", feedback[0].Reasons)
+	}
+
+	// Other users can't rate the document
+	err = repo.SaveFeedback(&models.GenerationFeedback{ID: uuid.New(), UserID: other.ID, DocumentID: doc.ID, Rating: models.RatingUp})
+	if err != ErrUserNotFound {
+		t.Errorf("SaveFeedback by another user = %v, want ErrUserNotFound", err)
+	}
+
+	summaries, err := repo.GetFeedbackSummaries(FeedbackScopeAll)
+	if err != nil {
+		t.Fatalf("GetFeedbackSummaries: %v", err)
+	}
+	want := &models.FeedbackSummary{
+		Model: "gpt-4o-mini", PromptVersion: "resume@1", TemplateID: "classic",
+		Total: 2, Up: 0, Down: 2, Comments: 1,
+		Reasons: map[string]int{"made_up": 1, "too_long": 2},
+	}
+	if len(summaries) != 1 || !reflect.DeepEqual(summaries[0], want) {
+		t.Errorf("summaries = %+v, want [%+v]", summaries, want)
+	}
+
+	summaries, err = repo.GetFeedbackSummaries(FeedbackScopeSection)
+	if err != nil || len(summaries) != 1 || summaries[0].Total != 1 || summaries[0].Reasons["made_up"] != 0 {
+		t.Errorf("section summaries = %+v, %v", summaries, err)
+	}
+
+	if err := repo.DeleteFeedback(doc.ID, user.ID, "summary"); err != nil {
+		t.Fatalf("DeleteFeedback: %v", err)
+	}
+	if err := repo.DeleteFeedback(doc.ID, user.ID, "summary"); err != ErrFeedbackNotFound {
+		t.Errorf("second DeleteFeedback = %v, want ErrFeedbackNotFound", err)
+	}
+}
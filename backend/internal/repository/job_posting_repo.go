@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/google/uuid"
+)
+
+var ErrJobPostingNotFound = errors.New("job posting not found")
+
+// jobPostingColumns is the column list read by scanJobPosting
+const jobPostingColumns = "id, user_id, title, company_name, description, source_url, location, salary_min, salary_max, salary_currency, content_hash, created_at, updated_at"
+
+type JobPostingRepository struct {
+	db *sql.DB
+}
+
+func NewJobPostingRepository(db *sql.DB) *JobPostingRepository {
+	return &JobPostingRepository{db: db}
+}
+
+// CreateJobPosting saves a posting unless the user already has one with the
+// same content hash, in which case posting is filled with the saved one. It
+// reports whether a new posting was created.
+func (r *JobPostingRepository) CreateJobPosting(posting *models.JobPosting) (bool, error) {
+	query := `
+		INSERT INTO job_postings (id, user_id, title, company_name, description, source_url, location,
+		                          salary_min, salary_max, salary_currency, content_hash, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW(), NOW())
+		ON CONFLICT (user_id, content_hash) DO NOTHING
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		posting.ID,
+		posting.UserID,
+		posting.Title,
+		posting.CompanyName,
+		posting.Description,
+		posting.SourceURL,
+		posting.Location,
+		posting.SalaryMin,
+		posting.SalaryMax,
+		posting.SalaryCurrency,
+		posting.ContentHash,
+	).Scan(&posting.CreatedAt, &posting.UpdatedAt)
+	if err == nil {
+		return true, nil
+	}
+	if err != sql.ErrNoRows {
+		return false, fmt.Errorf("failed to create job posting: %w", err)
+	}
+
+	existing, err := r.GetJobPostingByHash(posting.UserID, posting.ContentHash)
+	if err != nil {
+		return false, err
+	}
+	*posting = *existing
+
+	return false, nil
+}
+
+// GetJobPostingByID retrieves one of a user's postings
+func (r *JobPostingRepository) GetJobPostingByID(id, userID uuid.UUID) (*models.JobPosting, error) {
+	query := `
+		SELECT ` + jobPostingColumns + `
+		FROM job_postings
+		WHERE id = $1 AND user_id = $2
+	`
+
+	return r.getJobPosting(query, id, userID)
+}
+
+// GetJobPostingByHash retrieves the user's posting with a content hash
+func (r *JobPostingRepository) GetJobPostingByHash(userID uuid.UUID, contentHash string) (*models.JobPosting, error) {
+	query := `
+		SELECT ` + jobPostingColumns + `
+		FROM job_postings
+		WHERE user_id = $1 AND content_hash = $2
+	`
+
+	return r.getJobPosting(query, userID, contentHash)
+}
+
+// GetJobPostings lists a user's postings, newest first
+func (r *JobPostingRepository) GetJobPostings(userID uuid.UUID) ([]*models.JobPosting, error) {
+	query := `
+		SELECT ` + jobPostingColumns + `
+		FROM job_postings
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job postings: %w", err)
+	}
+	defer rows.Close()
+
+	var postings []*models.JobPosting
+	for rows.Next() {
+		posting, err := scanJobPosting(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job posting: %w", err)
+		}
+		postings = append(postings, posting)
+	}
+
+	return postings, rows.Err()
+}
+
+// UpdateJobPosting replaces the details of one of a user's postings
+func (r *JobPostingRepository) UpdateJobPosting(posting *models.JobPosting) error {
+	query := `
+		UPDATE job_postings
+		SET title = $1, company_name = $2, description = $3, source_url = $4, location = $5,
+		    salary_min = $6, salary_max = $7, salary_currency = $8, content_hash = $9, updated_at = NOW()
+		WHERE id = $10 AND user_id = $11
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		posting.Title,
+		posting.CompanyName,
+		posting.Description,
+		posting.SourceURL,
+		posting.Location,
+		posting.SalaryMin,
+		posting.SalaryMax,
+		posting.SalaryCurrency,
+		posting.ContentHash,
+		posting.ID,
+		posting.UserID,
+	).Scan(&posting.CreatedAt, &posting.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrJobPostingNotFound
+		}
+		return fmt.Errorf("failed to update job posting: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteJobPosting deletes one of a user's postings. Documents generated for
+// it keep their own copy of the description.
+func (r *JobPostingRepository) DeleteJobPosting(id, userID uuid.UUID) error {
+	result, err := r.db.Exec(`DELETE FROM job_postings WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete job posting: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrJobPostingNotFound
+	}
+
+	return nil
+}
+
+func (r *JobPostingRepository) getJobPosting(query string, args ...interface{}) (*models.JobPosting, error) {
+	posting, err := scanJobPosting(r.db.QueryRow(query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrJobPostingNotFound
+		}
+		return nil, fmt.Errorf("failed to get job posting: %w", err)
+	}
+
+	return posting, nil
+}
+
+// scanJobPosting reads a job_postings row selected in jobPostingColumns order
+func scanJobPosting(row rowScanner) (*models.JobPosting, error) {
+	posting := &models.JobPosting{}
+	var companyName, sourceURL, location, salaryCurrency sql.NullString
+	var salaryMin, salaryMax sql.NullInt64
+
+	err := row.Scan(
+		&posting.ID,
+		&posting.UserID,
+		&posting.Title,
+		&companyName,
+		&posting.Description,
+		&sourceURL,
+		&location,
+		&salaryMin,
+		&salaryMax,
+		&salaryCurrency,
+		&posting.ContentHash,
+		&posting.CreatedAt,
+		&posting.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	posting.CompanyName = companyName.String
+	posting.SourceURL = sourceURL.String
+	posting.Location = location.String
+	posting.SalaryCurrency = salaryCurrency.String
+	if salaryMin.Valid {
+		min := int(salaryMin.Int64)
+		posting.SalaryMin = &min
+	}
+	if salaryMax.Valid {
+		max := int(salaryMax.Int64)
+		posting.SalaryMax = &max
+	}
+
+	return posting, nil
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/google/uuid"
+)
+
+func newTestJobPosting(userID uuid.UUID, hash string) *models.JobPosting {
+	salaryMin, salaryMax := 90000, 120000
+	return &models.JobPosting{
+		ID:             uuid.New(),
+		UserID:         userID,
+		Title:          "Backend Engineer",
+		CompanyName:    "Acme",
+		Description:    "We need Go and PostgreSQL",
+		SourceURL:      "https://acme.example/jobs/1",
+		Location:       "Remote",
+		SalaryMin:      &salaryMin,
+		SalaryMax:      &salaryMax,
+		SalaryCurrency: "USD",
+		ContentHash:    strings.Repeat(hash, 64),
+	}
+}
+
+func TestJobPostingRepository_CreateDeduplicates(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewJobPostingRepository(db)
+	user, _ := createTestUser(t, db, "postings@example.com")
+	other, _ := createTestUser(t, db, "postingsother@example.com")
+
+	posting := newTestJobPosting(user.ID, "a")
+	created, err := repo.CreateJobPosting(posting)
+	if err != nil || !created {
+		t.Fatalf("CreateJobPosting = %v, %v", created, err)
+	}
+
+	got, err := repo.GetJobPostingByID(posting.ID, user.ID)
+	if err != nil {
+		t.Fatalf("GetJobPostingByID: %v", err)
+	}
+	if got.Title != posting.Title || got.Location != "Remote" || got.SalaryMin == nil || *got.SalaryMin != 90000 ||
+		got.SalaryMax == nil || *got.SalaryMax != 120000 || got.SalaryCurrency != "USD" || got.ContentHash != posting.ContentHash {
+		t.Errorf("GetJobPostingByID = %+v", got)
+	}
+
+	// The same content returns the saved posting
+	again := newTestJobPosting(user.ID, "a")
+	again.Title = "Another title"
+	created, err = repo.CreateJobPosting(again)
+	if err != nil || created {
+		t.Fatalf("duplicate CreateJobPosting = %v, %v, want false, nil", created, err)
+	}
+	if again.ID != posting.ID || again.Title != "Backend Engineer" {
+		t.Errorf("duplicate CreateJobPosting filled %+v, want the saved posting", again)
+	}
+
+	// Other users save their own copy
+	foreign := newTestJobPosting(other.ID, "a")
+	if created, err := repo.CreateJobPosting(foreign); err != nil || !created {
+		t.Errorf("other user CreateJobPosting = %v, %v", created, err)
+	}
+
+	postings, err := repo.GetJobPostings(user.ID)
+	if err != nil {
+		t.Fatalf("GetJobPostings: %v", err)
+	}
+	if len(postings) != 1 {
+		t.Errorf("GetJobPostings returned %d postings, want 1", len(postings))
+	}
+	if _, err := repo.GetJobPostingByID(posting.ID, other.ID); err != ErrJobPostingNotFound {
+		t.Errorf("GetJobPostingByID other user = %v, want ErrJobPostingNotFound", err)
+	}
+}
+
+func TestJobPostingRepository_UpdateAndDelete(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewJobPostingRepository(db)
+	docRepo := NewDocumentRepository(db)
+	user, _ := createTestUser(t, db, "postingupdate@example.com")
+
+	posting := newTestJobPosting(user.ID, "b")
+	if _, err := repo.CreateJobPosting(posting); err != nil {
+		t.Fatalf("CreateJobPosting: %v", err)
+	}
+
+	posting.Description = "We need Go, PostgreSQL and Kubernetes"
+	posting.ContentHash = strings.Repeat("c", 64)
+	posting.SalaryMin, posting.SalaryMax, posting.SalaryCurrency = nil, nil, ""
+	if err := repo.UpdateJobPosting(posting); err != nil {
+		t.Fatalf("UpdateJobPosting: %v", err)
+	}
+	got, err := repo.GetJobPostingByHash(user.ID, posting.ContentHash)
+	if err != nil {
+		t.Fatalf("GetJobPostingByHash: %v", err)
+	}
+	if got.ID != posting.ID || got.Description != posting.Description || got.SalaryMin != nil || got.SalaryCurrency != "" {
+		t.Errorf("after update = %+v", got)
+	}
+
+	doc := newTestDocument(user.ID, "Acme - Backend Engineer")
+	doc.JobPostingID = &posting.ID
+	if err := docRepo.CreateDocument(doc); err != nil {
+		t.Fatalf("CreateDocument: %v", err)
+	}
+	gotDoc, _ := docRepo.GetDocumentByID(doc.ID, user.ID)
+	if gotDoc.JobPostingID == nil || *gotDoc.JobPostingID != posting.ID {
+		t.Errorf("JobPostingID = %v, want %s", gotDoc.JobPostingID, posting.ID)
+	}
+
+	if err := repo.DeleteJobPosting(posting.ID, uuid.New()); err != ErrJobPostingNotFound {
+		t.Errorf("DeleteJobPosting other user = %v, want ErrJobPostingNotFound", err)
+	}
+	if err := repo.DeleteJobPosting(posting.ID, user.ID); err != nil {
+		t.Fatalf("DeleteJobPosting: %v", err)
+	}
+
+	// Deleting the posting keeps the document and its description
+	gotDoc, err = docRepo.GetDocumentByID(doc.ID, user.ID)
+	if err != nil {
+		t.Fatalf("GetDocumentByID after posting delete: %v", err)
+	}
+	if gotDoc.JobPostingID != nil || gotDoc.JobDescription != "We need Go" {
+		t.Errorf("document after posting delete = %+v", gotDoc)
+	}
+	if err := repo.UpdateJobPosting(posting); err != ErrJobPostingNotFound {
+		t.Errorf("UpdateJobPosting after delete = %v, want ErrJobPostingNotFound", err)
+	}
+}
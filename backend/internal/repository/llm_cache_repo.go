@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// LLMCacheRepository keeps model responses in Postgres, shared by all
+// instances of the API; it implements service.ResponseCache
+type LLMCacheRepository struct {
+	db *sql.DB
+}
+
+func NewLLMCacheRepository(db *sql.DB) *LLMCacheRepository {
+	return &LLMCacheRepository{db: db}
+}
+
+// Get returns the response stored under key, if it hasn't expired
+func (r *LLMCacheRepository) Get(key string) ([]byte, bool, error) {
+	var response []byte
+	err := r.db.QueryRow(
+		`SELECT response FROM llm_cache WHERE key = $1 AND expires_at > NOW()`,
+		key,
+	).Scan(&response)
+
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get cached response: %w", err)
+	}
+
+	return response, true, nil
+}
+
+// Set stores a response under key for ttl, replacing any earlier one, and
+// deletes expired responses
+func (r *LLMCacheRepository) Set(key string, value []byte, ttl time.Duration) error {
+	query := `
+		INSERT INTO llm_cache (key, response, created_at, expires_at)
+		VALUES ($1, $2, NOW(), NOW() + $3 * INTERVAL '1 millisecond')
+		ON CONFLICT (key) DO UPDATE
+		SET response = EXCLUDED.response, created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+	`
+
+	if _, err := r.db.Exec(query, key, value, ttl.Milliseconds()); err != nil {
+		return fmt.Errorf("failed to cache response: %w", err)
+	}
+
+	if _, err := r.db.Exec(`DELETE FROM llm_cache WHERE expires_at <= NOW()`); err != nil {
+		return fmt.Errorf("failed to delete expired responses: %w", err)
+	}
+
+	return nil
+}
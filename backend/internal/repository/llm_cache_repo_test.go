@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLLMCacheRepository_GetSet(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewLLMCacheRepository(db)
+
+	if _, ok, err := repo.Get("missing"); err != nil || ok {
+		t.Fatalf("Get missing = %v, %v, want false, nil", ok, err)
+	}
+
+	if err := repo.Set("key", []byte(`{"content":"first"}`), time.Hour); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	got, ok, err := repo.Get("key")
+	if err != nil || !ok || string(got) != `{"content":"first"}` {
+		t.Fatalf("Get = %q, %v, %v", got, ok, err)
+	}
+
+	// A fresh response replaces the cached one
+	if err := repo.Set("key", []byte(`{"content":"second"}`), time.Hour); err != nil {
+		t.Fatalf("Set again: %v", err)
+	}
+	if got, _, _ := repo.Get("key"); string(got) != `{"content":"second"}` {
+		t.Errorf("Get after replace = %q", got)
+	}
+
+	// Expired responses are not returned and are deleted on the next write
+	if _, err := db.Exec(`UPDATE llm_cache SET expires_at = NOW() - INTERVAL '1 minute' WHERE key = 'key'`); err != nil {
+		t.Fatalf("expire: %v", err)
+	}
+	if _, ok, err := repo.Get("key"); err != nil || ok {
+		t.Errorf("Get expired = %v, %v, want false, nil", ok, err)
+	}
+	if err := repo.Set("other", []byte(`{}`), time.Hour); err != nil {
+		t.Fatalf("Set other: %v", err)
+	}
+	var count int
+	db.QueryRow(`SELECT COUNT(*) FROM llm_cache WHERE key = 'key'`).Scan(&count)
+	if count != 0 {
+		t.Errorf("expired rows after Set = %d, want 0", count)
+	}
+}
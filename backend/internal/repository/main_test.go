@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"database/sql"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/testutil"
+	"github.com/google/uuid"
+)
+
+var testPostgres *testutil.Postgres
+
+func TestMain(m *testing.M) {
+	pg, err := testutil.StartPostgres()
+	if err != nil {
+		log.Printf("repository integration tests will be skipped: %v", err)
+	}
+	testPostgres = pg
+
+	code := m.Run()
+
+	if pg != nil {
+		if err := pg.Close(); err != nil {
+			log.Printf("failed to stop test postgres: %v", err)
+		}
+	}
+	os.Exit(code)
+}
+
+// newTestDB returns a fresh, migrated database or skips the test
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	if testPostgres == nil {
+		t.Skip("postgres unavailable")
+	}
+	return testPostgres.NewDatabase(t)
+}
+
+// createTestUser inserts a user with an empty profile
+func createTestUser(t *testing.T, db *sql.DB, email string) (*models.User, *models.Profile) {
+	t.Helper()
+
+	userRepo := NewUserRepository(db)
+	user := &models.User{
+		ID:                  uuid.New(),
+		Email:               email,
+		PasswordHash:        "hash",
+		FullName:            "Test User",
+		FreeGenerationsLeft: 2,
+	}
+	if err := userRepo.CreateUser(user); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if err := userRepo.CreateProfile(user.ID); err != nil {
+		t.Fatalf("CreateProfile: %v", err)
+	}
+
+	profile, err := NewProfileRepository(db).GetProfileByUserID(user.ID)
+	if err != nil {
+		t.Fatalf("GetProfileByUserID: %v", err)
+	}
+
+	return user, profile
+}
+
+func date(year int, month time.Month, day int) models.Date {
+	return models.Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+func nullDate(year int, month time.Month, day int) models.NullDate {
+	nd := models.NullDate{}
+	nd.Time = time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	nd.Valid = true
+	return nd
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Format("2006-01-02") == b.Format("2006-01-02")
+}
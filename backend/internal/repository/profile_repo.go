@@ -2,6 +2,7 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/feijoa-master/ai-resume-builder/internal/models"
@@ -9,6 +10,9 @@ import (
 	"github.com/lib/pq"
 )
 
+// ErrInvalidOrder is returned when a reorder doesn't list every entry exactly once
+var ErrInvalidOrder = errors.New("order must list every entry exactly once")
+
 type ProfileRepository struct {
 	db *sql.DB
 }
@@ -17,32 +21,37 @@ func NewProfileRepository(db *sql.DB) *ProfileRepository {
 	return &ProfileRepository{db: db}
 }
 
-// GetProfileByUserID retrieves a user's profile
+// profileColumns is the column list read by scanProfile
+const profileColumns = "id, user_id, name, is_default, phone, location, linkedin_url, github_url, website_url, summary, date_of_birth, nationality, photo_url, created_at, updated_at"
+
+// GetProfileByUserID retrieves a user's default profile
 func (r *ProfileRepository) GetProfileByUserID(userID uuid.UUID) (*models.Profile, error) {
 	query := `
-		SELECT id, user_id, phone, location, linkedin_url, github_url, website_url, summary, created_at, updated_at
+		SELECT ` + profileColumns + `
 		FROM profiles
-		WHERE user_id = $1
+		WHERE user_id = $1 AND is_default
 	`
 
-	profile := &models.Profile{}
+	profile, err := scanProfile(r.db.QueryRow(query, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrProfileNotFound
+		}
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
 
-	// Use sql.NullString for nullable fields
-	var phone, location, linkedinURL, githubURL, websiteURL, summary sql.NullString
+	return profile, nil
+}
 
-	err := r.db.QueryRow(query, userID).Scan(
-		&profile.ID,
-		&profile.UserID,
-		&phone,
-		&location,
-		&linkedinURL,
-		&githubURL,
-		&websiteURL,
-		&summary,
-		&profile.CreatedAt,
-		&profile.UpdatedAt,
-	)
+// GetProfileByID retrieves one of a user's profiles
+func (r *ProfileRepository) GetProfileByID(id, userID uuid.UUID) (*models.Profile, error) {
+	query := `
+		SELECT ` + profileColumns + `
+		FROM profiles
+		WHERE id = $1 AND user_id = $2
+	`
 
+	profile, err := scanProfile(r.db.QueryRow(query, id, userID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, ErrProfileNotFound
@@ -50,24 +59,194 @@ func (r *ProfileRepository) GetProfileByUserID(userID uuid.UUID) (*models.Profil
 		return nil, fmt.Errorf("failed to get profile: %w", err)
 	}
 
-	// Convert sql.NullString to regular string
-	profile.Phone = phone.String
-	profile.Location = location.String
-	profile.LinkedInURL = linkedinURL.String
-	profile.GithubURL = githubURL.String
-	profile.WebsiteURL = websiteURL.String
-	profile.Summary = summary.String
-
 	return profile, nil
 }
 
-// UpdateProfile updates profile information
+// GetProfiles retrieves all of a user's profiles, the default one first
+func (r *ProfileRepository) GetProfiles(userID uuid.UUID) ([]*models.Profile, error) {
+	query := `
+		SELECT ` + profileColumns + `
+		FROM profiles
+		WHERE user_id = $1
+		ORDER BY is_default DESC, created_at
+	`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profiles: %w", err)
+	}
+	defer rows.Close()
+
+	var profiles []*models.Profile
+	for rows.Next() {
+		profile, err := scanProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan profile: %w", err)
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, rows.Err()
+}
+
+// CreateProfile adds an empty, non-default profile
+func (r *ProfileRepository) CreateProfile(profile *models.Profile) error {
+	query := `
+		INSERT INTO profiles (id, user_id, name, is_default, created_at, updated_at)
+		VALUES ($1, $2, $3, FALSE, NOW(), NOW())
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRow(query, profile.ID, profile.UserID, profile.Name).Scan(&profile.CreatedAt, &profile.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create profile: %w", err)
+	}
+	profile.IsDefault = false
+
+	return nil
+}
+
+// profileSectionCopies lists, for every table holding profile entries, the
+// columns CopyProfile carries over to the new profile
+var profileSectionCopies = []struct {
+	table   string
+	columns string
+}{
+	{"experiences", "company, position, start_date, end_date, is_current, description, achievements, sort_order, created_at"},
+	{"education", "institution, degree, field_of_study, start_date, end_date, gpa, sort_order, created_at"},
+	{"skills", "name, category, proficiency_level, group_name, sort_order, created_at"},
+	{"projects", "name, role, description, url, repository_url, tech_stack, start_date, end_date, created_at"},
+	{"certifications", "name, issuer, issue_date, expiry_date, credential_id, credential_url, created_at"},
+	{"publications", "title, publisher, published_date, url, authors, description, created_at"},
+	{"languages", "name, level, created_at"},
+	{"volunteering", "organization, role, start_date, end_date, is_current, description, created_at"},
+	{"awards", "title, issuer, award_date, description, created_at"},
+}
+
+// CopyProfile creates profile as a copy of the user's profile sourceID, with
+// its contact details and every entry of every section. profile.ID, UserID
+// and Name must be set; the rest is filled in from the source.
+func (r *ProfileRepository) CopyProfile(sourceID uuid.UUID, profile *models.Profile) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO profiles (id, user_id, name, is_default, phone, location, linkedin_url, github_url, website_url, summary, date_of_birth, nationality, photo_url, created_at, updated_at)
+		SELECT $1, user_id, $2, FALSE, phone, location, linkedin_url, github_url, website_url, summary, date_of_birth, nationality, photo_url, NOW(), NOW()
+		FROM profiles
+		WHERE id = $3 AND user_id = $4
+		RETURNING ` + profileColumns + `
+	`
+
+	copied, err := scanProfile(tx.QueryRow(query, profile.ID, profile.Name, sourceID, profile.UserID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrProfileNotFound
+		}
+		return fmt.Errorf("failed to copy profile: %w", err)
+	}
+
+	for _, section := range profileSectionCopies {
+		query := fmt.Sprintf(
+			"INSERT INTO %s (profile_id, %s) SELECT $1, %s FROM %s WHERE profile_id = $2",
+			section.table, section.columns, section.columns, section.table,
+		)
+		if _, err := tx.Exec(query, profile.ID, sourceID); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", section.table, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	*profile = *copied
+	return nil
+}
+
+// RenameProfile changes the name of one of a user's profiles
+func (r *ProfileRepository) RenameProfile(id, userID uuid.UUID, name string) error {
+	query := `UPDATE profiles SET name = $1, updated_at = NOW() WHERE id = $2 AND user_id = $3`
+
+	result, err := r.db.Exec(query, name, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to rename profile: %w", err)
+	}
+
+	if err := expectRowAffected(result); err != nil {
+		if err == ErrUserNotFound {
+			return ErrProfileNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// SetDefaultProfile makes one of a user's profiles the default one
+func (r *ProfileRepository) SetDefaultProfile(id, userID uuid.UUID) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM profiles WHERE id = $1 AND user_id = $2)`, id, userID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to set default profile: %w", err)
+	}
+	if !exists {
+		return ErrProfileNotFound
+	}
+
+	// Clear the old default first, the unique index allows only one per user
+	if _, err := tx.Exec(`UPDATE profiles SET is_default = FALSE WHERE user_id = $1 AND is_default AND id <> $2`, userID, id); err != nil {
+		return fmt.Errorf("failed to set default profile: %w", err)
+	}
+	if _, err := tx.Exec(`UPDATE profiles SET is_default = TRUE WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to set default profile: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteProfile deletes one of a user's profiles with all its entries. The
+// default profile is never deleted.
+func (r *ProfileRepository) DeleteProfile(id, userID uuid.UUID) error {
+	query := `DELETE FROM profiles WHERE id = $1 AND user_id = $2 AND NOT is_default`
+
+	result, err := r.db.Exec(query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete profile: %w", err)
+	}
+
+	if err := expectRowAffected(result); err != nil {
+		if err == ErrUserNotFound {
+			return ErrProfileNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// UpdateProfile updates the contact details and summary of the user's
+// profile profile.ID
 func (r *ProfileRepository) UpdateProfile(profile *models.Profile) error {
 	query := `
 		UPDATE profiles
 		SET phone = $1, location = $2, linkedin_url = $3, github_url = $4, 
-		    website_url = $5, summary = $6, updated_at = NOW()
-		WHERE user_id = $7
+		    website_url = $5, summary = $6, date_of_birth = $7, nationality = $8,
+		    photo_url = $9, updated_at = NOW()
+		WHERE id = $10 AND user_id = $11
 	`
 
 	result, err := r.db.Exec(query,
@@ -77,6 +256,10 @@ func (r *ProfileRepository) UpdateProfile(profile *models.Profile) error {
 		profile.GithubURL,
 		profile.WebsiteURL,
 		profile.Summary,
+		profile.DateOfBirth,
+		profile.Nationality,
+		profile.PhotoURL,
+		profile.ID,
 		profile.UserID,
 	)
 	if err != nil {
@@ -98,10 +281,11 @@ func (r *ProfileRepository) UpdateProfile(profile *models.Profile) error {
 // Experience methods
 
 func (r *ProfileRepository) CreateExperience(exp *models.Experience) error {
+	// New entries go to the top, where the latest role usually belongs
 	query := `
-		INSERT INTO experiences (id, profile_id, company, position, start_date, end_date, is_current, description, achievements, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
-		RETURNING id, created_at
+		INSERT INTO experiences (id, profile_id, company, position, start_date, end_date, is_current, description, achievements, sort_order, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, (SELECT COALESCE(MIN(sort_order) - 1, 0) FROM experiences WHERE profile_id = $2), NOW())
+		RETURNING id, sort_order, created_at
 	`
 
 	err := r.db.QueryRow(
@@ -115,7 +299,7 @@ func (r *ProfileRepository) CreateExperience(exp *models.Experience) error {
 		exp.IsCurrent,
 		exp.Description,
 		pq.Array(exp.Achievements),
-	).Scan(&exp.ID, &exp.CreatedAt)
+	).Scan(&exp.ID, &exp.SortOrder, &exp.CreatedAt)
 
 	if err != nil {
 		return fmt.Errorf("failed to create experience: %w", err)
@@ -126,10 +310,10 @@ func (r *ProfileRepository) CreateExperience(exp *models.Experience) error {
 
 func (r *ProfileRepository) GetExperiences(profileID uuid.UUID) ([]*models.Experience, error) {
 	query := `
-		SELECT id, profile_id, company, position, start_date, end_date, is_current, description, achievements, created_at
+		SELECT id, profile_id, company, position, start_date, end_date, is_current, description, achievements, sort_order, created_at
 		FROM experiences
 		WHERE profile_id = $1
-		ORDER BY start_date DESC
+		ORDER BY sort_order, start_date DESC
 	`
 
 	rows, err := r.db.Query(query, profileID)
@@ -141,6 +325,8 @@ func (r *ProfileRepository) GetExperiences(profileID uuid.UUID) ([]*models.Exper
 	var experiences []*models.Experience
 	for rows.Next() {
 		exp := &models.Experience{}
+		var isCurrent sql.NullBool
+		var description sql.NullString
 		err := rows.Scan(
 			&exp.ID,
 			&exp.ProfileID,
@@ -148,14 +334,17 @@ func (r *ProfileRepository) GetExperiences(profileID uuid.UUID) ([]*models.Exper
 			&exp.Position,
 			&exp.StartDate,
 			&exp.EndDate,
-			&exp.IsCurrent,
-			&exp.Description,
+			&isCurrent,
+			&description,
 			pq.Array(&exp.Achievements),
+			&exp.SortOrder,
 			&exp.CreatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan experience: %w", err)
 		}
+		exp.IsCurrent = isCurrent.Bool
+		exp.Description = description.String
 		experiences = append(experiences, exp)
 	}
 
@@ -217,13 +406,20 @@ func (r *ProfileRepository) DeleteExperience(id, profileID uuid.UUID) error {
 	return nil
 }
 
+// ReorderExperiences sets the display order of a profile's experiences.
+// ids must list every experience of the profile exactly once.
+func (r *ProfileRepository) ReorderExperiences(profileID uuid.UUID, ids []uuid.UUID) error {
+	return r.reorder("experiences", profileID, ids)
+}
+
 // Education methods
 
 func (r *ProfileRepository) CreateEducation(edu *models.Education) error {
+	// New entries go to the top, like experiences
 	query := `
-		INSERT INTO education (id, profile_id, institution, degree, field_of_study, start_date, end_date, gpa, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
-		RETURNING id, created_at
+		INSERT INTO education (id, profile_id, institution, degree, field_of_study, start_date, end_date, gpa, sort_order, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, (SELECT COALESCE(MIN(sort_order) - 1, 0) FROM education WHERE profile_id = $2), NOW())
+		RETURNING id, sort_order, created_at
 	`
 
 	err := r.db.QueryRow(
@@ -236,7 +432,7 @@ func (r *ProfileRepository) CreateEducation(edu *models.Education) error {
 		edu.StartDate,
 		edu.EndDate,
 		edu.GPA,
-	).Scan(&edu.ID, &edu.CreatedAt)
+	).Scan(&edu.ID, &edu.SortOrder, &edu.CreatedAt)
 
 	if err != nil {
 		return fmt.Errorf("failed to create education: %w", err)
@@ -247,10 +443,10 @@ func (r *ProfileRepository) CreateEducation(edu *models.Education) error {
 
 func (r *ProfileRepository) GetEducation(profileID uuid.UUID) ([]*models.Education, error) {
 	query := `
-		SELECT id, profile_id, institution, degree, field_of_study, start_date, end_date, gpa, created_at
+		SELECT id, profile_id, institution, degree, field_of_study, start_date, end_date, gpa, sort_order, created_at
 		FROM education
 		WHERE profile_id = $1
-		ORDER BY start_date DESC
+		ORDER BY sort_order, start_date DESC
 	`
 
 	rows, err := r.db.Query(query, profileID)
@@ -262,20 +458,25 @@ func (r *ProfileRepository) GetEducation(profileID uuid.UUID) ([]*models.Educati
 	var educations []*models.Education
 	for rows.Next() {
 		edu := &models.Education{}
+		var fieldOfStudy sql.NullString
+		var gpa sql.NullFloat64
 		err := rows.Scan(
 			&edu.ID,
 			&edu.ProfileID,
 			&edu.Institution,
 			&edu.Degree,
-			&edu.FieldOfStudy,
+			&fieldOfStudy,
 			&edu.StartDate,
 			&edu.EndDate,
-			&edu.GPA,
+			&gpa,
+			&edu.SortOrder,
 			&edu.CreatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan education: %w", err)
 		}
+		edu.FieldOfStudy = fieldOfStudy.String
+		edu.GPA = gpa.Float64
 		educations = append(educations, edu)
 	}
 
@@ -336,13 +537,68 @@ func (r *ProfileRepository) DeleteEducation(id, profileID uuid.UUID) error {
 	return nil
 }
 
+// ReorderEducation sets the display order of a profile's education.
+// ids must list every education entry of the profile exactly once.
+func (r *ProfileRepository) ReorderEducation(profileID uuid.UUID, ids []uuid.UUID) error {
+	return r.reorder("education", profileID, ids)
+}
+
+// reorder sets sort_order of table's rows to their position in ids, or
+// returns ErrInvalidOrder when ids aren't exactly the profile's rows
+func (r *ProfileRepository) reorder(table string, profileID uuid.UUID, ids []uuid.UUID) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var count int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM `+table+` WHERE profile_id = $1`, profileID).Scan(&count); err != nil {
+		return fmt.Errorf("failed to count %s: %w", table, err)
+	}
+	if count != len(ids) {
+		return ErrInvalidOrder
+	}
+
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+	query := `
+		UPDATE ` + table + `
+		SET sort_order = ordered.position - 1
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS ordered(id, position)
+		WHERE ` + table + `.id = ordered.id AND ` + table + `.profile_id = $1
+	`
+	result, err := tx.Exec(query, profileID, pq.Array(values))
+	if err != nil {
+		return fmt.Errorf("failed to reorder %s: %w", table, err)
+	}
+
+	// Rows are matched once each, so a repeated or foreign ID leaves some out
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected != int64(count) {
+		return ErrInvalidOrder
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
 // Skills methods
 
 func (r *ProfileRepository) CreateSkill(skill *models.Skill) error {
+	// New skills go to the end of the list
 	query := `
-		INSERT INTO skills (id, profile_id, name, category, proficiency_level, created_at)
-		VALUES ($1, $2, $3, $4, $5, NOW())
-		RETURNING id, created_at
+		INSERT INTO skills (id, profile_id, name, category, proficiency_level, group_name, sort_order, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, (SELECT COALESCE(MAX(sort_order) + 1, 0) FROM skills WHERE profile_id = $2), NOW())
+		RETURNING id, sort_order, created_at
 	`
 
 	err := r.db.QueryRow(
@@ -352,7 +608,8 @@ func (r *ProfileRepository) CreateSkill(skill *models.Skill) error {
 		skill.Name,
 		skill.Category,
 		skill.ProficiencyLevel,
-	).Scan(&skill.ID, &skill.CreatedAt)
+		skill.Group,
+	).Scan(&skill.ID, &skill.SortOrder, &skill.CreatedAt)
 
 	if err != nil {
 		return fmt.Errorf("failed to create skill: %w", err)
@@ -363,10 +620,10 @@ func (r *ProfileRepository) CreateSkill(skill *models.Skill) error {
 
 func (r *ProfileRepository) GetSkills(profileID uuid.UUID) ([]*models.Skill, error) {
 	query := `
-		SELECT id, profile_id, name, category, proficiency_level, created_at
+		SELECT ` + skillColumns + `
 		FROM skills
 		WHERE profile_id = $1
-		ORDER BY category, name
+		ORDER BY sort_order, created_at
 	`
 
 	rows, err := r.db.Query(query, profileID)
@@ -375,24 +632,193 @@ func (r *ProfileRepository) GetSkills(profileID uuid.UUID) ([]*models.Skill, err
 	}
 	defer rows.Close()
 
+	return scanSkills(rows)
+}
+
+// GetAllSkills retrieves the skills of every profile, grouped by profile.
+// It is meant for maintenance tools, not request handlers.
+func (r *ProfileRepository) GetAllSkills() ([]*models.Skill, error) {
+	query := `
+		SELECT ` + skillColumns + `
+		FROM skills
+		ORDER BY profile_id, created_at
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSkills(rows)
+}
+
+const skillColumns = "id, profile_id, name, category, proficiency_level, group_name, sort_order, created_at"
+
+func scanSkills(rows *sql.Rows) ([]*models.Skill, error) {
 	var skills []*models.Skill
 	for rows.Next() {
 		skill := &models.Skill{}
+		var category, proficiencyLevel, group sql.NullString
 		err := rows.Scan(
 			&skill.ID,
 			&skill.ProfileID,
 			&skill.Name,
-			&skill.Category,
-			&skill.ProficiencyLevel,
+			&category,
+			&proficiencyLevel,
+			&group,
+			&skill.SortOrder,
 			&skill.CreatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan skill: %w", err)
 		}
+		skill.Category = category.String
+		skill.ProficiencyLevel = proficiencyLevel.String
+		skill.Group = group.String
 		skills = append(skills, skill)
 	}
 
-	return skills, nil
+	return skills, rows.Err()
+}
+
+// MergeSkills updates the kept skill and deletes its duplicates of the same
+// profile in one transaction
+func (r *ProfileRepository) MergeSkills(keep *models.Skill, duplicateIDs []uuid.UUID) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE skills
+		SET name = $1, category = $2, proficiency_level = $3
+		WHERE id = $4 AND profile_id = $5
+	`
+
+	result, err := tx.Exec(query, keep.Name, keep.Category, keep.ProficiencyLevel, keep.ID, keep.ProfileID)
+	if err != nil {
+		return fmt.Errorf("failed to update skill: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	if len(duplicateIDs) > 0 {
+		ids := make([]string, len(duplicateIDs))
+		for i, id := range duplicateIDs {
+			ids[i] = id.String()
+		}
+		query = `DELETE FROM skills WHERE profile_id = $1 AND id = ANY($2::uuid[])`
+		if _, err := tx.Exec(query, keep.ProfileID, pq.Array(ids)); err != nil {
+			return fmt.Errorf("failed to delete duplicate skills: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateSkill updates a skill's name, category, proficiency level and group.
+// Its position only changes through ReplaceSkills.
+func (r *ProfileRepository) UpdateSkill(skill *models.Skill) error {
+	query := `
+		UPDATE skills
+		SET name = $1, category = $2, proficiency_level = $3, group_name = $4
+		WHERE id = $5 AND profile_id = $6
+		RETURNING sort_order, created_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		skill.Name,
+		skill.Category,
+		skill.ProficiencyLevel,
+		skill.Group,
+		skill.ID,
+		skill.ProfileID,
+	).Scan(&skill.SortOrder, &skill.CreatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to update skill: %w", err)
+	}
+
+	return nil
+}
+
+// ReplaceSkills makes skills the complete skills list of a profile, in slice
+// order. Skills of the profile missing from the list are deleted; listed ones
+// are updated when their ID exists and created otherwise. Callers must make
+// sure listed IDs don't belong to another profile.
+func (r *ProfileRepository) ReplaceSkills(profileID uuid.UUID, skills []*models.Skill) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ids := make([]string, len(skills))
+	for i, skill := range skills {
+		ids[i] = skill.ID.String()
+	}
+
+	query := `DELETE FROM skills WHERE profile_id = $1 AND NOT (id = ANY($2::uuid[]))`
+	if _, err := tx.Exec(query, profileID, pq.Array(ids)); err != nil {
+		return fmt.Errorf("failed to delete skills: %w", err)
+	}
+
+	query = `
+		INSERT INTO skills (id, profile_id, name, category, proficiency_level, group_name, sort_order, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		ON CONFLICT (id) DO UPDATE
+		SET name = EXCLUDED.name,
+		    category = EXCLUDED.category,
+		    proficiency_level = EXCLUDED.proficiency_level,
+		    group_name = EXCLUDED.group_name,
+		    sort_order = EXCLUDED.sort_order
+		WHERE skills.profile_id = EXCLUDED.profile_id
+		RETURNING created_at
+	`
+	for i, skill := range skills {
+		skill.ProfileID = profileID
+		skill.SortOrder = i
+		err := tx.QueryRow(
+			query,
+			skill.ID,
+			profileID,
+			skill.Name,
+			skill.Category,
+			skill.ProficiencyLevel,
+			skill.Group,
+			skill.SortOrder,
+		).Scan(&skill.CreatedAt)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				// The ID belongs to another profile
+				return ErrUserNotFound
+			}
+			return fmt.Errorf("failed to save skill: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
 }
 
 func (r *ProfileRepository) DeleteSkill(id, profileID uuid.UUID) error {
@@ -414,3 +840,44 @@ func (r *ProfileRepository) DeleteSkill(id, profileID uuid.UUID) error {
 
 	return nil
 }
+
+// scanProfile reads a profiles row selected in the standard column order
+func scanProfile(row rowScanner) (*models.Profile, error) {
+	profile := &models.Profile{}
+
+	// Use sql.NullString for nullable fields
+	var phone, location, linkedinURL, githubURL, websiteURL, summary, nationality, photoURL sql.NullString
+
+	err := row.Scan(
+		&profile.ID,
+		&profile.UserID,
+		&profile.Name,
+		&profile.IsDefault,
+		&phone,
+		&location,
+		&linkedinURL,
+		&githubURL,
+		&websiteURL,
+		&summary,
+		&profile.DateOfBirth,
+		&nationality,
+		&photoURL,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert sql.NullString to regular string
+	profile.Phone = phone.String
+	profile.Location = location.String
+	profile.LinkedInURL = linkedinURL.String
+	profile.GithubURL = githubURL.String
+	profile.WebsiteURL = websiteURL.String
+	profile.Summary = summary.String
+	profile.Nationality = nationality.String
+	profile.PhotoURL = photoURL.String
+
+	return profile, nil
+}
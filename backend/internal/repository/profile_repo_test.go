@@ -0,0 +1,680 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestProfileRepository_GetProfileNullFields(t *testing.T) {
+	db := newTestDB(t)
+	user, profile := createTestUser(t, db, "nulls@example.com")
+
+	// CreateProfile leaves every contact column NULL
+	if profile.UserID != user.ID {
+		t.Fatalf("profile.UserID = %s, want %s", profile.UserID, user.ID)
+	}
+	if profile.Phone != "" || profile.Location != "" || profile.LinkedInURL != "" ||
+		profile.GithubURL != "" || profile.WebsiteURL != "" || profile.Summary != "" {
+		t.Errorf("NULL columns not mapped to empty strings: %+v", profile)
+	}
+}
+
+func TestProfileRepository_GetProfileNotFound(t *testing.T) {
+	db := newTestDB(t)
+
+	if _, err := NewProfileRepository(db).GetProfileByUserID(uuid.New()); err != ErrProfileNotFound {
+		t.Fatalf("GetProfileByUserID = %v, want ErrProfileNotFound", err)
+	}
+}
+
+func TestProfileRepository_UpdateProfile(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	user, profile := createTestUser(t, db, "update@example.com")
+
+	update := &models.Profile{
+		ID:          profile.ID,
+		UserID:      user.ID,
+		Phone:       "+1 555 0100",
+		Location:    "Berlin",
+		LinkedInURL: "https://linkedin.com/in/test",
+		GithubURL:   "https://github.com/test",
+		WebsiteURL:  "https://example.com",
+		Summary:     "Backend engineer",
+		DateOfBirth: nullDate(1990, time.May, 14),
+		Nationality: "German",
+		PhotoURL:    "https://example.com/photo.jpg",
+	}
+	if err := repo.UpdateProfile(update); err != nil {
+		t.Fatalf("UpdateProfile: %v", err)
+	}
+
+	got, err := repo.GetProfileByUserID(user.ID)
+	if err != nil {
+		t.Fatalf("GetProfileByUserID: %v", err)
+	}
+	if got.Phone != update.Phone || got.Location != update.Location || got.LinkedInURL != update.LinkedInURL ||
+		got.GithubURL != update.GithubURL || got.WebsiteURL != update.WebsiteURL || got.Summary != update.Summary {
+		t.Errorf("GetProfileByUserID after update = %+v", got)
+	}
+	if !sameDay(got.DateOfBirth.Time, update.DateOfBirth.Time) || got.Nationality != "German" || got.PhotoURL != update.PhotoURL {
+		t.Errorf("personal details after update = %v, %q, %q", got.DateOfBirth, got.Nationality, got.PhotoURL)
+	}
+
+	if err := repo.UpdateProfile(&models.Profile{ID: profile.ID, UserID: uuid.New()}); err != ErrUserNotFound {
+		t.Errorf("UpdateProfile other user = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestProfileRepository_ExperienceCRUD(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	_, profile := createTestUser(t, db, "exp@example.com")
+
+	older := &models.Experience{
+		ID:           uuid.New(),
+		ProfileID:    profile.ID,
+		Company:      "Acme",
+		Position:     "Engineer",
+		StartDate:    date(2018, time.January, 1),
+		EndDate:      nullDate(2020, time.June, 30),
+		Description:  "Built things",
+		Achievements: []string{"Shipped v1", "Cut latency 40%"},
+	}
+	current := &models.Experience{
+		ID:        uuid.New(),
+		ProfileID: profile.ID,
+		Company:   "Globex",
+		Position:  "Senior Engineer",
+		StartDate: date(2020, time.July, 1),
+		IsCurrent: true,
+	}
+	for _, exp := range []*models.Experience{older, current} {
+		if err := repo.CreateExperience(exp); err != nil {
+			t.Fatalf("CreateExperience: %v", err)
+		}
+		if exp.CreatedAt.IsZero() {
+			t.Fatal("CreateExperience did not populate created_at")
+		}
+	}
+
+	experiences, err := repo.GetExperiences(profile.ID)
+	if err != nil {
+		t.Fatalf("GetExperiences: %v", err)
+	}
+	if len(experiences) != 2 {
+		t.Fatalf("GetExperiences returned %d rows, want 2", len(experiences))
+	}
+	// New entries go to the top
+	if experiences[0].ID != current.ID || experiences[1].ID != older.ID {
+		t.Errorf("GetExperiences order = %s, %s", experiences[0].Company, experiences[1].Company)
+	}
+	if experiences[0].EndDate.Valid || !experiences[0].IsCurrent {
+		t.Errorf("current experience = %+v", experiences[0])
+	}
+	got := experiences[1]
+	if !sameDay(got.StartDate.Time, older.StartDate.Time) || !got.EndDate.Valid || !sameDay(got.EndDate.Time, older.EndDate.Time) {
+		t.Errorf("dates = %v - %v", got.StartDate, got.EndDate)
+	}
+	if len(got.Achievements) != 2 || got.Achievements[1] != "Cut latency 40%" {
+		t.Errorf("achievements = %v", got.Achievements)
+	}
+
+	older.Position = "Lead Engineer"
+	older.Achievements = []string{"Led team of 5"}
+	if err := repo.UpdateExperience(older); err != nil {
+		t.Fatalf("UpdateExperience: %v", err)
+	}
+	experiences, _ = repo.GetExperiences(profile.ID)
+	if experiences[1].Position != "Lead Engineer" || len(experiences[1].Achievements) != 1 {
+		t.Errorf("after update = %+v", experiences[1])
+	}
+
+	if err := repo.DeleteExperience(older.ID, profile.ID); err != nil {
+		t.Fatalf("DeleteExperience: %v", err)
+	}
+	experiences, _ = repo.GetExperiences(profile.ID)
+	if len(experiences) != 1 {
+		t.Errorf("GetExperiences after delete returned %d rows, want 1", len(experiences))
+	}
+}
+
+func TestProfileRepository_ExperienceNullColumns(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	_, profile := createTestUser(t, db, "expnull@example.com")
+
+	_, err := db.Exec(`
+		INSERT INTO experiences (profile_id, company, position, start_date, end_date, is_current, description, achievements)
+		VALUES ($1, 'Initech', 'Analyst', '2015-03-01', NULL, NULL, NULL, NULL)
+	`, profile.ID)
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	experiences, err := repo.GetExperiences(profile.ID)
+	if err != nil {
+		t.Fatalf("GetExperiences: %v", err)
+	}
+	if len(experiences) != 1 {
+		t.Fatalf("GetExperiences returned %d rows, want 1", len(experiences))
+	}
+	exp := experiences[0]
+	if exp.EndDate.Valid || exp.IsCurrent || exp.Description != "" || exp.Achievements != nil {
+		t.Errorf("NULL columns not mapped to zero values: %+v", exp)
+	}
+}
+
+func TestProfileRepository_ExperienceWrongProfile(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	_, owner := createTestUser(t, db, "owner@example.com")
+	_, other := createTestUser(t, db, "other@example.com")
+
+	exp := &models.Experience{ID: uuid.New(), ProfileID: owner.ID, Company: "Acme", Position: "Dev", StartDate: date(2020, time.January, 1)}
+	if err := repo.CreateExperience(exp); err != nil {
+		t.Fatalf("CreateExperience: %v", err)
+	}
+
+	exp.ProfileID = other.ID
+	if err := repo.UpdateExperience(exp); err != ErrUserNotFound {
+		t.Errorf("UpdateExperience other profile = %v, want ErrUserNotFound", err)
+	}
+	if err := repo.DeleteExperience(exp.ID, other.ID); err != ErrUserNotFound {
+		t.Errorf("DeleteExperience other profile = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestProfileRepository_ReorderExperiences(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	_, profile := createTestUser(t, db, "reorderexp@example.com")
+
+	var ids []uuid.UUID
+	for i, company := range []string{"Acme", "Globex", "Initech"} {
+		exp := &models.Experience{ID: uuid.New(), ProfileID: profile.ID, Company: company, Position: "Engineer", StartDate: date(2015+i, time.January, 1)}
+		if err := repo.CreateExperience(exp); err != nil {
+			t.Fatalf("CreateExperience: %v", err)
+		}
+		ids = append(ids, exp.ID)
+	}
+
+	// Pin the oldest role first
+	order := []uuid.UUID{ids[0], ids[2], ids[1]}
+	if err := repo.ReorderExperiences(profile.ID, order); err != nil {
+		t.Fatalf("ReorderExperiences: %v", err)
+	}
+	experiences, err := repo.GetExperiences(profile.ID)
+	if err != nil {
+		t.Fatalf("GetExperiences: %v", err)
+	}
+	for i, exp := range experiences {
+		if exp.ID != order[i] || exp.SortOrder != i {
+			t.Fatalf("GetExperiences[%d] = %s (sort %d), want %s", i, exp.Company, exp.SortOrder, order[i])
+		}
+	}
+
+	// Incomplete, repeated and foreign IDs are rejected
+	for name, bad := range map[string][]uuid.UUID{
+		"missing":  {ids[0], ids[1]},
+		"repeated": {ids[0], ids[0], ids[1]},
+		"foreign":  {ids[0], ids[1], uuid.New()},
+	} {
+		if err := repo.ReorderExperiences(profile.ID, bad); err != ErrInvalidOrder {
+			t.Errorf("ReorderExperiences %s = %v, want ErrInvalidOrder", name, err)
+		}
+	}
+	experiences, _ = repo.GetExperiences(profile.ID)
+	if experiences[0].ID != order[0] || experiences[1].ID != order[1] {
+		t.Error("rejected reorder changed the order")
+	}
+}
+
+func TestProfileRepository_EducationCRUD(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	_, profile := createTestUser(t, db, "edu@example.com")
+
+	edu := &models.Education{
+		ID:           uuid.New(),
+		ProfileID:    profile.ID,
+		Institution:  "MIT",
+		Degree:       "BSc",
+		FieldOfStudy: "Computer Science",
+		StartDate:    date(2010, time.September, 1),
+		EndDate:      nullDate(2014, time.June, 1),
+		GPA:          3.75,
+	}
+	if err := repo.CreateEducation(edu); err != nil {
+		t.Fatalf("CreateEducation: %v", err)
+	}
+
+	education, err := repo.GetEducation(profile.ID)
+	if err != nil {
+		t.Fatalf("GetEducation: %v", err)
+	}
+	if len(education) != 1 {
+		t.Fatalf("GetEducation returned %d rows, want 1", len(education))
+	}
+	got := education[0]
+	if got.Institution != "MIT" || got.FieldOfStudy != "Computer Science" || got.GPA != 3.75 || !got.EndDate.Valid {
+		t.Errorf("GetEducation = %+v", got)
+	}
+
+	edu.Degree = "MSc"
+	edu.EndDate = models.NullDate{}
+	if err := repo.UpdateEducation(edu); err != nil {
+		t.Fatalf("UpdateEducation: %v", err)
+	}
+	education, _ = repo.GetEducation(profile.ID)
+	if education[0].Degree != "MSc" || education[0].EndDate.Valid {
+		t.Errorf("after update = %+v", education[0])
+	}
+
+	if err := repo.DeleteEducation(edu.ID, profile.ID); err != nil {
+		t.Fatalf("DeleteEducation: %v", err)
+	}
+	if err := repo.DeleteEducation(edu.ID, profile.ID); err != ErrUserNotFound {
+		t.Errorf("second DeleteEducation = %v, want ErrUserNotFound", err)
+	}
+	if err := repo.UpdateEducation(edu); err != ErrUserNotFound {
+		t.Errorf("UpdateEducation deleted row = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestProfileRepository_ReorderEducation(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	_, profile := createTestUser(t, db, "reorderedu@example.com")
+
+	bachelor := &models.Education{ID: uuid.New(), ProfileID: profile.ID, Institution: "MIT", Degree: "BSc", StartDate: date(2010, time.September, 1)}
+	master := &models.Education{ID: uuid.New(), ProfileID: profile.ID, Institution: "ETH", Degree: "MSc", StartDate: date(2014, time.September, 1)}
+	for _, edu := range []*models.Education{bachelor, master} {
+		if err := repo.CreateEducation(edu); err != nil {
+			t.Fatalf("CreateEducation: %v", err)
+		}
+	}
+
+	if err := repo.ReorderEducation(profile.ID, []uuid.UUID{bachelor.ID, master.ID}); err != nil {
+		t.Fatalf("ReorderEducation: %v", err)
+	}
+	education, err := repo.GetEducation(profile.ID)
+	if err != nil {
+		t.Fatalf("GetEducation: %v", err)
+	}
+	if len(education) != 2 || education[0].ID != bachelor.ID || education[1].ID != master.ID {
+		t.Errorf("GetEducation after reorder = %+v", education)
+	}
+
+	if err := repo.ReorderEducation(profile.ID, []uuid.UUID{bachelor.ID}); err != ErrInvalidOrder {
+		t.Errorf("ReorderEducation missing = %v, want ErrInvalidOrder", err)
+	}
+}
+
+func TestProfileRepository_EducationNullColumns(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	_, profile := createTestUser(t, db, "edunull@example.com")
+
+	_, err := db.Exec(`
+		INSERT INTO education (profile_id, institution, degree, field_of_study, start_date, end_date, gpa)
+		VALUES ($1, 'Night School', 'Certificate', NULL, '2012-01-01', NULL, NULL)
+	`, profile.ID)
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	education, err := repo.GetEducation(profile.ID)
+	if err != nil {
+		t.Fatalf("GetEducation: %v", err)
+	}
+	if len(education) != 1 {
+		t.Fatalf("GetEducation returned %d rows, want 1", len(education))
+	}
+	if education[0].FieldOfStudy != "" || education[0].GPA != 0 || education[0].EndDate.Valid {
+		t.Errorf("NULL columns not mapped to zero values: %+v", education[0])
+	}
+}
+
+func TestProfileRepository_SkillsCRUD(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	_, profile := createTestUser(t, db, "skills@example.com")
+
+	skills := []*models.Skill{
+		{ID: uuid.New(), ProfileID: profile.ID, Name: "Go", Category: "technical", ProficiencyLevel: "expert"},
+		{ID: uuid.New(), ProfileID: profile.ID, Name: "Communication", Category: "soft"},
+		{ID: uuid.New(), ProfileID: profile.ID, Name: "Docker", Category: "technical", ProficiencyLevel: "advanced"},
+	}
+	for _, skill := range skills {
+		if err := repo.CreateSkill(skill); err != nil {
+			t.Fatalf("CreateSkill: %v", err)
+		}
+	}
+
+	got, err := repo.GetSkills(profile.ID)
+	if err != nil {
+		t.Fatalf("GetSkills: %v", err)
+	}
+	var names []string
+	for _, skill := range got {
+		names = append(names, skill.Name)
+	}
+	// New skills are appended in display order
+	want := []string{"Go", "Communication", "Docker"}
+	if len(names) != len(want) {
+		t.Fatalf("GetSkills = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] || got[i].SortOrder != i {
+			t.Fatalf("GetSkills = %v, want %v in sort order", names, want)
+		}
+	}
+
+	if err := repo.DeleteSkill(skills[0].ID, profile.ID); err != nil {
+		t.Fatalf("DeleteSkill: %v", err)
+	}
+	if err := repo.DeleteSkill(skills[0].ID, profile.ID); err != ErrUserNotFound {
+		t.Errorf("second DeleteSkill = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestProfileRepository_SkillNullColumns(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	_, profile := createTestUser(t, db, "skillnull@example.com")
+
+	_, err := db.Exec(`INSERT INTO skills (profile_id, name, category, proficiency_level) VALUES ($1, 'Rust', NULL, NULL)`, profile.ID)
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	skills, err := repo.GetSkills(profile.ID)
+	if err != nil {
+		t.Fatalf("GetSkills: %v", err)
+	}
+	if len(skills) != 1 || skills[0].Category != "" || skills[0].ProficiencyLevel != "" {
+		t.Errorf("GetSkills = %+v", skills)
+	}
+}
+
+func TestProfileRepository_GetAllSkills(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	_, first := createTestUser(t, db, "allskills1@example.com")
+	_, second := createTestUser(t, db, "allskills2@example.com")
+
+	for _, skill := range []*models.Skill{
+		{ID: uuid.New(), ProfileID: first.ID, Name: "Go", Category: "technical"},
+		{ID: uuid.New(), ProfileID: second.ID, Name: "SQL", Category: "technical"},
+		{ID: uuid.New(), ProfileID: first.ID, Name: "Golang", Category: "technical"},
+	} {
+		if err := repo.CreateSkill(skill); err != nil {
+			t.Fatalf("CreateSkill: %v", err)
+		}
+	}
+
+	skills, err := repo.GetAllSkills()
+	if err != nil {
+		t.Fatalf("GetAllSkills: %v", err)
+	}
+	if len(skills) != 3 {
+		t.Fatalf("GetAllSkills returned %d skills, want 3", len(skills))
+	}
+	// Grouped by profile
+	if skills[0].ProfileID == skills[2].ProfileID && skills[1].ProfileID != skills[0].ProfileID {
+		t.Errorf("GetAllSkills not grouped by profile: %+v", skills)
+	}
+}
+
+func TestProfileRepository_MergeSkills(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	_, profile := createTestUser(t, db, "mergeskills@example.com")
+	_, other := createTestUser(t, db, "mergeother@example.com")
+
+	keep := &models.Skill{ID: uuid.New(), ProfileID: profile.ID, Name: "javascript", ProficiencyLevel: "advanced"}
+	duplicate := &models.Skill{ID: uuid.New(), ProfileID: profile.ID, Name: "JS", Category: "technical"}
+	foreign := &models.Skill{ID: uuid.New(), ProfileID: other.ID, Name: "JS", Category: "technical"}
+	for _, skill := range []*models.Skill{keep, duplicate, foreign} {
+		if err := repo.CreateSkill(skill); err != nil {
+			t.Fatalf("CreateSkill: %v", err)
+		}
+	}
+
+	keep.Name = "JavaScript"
+	keep.Category = "technical"
+	// The foreign ID belongs to another profile and must survive
+	if err := repo.MergeSkills(keep, []uuid.UUID{duplicate.ID, foreign.ID}); err != nil {
+		t.Fatalf("MergeSkills: %v", err)
+	}
+
+	skills, err := repo.GetSkills(profile.ID)
+	if err != nil {
+		t.Fatalf("GetSkills: %v", err)
+	}
+	if len(skills) != 1 || skills[0].ID != keep.ID || skills[0].Name != "JavaScript" ||
+		skills[0].Category != "technical" || skills[0].ProficiencyLevel != "advanced" {
+		t.Errorf("GetSkills after merge = %+v", skills)
+	}
+
+	skills, err = repo.GetSkills(other.ID)
+	if err != nil {
+		t.Fatalf("GetSkills other: %v", err)
+	}
+	if len(skills) != 1 {
+		t.Errorf("other profile lost its skill: %+v", skills)
+	}
+
+	if err := repo.MergeSkills(&models.Skill{ID: keep.ID, ProfileID: other.ID, Name: "X"}, nil); err != ErrUserNotFound {
+		t.Errorf("MergeSkills wrong profile = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestProfileRepository_UpdateSkill(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	_, profile := createTestUser(t, db, "updateskill@example.com")
+
+	skill := &models.Skill{ID: uuid.New(), ProfileID: profile.ID, Name: "Go", Category: "technical"}
+	if err := repo.CreateSkill(skill); err != nil {
+		t.Fatalf("CreateSkill: %v", err)
+	}
+
+	update := &models.Skill{ID: skill.ID, ProfileID: profile.ID, Name: "Go", Category: "technical", ProficiencyLevel: "expert", Group: "Backend"}
+	if err := repo.UpdateSkill(update); err != nil {
+		t.Fatalf("UpdateSkill: %v", err)
+	}
+
+	skills, err := repo.GetSkills(profile.ID)
+	if err != nil {
+		t.Fatalf("GetSkills: %v", err)
+	}
+	if len(skills) != 1 || skills[0].ProficiencyLevel != "expert" || skills[0].Group != "Backend" {
+		t.Errorf("GetSkills after update = %+v", skills)
+	}
+
+	update.ProfileID = uuid.New()
+	if err := repo.UpdateSkill(update); err != ErrUserNotFound {
+		t.Errorf("UpdateSkill wrong profile = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestProfileRepository_ReplaceSkills(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	_, profile := createTestUser(t, db, "replaceskills@example.com")
+	_, other := createTestUser(t, db, "replaceother@example.com")
+
+	goSkill := &models.Skill{ID: uuid.New(), ProfileID: profile.ID, Name: "Go", Category: "technical"}
+	sqlSkill := &models.Skill{ID: uuid.New(), ProfileID: profile.ID, Name: "SQL", Category: "technical"}
+	foreign := &models.Skill{ID: uuid.New(), ProfileID: other.ID, Name: "Rust", Category: "technical"}
+	for _, skill := range []*models.Skill{goSkill, sqlSkill, foreign} {
+		if err := repo.CreateSkill(skill); err != nil {
+			t.Fatalf("CreateSkill: %v", err)
+		}
+	}
+
+	// Reorder, drop SQL, add Docker
+	replacement := []*models.Skill{
+		{ID: uuid.New(), Name: "Docker", Category: "technical", Group: "DevOps"},
+		{ID: goSkill.ID, Name: "Go", Category: "technical", ProficiencyLevel: "advanced"},
+	}
+	if err := repo.ReplaceSkills(profile.ID, replacement); err != nil {
+		t.Fatalf("ReplaceSkills: %v", err)
+	}
+
+	skills, err := repo.GetSkills(profile.ID)
+	if err != nil {
+		t.Fatalf("GetSkills: %v", err)
+	}
+	if len(skills) != 2 || skills[0].Name != "Docker" || skills[0].Group != "DevOps" ||
+		skills[1].ID != goSkill.ID || skills[1].ProficiencyLevel != "advanced" || skills[1].SortOrder != 1 {
+		t.Errorf("GetSkills after replace = %+v", skills)
+	}
+
+	// Another profile's skill can't be taken over
+	err = repo.ReplaceSkills(profile.ID, []*models.Skill{{ID: foreign.ID, Name: "Rust", Category: "technical"}})
+	if err != ErrUserNotFound {
+		t.Errorf("ReplaceSkills foreign ID = %v, want ErrUserNotFound", err)
+	}
+	skills, err = repo.GetSkills(other.ID)
+	if err != nil {
+		t.Fatalf("GetSkills other: %v", err)
+	}
+	if len(skills) != 1 || skills[0].ProfileID != other.ID {
+		t.Errorf("other profile's skills = %+v", skills)
+	}
+}
+
+func TestProfileRepository_NamedProfiles(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	user, defaultProfile := createTestUser(t, db, "named@example.com")
+	_, stranger := createTestUser(t, db, "stranger@example.com")
+
+	manager := &models.Profile{ID: uuid.New(), UserID: user.ID, Name: "Engineering manager"}
+	if err := repo.CreateProfile(manager); err != nil {
+		t.Fatalf("CreateProfile: %v", err)
+	}
+
+	profiles, err := repo.GetProfiles(user.ID)
+	if err != nil {
+		t.Fatalf("GetProfiles: %v", err)
+	}
+	if len(profiles) != 2 || profiles[0].ID != defaultProfile.ID || profiles[1].ID != manager.ID || profiles[1].IsDefault {
+		t.Fatalf("GetProfiles = %+v", profiles)
+	}
+
+	if err := repo.RenameProfile(manager.ID, user.ID, "Manager"); err != nil {
+		t.Fatalf("RenameProfile: %v", err)
+	}
+	got, err := repo.GetProfileByID(manager.ID, user.ID)
+	if err != nil {
+		t.Fatalf("GetProfileByID: %v", err)
+	}
+	if got.Name != "Manager" {
+		t.Errorf("name after rename = %q", got.Name)
+	}
+	if _, err := repo.GetProfileByID(manager.ID, stranger.UserID); err != ErrProfileNotFound {
+		t.Errorf("GetProfileByID other user = %v, want ErrProfileNotFound", err)
+	}
+
+	if err := repo.SetDefaultProfile(manager.ID, user.ID); err != nil {
+		t.Fatalf("SetDefaultProfile: %v", err)
+	}
+	got, err = repo.GetProfileByUserID(user.ID)
+	if err != nil {
+		t.Fatalf("GetProfileByUserID: %v", err)
+	}
+	if got.ID != manager.ID || !got.IsDefault {
+		t.Errorf("default after SetDefaultProfile = %+v", got)
+	}
+	if err := repo.SetDefaultProfile(manager.ID, stranger.UserID); err != ErrProfileNotFound {
+		t.Errorf("SetDefaultProfile other user = %v, want ErrProfileNotFound", err)
+	}
+
+	// UpdateProfile only touches the given profile, default or not
+	if err := repo.UpdateProfile(&models.Profile{ID: defaultProfile.ID, UserID: user.ID, Summary: "Leads teams"}); err != nil {
+		t.Fatalf("UpdateProfile: %v", err)
+	}
+	if got, _ = repo.GetProfileByID(defaultProfile.ID, user.ID); got.Summary != "Leads teams" {
+		t.Errorf("UpdateProfile didn't change a non-default profile: %+v", got)
+	}
+	if got, _ = repo.GetProfileByID(manager.ID, user.ID); got.Summary != "" {
+		t.Errorf("UpdateProfile changed another profile: %+v", got)
+	}
+
+	if err := repo.DeleteProfile(manager.ID, user.ID); err != ErrProfileNotFound {
+		t.Errorf("DeleteProfile default = %v, want ErrProfileNotFound", err)
+	}
+	if err := repo.DeleteProfile(defaultProfile.ID, user.ID); err != nil {
+		t.Fatalf("DeleteProfile: %v", err)
+	}
+	if profiles, _ = repo.GetProfiles(user.ID); len(profiles) != 1 {
+		t.Errorf("GetProfiles after delete returned %d rows, want 1", len(profiles))
+	}
+}
+
+func TestProfileRepository_CopyProfile(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	user, source := createTestUser(t, db, "copy@example.com")
+	_, stranger := createTestUser(t, db, "copystranger@example.com")
+
+	source.Summary = "Backend engineer"
+	if err := repo.UpdateProfile(source); err != nil {
+		t.Fatalf("UpdateProfile: %v", err)
+	}
+	for i, company := range []string{"Acme", "Globex"} {
+		exp := &models.Experience{ID: uuid.New(), ProfileID: source.ID, Company: company, Position: "Engineer", StartDate: date(2018+i, time.January, 1)}
+		if err := repo.CreateExperience(exp); err != nil {
+			t.Fatalf("CreateExperience: %v", err)
+		}
+	}
+	skill := &models.Skill{ID: uuid.New(), ProfileID: source.ID, Name: "Go", Category: "technical", Group: "Languages"}
+	if err := repo.CreateSkill(skill); err != nil {
+		t.Fatalf("CreateSkill: %v", err)
+	}
+	lang := &models.Language{ID: uuid.New(), ProfileID: source.ID, Name: "German", Level: "B2"}
+	if err := repo.CreateLanguage(lang); err != nil {
+		t.Fatalf("CreateLanguage: %v", err)
+	}
+
+	copied := &models.Profile{ID: uuid.New(), UserID: user.ID, Name: "Backend"}
+	if err := repo.CopyProfile(source.ID, copied); err != nil {
+		t.Fatalf("CopyProfile: %v", err)
+	}
+	if copied.Summary != "Backend engineer" || copied.IsDefault || copied.Name != "Backend" {
+		t.Errorf("copied profile = %+v", copied)
+	}
+
+	experiences, _ := repo.GetExperiences(copied.ID)
+	if len(experiences) != 2 || experiences[0].Company != "Globex" || experiences[1].Company != "Acme" {
+		t.Errorf("copied experiences = %+v", experiences)
+	}
+	skills, _ := repo.GetSkills(copied.ID)
+	if len(skills) != 1 || skills[0].ID == skill.ID || skills[0].Group != "Languages" {
+		t.Errorf("copied skills = %+v", skills)
+	}
+	langs, _ := repo.GetLanguages(copied.ID)
+	if len(langs) != 1 || langs[0].Level != "B2" {
+		t.Errorf("copied languages = %+v", langs)
+	}
+
+	// The copy is independent of its source
+	if err := repo.DeleteExperience(experiences[0].ID, copied.ID); err != nil {
+		t.Fatalf("DeleteExperience: %v", err)
+	}
+	if experiences, _ = repo.GetExperiences(source.ID); len(experiences) != 2 {
+		t.Errorf("source experiences after deleting from copy = %d, want 2", len(experiences))
+	}
+
+	other := &models.Profile{ID: uuid.New(), UserID: stranger.UserID, Name: "Stolen"}
+	if err := repo.CopyProfile(source.ID, other); err != ErrProfileNotFound {
+		t.Errorf("CopyProfile other user = %v, want ErrProfileNotFound", err)
+	}
+}
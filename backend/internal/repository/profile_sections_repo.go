@@ -0,0 +1,586 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Project methods
+
+func (r *ProfileRepository) CreateProject(project *models.Project) error {
+	query := `
+		INSERT INTO projects (id, profile_id, name, role, description, url, repository_url, tech_stack, start_date, end_date, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW())
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		project.ID,
+		project.ProfileID,
+		project.Name,
+		project.Role,
+		project.Description,
+		project.URL,
+		project.RepositoryURL,
+		pq.Array(project.TechStack),
+		project.StartDate,
+		project.EndDate,
+	).Scan(&project.ID, &project.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProfileRepository) GetProjects(profileID uuid.UUID) ([]*models.Project, error) {
+	query := `
+		SELECT id, profile_id, name, role, description, url, repository_url, tech_stack, start_date, end_date, created_at
+		FROM projects
+		WHERE profile_id = $1
+		ORDER BY start_date DESC NULLS LAST, created_at DESC
+	`
+
+	rows, err := r.db.Query(query, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+	defer rows.Close()
+
+	var projects []*models.Project
+	for rows.Next() {
+		project := &models.Project{}
+		var role, description, url, repositoryURL sql.NullString
+		err := rows.Scan(
+			&project.ID,
+			&project.ProfileID,
+			&project.Name,
+			&role,
+			&description,
+			&url,
+			&repositoryURL,
+			pq.Array(&project.TechStack),
+			&project.StartDate,
+			&project.EndDate,
+			&project.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project: %w", err)
+		}
+		project.Role = role.String
+		project.Description = description.String
+		project.URL = url.String
+		project.RepositoryURL = repositoryURL.String
+		projects = append(projects, project)
+	}
+
+	return projects, rows.Err()
+}
+
+func (r *ProfileRepository) UpdateProject(project *models.Project) error {
+	query := `
+		UPDATE projects
+		SET name = $1, role = $2, description = $3, url = $4, repository_url = $5,
+		    tech_stack = $6, start_date = $7, end_date = $8
+		WHERE id = $9 AND profile_id = $10
+	`
+
+	result, err := r.db.Exec(query,
+		project.Name,
+		project.Role,
+		project.Description,
+		project.URL,
+		project.RepositoryURL,
+		pq.Array(project.TechStack),
+		project.StartDate,
+		project.EndDate,
+		project.ID,
+		project.ProfileID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	return expectRowAffected(result)
+}
+
+func (r *ProfileRepository) DeleteProject(id, profileID uuid.UUID) error {
+	return r.deleteProfileRow("projects", id, profileID)
+}
+
+// Certification methods
+
+func (r *ProfileRepository) CreateCertification(cert *models.Certification) error {
+	query := `
+		INSERT INTO certifications (id, profile_id, name, issuer, issue_date, expiry_date, credential_id, credential_url, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		cert.ID,
+		cert.ProfileID,
+		cert.Name,
+		cert.Issuer,
+		cert.IssueDate,
+		cert.ExpiryDate,
+		cert.CredentialID,
+		cert.CredentialURL,
+	).Scan(&cert.ID, &cert.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create certification: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProfileRepository) GetCertifications(profileID uuid.UUID) ([]*models.Certification, error) {
+	query := `
+		SELECT id, profile_id, name, issuer, issue_date, expiry_date, credential_id, credential_url, created_at
+		FROM certifications
+		WHERE profile_id = $1
+		ORDER BY issue_date DESC NULLS LAST, created_at DESC
+	`
+
+	rows, err := r.db.Query(query, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get certifications: %w", err)
+	}
+	defer rows.Close()
+
+	var certs []*models.Certification
+	for rows.Next() {
+		cert := &models.Certification{}
+		var credentialID, credentialURL sql.NullString
+		err := rows.Scan(
+			&cert.ID,
+			&cert.ProfileID,
+			&cert.Name,
+			&cert.Issuer,
+			&cert.IssueDate,
+			&cert.ExpiryDate,
+			&credentialID,
+			&credentialURL,
+			&cert.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan certification: %w", err)
+		}
+		cert.CredentialID = credentialID.String
+		cert.CredentialURL = credentialURL.String
+		certs = append(certs, cert)
+	}
+
+	return certs, rows.Err()
+}
+
+func (r *ProfileRepository) UpdateCertification(cert *models.Certification) error {
+	query := `
+		UPDATE certifications
+		SET name = $1, issuer = $2, issue_date = $3, expiry_date = $4,
+		    credential_id = $5, credential_url = $6
+		WHERE id = $7 AND profile_id = $8
+	`
+
+	result, err := r.db.Exec(query,
+		cert.Name,
+		cert.Issuer,
+		cert.IssueDate,
+		cert.ExpiryDate,
+		cert.CredentialID,
+		cert.CredentialURL,
+		cert.ID,
+		cert.ProfileID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update certification: %w", err)
+	}
+
+	return expectRowAffected(result)
+}
+
+func (r *ProfileRepository) DeleteCertification(id, profileID uuid.UUID) error {
+	return r.deleteProfileRow("certifications", id, profileID)
+}
+
+// Publication methods
+
+func (r *ProfileRepository) CreatePublication(pub *models.Publication) error {
+	query := `
+		INSERT INTO publications (id, profile_id, title, publisher, published_date, url, authors, description, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		pub.ID,
+		pub.ProfileID,
+		pub.Title,
+		pub.Publisher,
+		pub.PublishedDate,
+		pub.URL,
+		pq.Array(pub.Authors),
+		pub.Description,
+	).Scan(&pub.ID, &pub.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create publication: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProfileRepository) GetPublications(profileID uuid.UUID) ([]*models.Publication, error) {
+	query := `
+		SELECT id, profile_id, title, publisher, published_date, url, authors, description, created_at
+		FROM publications
+		WHERE profile_id = $1
+		ORDER BY published_date DESC NULLS LAST, created_at DESC
+	`
+
+	rows, err := r.db.Query(query, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get publications: %w", err)
+	}
+	defer rows.Close()
+
+	var pubs []*models.Publication
+	for rows.Next() {
+		pub := &models.Publication{}
+		var publisher, url, description sql.NullString
+		err := rows.Scan(
+			&pub.ID,
+			&pub.ProfileID,
+			&pub.Title,
+			&publisher,
+			&pub.PublishedDate,
+			&url,
+			pq.Array(&pub.Authors),
+			&description,
+			&pub.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan publication: %w", err)
+		}
+		pub.Publisher = publisher.String
+		pub.URL = url.String
+		pub.Description = description.String
+		pubs = append(pubs, pub)
+	}
+
+	return pubs, rows.Err()
+}
+
+func (r *ProfileRepository) UpdatePublication(pub *models.Publication) error {
+	query := `
+		UPDATE publications
+		SET title = $1, publisher = $2, published_date = $3, url = $4,
+		    authors = $5, description = $6
+		WHERE id = $7 AND profile_id = $8
+	`
+
+	result, err := r.db.Exec(query,
+		pub.Title,
+		pub.Publisher,
+		pub.PublishedDate,
+		pub.URL,
+		pq.Array(pub.Authors),
+		pub.Description,
+		pub.ID,
+		pub.ProfileID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update publication: %w", err)
+	}
+
+	return expectRowAffected(result)
+}
+
+func (r *ProfileRepository) DeletePublication(id, profileID uuid.UUID) error {
+	return r.deleteProfileRow("publications", id, profileID)
+}
+
+// Language methods
+
+func (r *ProfileRepository) CreateLanguage(lang *models.Language) error {
+	query := `
+		INSERT INTO languages (id, profile_id, name, level, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(query, lang.ID, lang.ProfileID, lang.Name, lang.Level).Scan(&lang.ID, &lang.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create language: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProfileRepository) GetLanguages(profileID uuid.UUID) ([]*models.Language, error) {
+	// Native first, then from the most to the least fluent
+	query := `
+		SELECT id, profile_id, name, level, created_at
+		FROM languages
+		WHERE profile_id = $1
+		ORDER BY CASE WHEN level = 'native' THEN 0 ELSE 1 END, level DESC, name
+	`
+
+	rows, err := r.db.Query(query, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get languages: %w", err)
+	}
+	defer rows.Close()
+
+	var langs []*models.Language
+	for rows.Next() {
+		lang := &models.Language{}
+		if err := rows.Scan(&lang.ID, &lang.ProfileID, &lang.Name, &lang.Level, &lang.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan language: %w", err)
+		}
+		langs = append(langs, lang)
+	}
+
+	return langs, rows.Err()
+}
+
+func (r *ProfileRepository) UpdateLanguage(lang *models.Language) error {
+	query := `
+		UPDATE languages
+		SET name = $1, level = $2
+		WHERE id = $3 AND profile_id = $4
+	`
+
+	result, err := r.db.Exec(query, lang.Name, lang.Level, lang.ID, lang.ProfileID)
+	if err != nil {
+		return fmt.Errorf("failed to update language: %w", err)
+	}
+
+	return expectRowAffected(result)
+}
+
+func (r *ProfileRepository) DeleteLanguage(id, profileID uuid.UUID) error {
+	return r.deleteProfileRow("languages", id, profileID)
+}
+
+// Volunteering methods
+
+func (r *ProfileRepository) CreateVolunteering(vol *models.Volunteering) error {
+	query := `
+		INSERT INTO volunteering (id, profile_id, organization, role, start_date, end_date, is_current, description, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		vol.ID,
+		vol.ProfileID,
+		vol.Organization,
+		vol.Role,
+		vol.StartDate,
+		vol.EndDate,
+		vol.IsCurrent,
+		vol.Description,
+	).Scan(&vol.ID, &vol.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create volunteering: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProfileRepository) GetVolunteering(profileID uuid.UUID) ([]*models.Volunteering, error) {
+	query := `
+		SELECT id, profile_id, organization, role, start_date, end_date, is_current, description, created_at
+		FROM volunteering
+		WHERE profile_id = $1
+		ORDER BY start_date DESC
+	`
+
+	rows, err := r.db.Query(query, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get volunteering: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*models.Volunteering
+	for rows.Next() {
+		vol := &models.Volunteering{}
+		var isCurrent sql.NullBool
+		var description sql.NullString
+		err := rows.Scan(
+			&vol.ID,
+			&vol.ProfileID,
+			&vol.Organization,
+			&vol.Role,
+			&vol.StartDate,
+			&vol.EndDate,
+			&isCurrent,
+			&description,
+			&vol.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan volunteering: %w", err)
+		}
+		vol.IsCurrent = isCurrent.Bool
+		vol.Description = description.String
+		entries = append(entries, vol)
+	}
+
+	return entries, rows.Err()
+}
+
+func (r *ProfileRepository) UpdateVolunteering(vol *models.Volunteering) error {
+	query := `
+		UPDATE volunteering
+		SET organization = $1, role = $2, start_date = $3, end_date = $4,
+		    is_current = $5, description = $6
+		WHERE id = $7 AND profile_id = $8
+	`
+
+	result, err := r.db.Exec(query,
+		vol.Organization,
+		vol.Role,
+		vol.StartDate,
+		vol.EndDate,
+		vol.IsCurrent,
+		vol.Description,
+		vol.ID,
+		vol.ProfileID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update volunteering: %w", err)
+	}
+
+	return expectRowAffected(result)
+}
+
+func (r *ProfileRepository) DeleteVolunteering(id, profileID uuid.UUID) error {
+	return r.deleteProfileRow("volunteering", id, profileID)
+}
+
+// Award methods
+
+func (r *ProfileRepository) CreateAward(award *models.Award) error {
+	query := `
+		INSERT INTO awards (id, profile_id, title, issuer, award_date, description, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		award.ID,
+		award.ProfileID,
+		award.Title,
+		award.Issuer,
+		award.Date,
+		award.Description,
+	).Scan(&award.ID, &award.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create award: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProfileRepository) GetAwards(profileID uuid.UUID) ([]*models.Award, error) {
+	query := `
+		SELECT id, profile_id, title, issuer, award_date, description, created_at
+		FROM awards
+		WHERE profile_id = $1
+		ORDER BY award_date DESC NULLS LAST, created_at DESC
+	`
+
+	rows, err := r.db.Query(query, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get awards: %w", err)
+	}
+	defer rows.Close()
+
+	var awards []*models.Award
+	for rows.Next() {
+		award := &models.Award{}
+		var issuer, description sql.NullString
+		err := rows.Scan(
+			&award.ID,
+			&award.ProfileID,
+			&award.Title,
+			&issuer,
+			&award.Date,
+			&description,
+			&award.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan award: %w", err)
+		}
+		award.Issuer = issuer.String
+		award.Description = description.String
+		awards = append(awards, award)
+	}
+
+	return awards, rows.Err()
+}
+
+func (r *ProfileRepository) UpdateAward(award *models.Award) error {
+	query := `
+		UPDATE awards
+		SET title = $1, issuer = $2, award_date = $3, description = $4
+		WHERE id = $5 AND profile_id = $6
+	`
+
+	result, err := r.db.Exec(query,
+		award.Title,
+		award.Issuer,
+		award.Date,
+		award.Description,
+		award.ID,
+		award.ProfileID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update award: %w", err)
+	}
+
+	return expectRowAffected(result)
+}
+
+func (r *ProfileRepository) DeleteAward(id, profileID uuid.UUID) error {
+	return r.deleteProfileRow("awards", id, profileID)
+}
+
+// deleteProfileRow deletes a row of one of the profile section tables
+func (r *ProfileRepository) deleteProfileRow(table string, id, profileID uuid.UUID) error {
+	result, err := r.db.Exec(`DELETE FROM `+table+` WHERE id = $1 AND profile_id = $2`, id, profileID)
+	if err != nil {
+		return fmt.Errorf("failed to delete from %s: %w", table, err)
+	}
+
+	return expectRowAffected(result)
+}
+
+// expectRowAffected returns ErrUserNotFound when an update or delete matched
+// no row, i.e. the row doesn't exist or belongs to another profile
+func expectRowAffected(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
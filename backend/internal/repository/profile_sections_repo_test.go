@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestProfileRepository_ProjectCRUD(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	_, profile := createTestUser(t, db, "projects@example.com")
+
+	older := &models.Project{
+		ID:            uuid.New(),
+		ProfileID:     profile.ID,
+		Name:          "Resume builder",
+		Role:          "Author",
+		RepositoryURL: "https://github.com/test/resume",
+		TechStack:     []string{"Go", "PostgreSQL"},
+		StartDate:     nullDate(2021, time.March, 1),
+		EndDate:       nullDate(2022, time.May, 1),
+	}
+	undated := &models.Project{ID: uuid.New(), ProfileID: profile.ID, Name: "Dotfiles"}
+	newer := &models.Project{ID: uuid.New(), ProfileID: profile.ID, Name: "CLI", StartDate: nullDate(2023, time.January, 1)}
+	for _, project := range []*models.Project{older, undated, newer} {
+		if err := repo.CreateProject(project); err != nil {
+			t.Fatalf("CreateProject: %v", err)
+		}
+		if project.CreatedAt.IsZero() {
+			t.Fatal("CreateProject did not populate created_at")
+		}
+	}
+
+	projects, err := repo.GetProjects(profile.ID)
+	if err != nil {
+		t.Fatalf("GetProjects: %v", err)
+	}
+	if len(projects) != 3 {
+		t.Fatalf("GetProjects returned %d rows, want 3", len(projects))
+	}
+	// Most recent first, undated projects last
+	if projects[0].ID != newer.ID || projects[1].ID != older.ID || projects[2].ID != undated.ID {
+		t.Errorf("GetProjects order = %s, %s, %s", projects[0].Name, projects[1].Name, projects[2].Name)
+	}
+	got := projects[1]
+	if got.Role != "Author" || got.RepositoryURL != older.RepositoryURL || len(got.TechStack) != 2 ||
+		!sameDay(got.EndDate.Time, older.EndDate.Time) {
+		t.Errorf("project = %+v", got)
+	}
+	if projects[2].StartDate.Valid || projects[2].URL != "" || projects[2].TechStack != nil {
+		t.Errorf("undated project = %+v", projects[2])
+	}
+
+	older.Name = "Resume builder v2"
+	older.TechStack = []string{"Go"}
+	if err := repo.UpdateProject(older); err != nil {
+		t.Fatalf("UpdateProject: %v", err)
+	}
+	projects, _ = repo.GetProjects(profile.ID)
+	if projects[1].Name != "Resume builder v2" || len(projects[1].TechStack) != 1 {
+		t.Errorf("after update = %+v", projects[1])
+	}
+
+	if err := repo.DeleteProject(older.ID, profile.ID); err != nil {
+		t.Fatalf("DeleteProject: %v", err)
+	}
+	projects, _ = repo.GetProjects(profile.ID)
+	if len(projects) != 2 {
+		t.Errorf("GetProjects after delete returned %d rows, want 2", len(projects))
+	}
+}
+
+func TestProfileRepository_CertificationCRUD(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	_, profile := createTestUser(t, db, "certs@example.com")
+
+	cert := &models.Certification{
+		ID:           uuid.New(),
+		ProfileID:    profile.ID,
+		Name:         "CKA",
+		Issuer:       "CNCF",
+		IssueDate:    nullDate(2022, time.June, 1),
+		CredentialID: "ABC-123",
+	}
+	if err := repo.CreateCertification(cert); err != nil {
+		t.Fatalf("CreateCertification: %v", err)
+	}
+
+	certs, err := repo.GetCertifications(profile.ID)
+	if err != nil {
+		t.Fatalf("GetCertifications: %v", err)
+	}
+	if len(certs) != 1 || certs[0].CredentialID != "ABC-123" || certs[0].ExpiryDate.Valid || certs[0].CredentialURL != "" {
+		t.Fatalf("GetCertifications = %+v", certs)
+	}
+
+	cert.ExpiryDate = nullDate(2025, time.June, 1)
+	if err := repo.UpdateCertification(cert); err != nil {
+		t.Fatalf("UpdateCertification: %v", err)
+	}
+	certs, _ = repo.GetCertifications(profile.ID)
+	if !certs[0].ExpiryDate.Valid || !sameDay(certs[0].ExpiryDate.Time, cert.ExpiryDate.Time) {
+		t.Errorf("expiry after update = %v", certs[0].ExpiryDate)
+	}
+
+	if err := repo.DeleteCertification(cert.ID, profile.ID); err != nil {
+		t.Fatalf("DeleteCertification: %v", err)
+	}
+	if certs, _ = repo.GetCertifications(profile.ID); len(certs) != 0 {
+		t.Errorf("GetCertifications after delete returned %d rows, want 0", len(certs))
+	}
+}
+
+func TestProfileRepository_PublicationCRUD(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	_, profile := createTestUser(t, db, "pubs@example.com")
+
+	pub := &models.Publication{
+		ID:            uuid.New(),
+		ProfileID:     profile.ID,
+		Title:         "Scaling Postgres",
+		Publisher:     "ACM Queue",
+		PublishedDate: nullDate(2020, time.September, 15),
+		Authors:       []string{"A. Author", "B. Author"},
+	}
+	if err := repo.CreatePublication(pub); err != nil {
+		t.Fatalf("CreatePublication: %v", err)
+	}
+
+	pubs, err := repo.GetPublications(profile.ID)
+	if err != nil {
+		t.Fatalf("GetPublications: %v", err)
+	}
+	if len(pubs) != 1 || pubs[0].Publisher != "ACM Queue" || len(pubs[0].Authors) != 2 || pubs[0].URL != "" {
+		t.Fatalf("GetPublications = %+v", pubs)
+	}
+
+	pub.URL = "https://queue.acm.org/scaling"
+	if err := repo.UpdatePublication(pub); err != nil {
+		t.Fatalf("UpdatePublication: %v", err)
+	}
+	pubs, _ = repo.GetPublications(profile.ID)
+	if pubs[0].URL != pub.URL {
+		t.Errorf("url after update = %q", pubs[0].URL)
+	}
+
+	if err := repo.DeletePublication(pub.ID, profile.ID); err != nil {
+		t.Fatalf("DeletePublication: %v", err)
+	}
+}
+
+func TestProfileRepository_LanguageCRUD(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	_, profile := createTestUser(t, db, "langs@example.com")
+
+	lang := &models.Language{ID: uuid.New(), ProfileID: profile.ID, Name: "German", Level: "B2"}
+	if err := repo.CreateLanguage(lang); err != nil {
+		t.Fatalf("CreateLanguage: %v", err)
+	}
+
+	lang.Level = "C1"
+	if err := repo.UpdateLanguage(lang); err != nil {
+		t.Fatalf("UpdateLanguage: %v", err)
+	}
+	langs, err := repo.GetLanguages(profile.ID)
+	if err != nil {
+		t.Fatalf("GetLanguages: %v", err)
+	}
+	if len(langs) != 1 || langs[0].Name != "German" || langs[0].Level != "C1" {
+		t.Fatalf("GetLanguages = %+v", langs)
+	}
+
+	if err := repo.DeleteLanguage(lang.ID, profile.ID); err != nil {
+		t.Fatalf("DeleteLanguage: %v", err)
+	}
+}
+
+func TestProfileRepository_VolunteeringCRUD(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	_, profile := createTestUser(t, db, "volunteer@example.com")
+
+	vol := &models.Volunteering{
+		ID:           uuid.New(),
+		ProfileID:    profile.ID,
+		Organization: "Code Club",
+		Role:         "Mentor",
+		StartDate:    date(2019, time.February, 1),
+		IsCurrent:    true,
+	}
+	if err := repo.CreateVolunteering(vol); err != nil {
+		t.Fatalf("CreateVolunteering: %v", err)
+	}
+
+	vols, err := repo.GetVolunteering(profile.ID)
+	if err != nil {
+		t.Fatalf("GetVolunteering: %v", err)
+	}
+	if len(vols) != 1 || !vols[0].IsCurrent || vols[0].EndDate.Valid || vols[0].Description != "" ||
+		!sameDay(vols[0].StartDate.Time, vol.StartDate.Time) {
+		t.Fatalf("GetVolunteering = %+v", vols)
+	}
+
+	vol.IsCurrent = false
+	vol.EndDate = nullDate(2021, time.December, 31)
+	if err := repo.UpdateVolunteering(vol); err != nil {
+		t.Fatalf("UpdateVolunteering: %v", err)
+	}
+	vols, _ = repo.GetVolunteering(profile.ID)
+	if vols[0].IsCurrent || !vols[0].EndDate.Valid {
+		t.Errorf("after update = %+v", vols[0])
+	}
+
+	if err := repo.DeleteVolunteering(vol.ID, profile.ID); err != nil {
+		t.Fatalf("DeleteVolunteering: %v", err)
+	}
+}
+
+func TestProfileRepository_AwardCRUD(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	_, profile := createTestUser(t, db, "awards@example.com")
+
+	award := &models.Award{ID: uuid.New(), ProfileID: profile.ID, Title: "Hackathon winner"}
+	if err := repo.CreateAward(award); err != nil {
+		t.Fatalf("CreateAward: %v", err)
+	}
+
+	awards, err := repo.GetAwards(profile.ID)
+	if err != nil {
+		t.Fatalf("GetAwards: %v", err)
+	}
+	if len(awards) != 1 || awards[0].Issuer != "" || awards[0].Date.Valid {
+		t.Fatalf("GetAwards = %+v", awards)
+	}
+
+	award.Issuer = "Acme"
+	award.Date = nullDate(2022, time.October, 2)
+	if err := repo.UpdateAward(award); err != nil {
+		t.Fatalf("UpdateAward: %v", err)
+	}
+	awards, _ = repo.GetAwards(profile.ID)
+	if awards[0].Issuer != "Acme" || !sameDay(awards[0].Date.Time, award.Date.Time) {
+		t.Errorf("after update = %+v", awards[0])
+	}
+
+	if err := repo.DeleteAward(award.ID, profile.ID); err != nil {
+		t.Fatalf("DeleteAward: %v", err)
+	}
+}
+
+func TestProfileRepository_SectionsWrongProfile(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProfileRepository(db)
+	_, owner := createTestUser(t, db, "sectionowner@example.com")
+	_, other := createTestUser(t, db, "sectionother@example.com")
+
+	project := &models.Project{ID: uuid.New(), ProfileID: owner.ID, Name: "Secret"}
+	if err := repo.CreateProject(project); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	lang := &models.Language{ID: uuid.New(), ProfileID: owner.ID, Name: "French", Level: "A2"}
+	if err := repo.CreateLanguage(lang); err != nil {
+		t.Fatalf("CreateLanguage: %v", err)
+	}
+
+	project.ProfileID = other.ID
+	if err := repo.UpdateProject(project); err != ErrUserNotFound {
+		t.Errorf("UpdateProject other profile = %v, want ErrUserNotFound", err)
+	}
+	if err := repo.DeleteProject(project.ID, other.ID); err != ErrUserNotFound {
+		t.Errorf("DeleteProject other profile = %v, want ErrUserNotFound", err)
+	}
+	lang.ProfileID = other.ID
+	if err := repo.UpdateLanguage(lang); err != ErrUserNotFound {
+		t.Errorf("UpdateLanguage other profile = %v, want ErrUserNotFound", err)
+	}
+	if err := repo.DeleteLanguage(lang.ID, other.ID); err != ErrUserNotFound {
+		t.Errorf("DeleteLanguage other profile = %v, want ErrUserNotFound", err)
+	}
+}
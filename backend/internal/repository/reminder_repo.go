@@ -0,0 +1,323 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+var ErrReminderNotFound = errors.New("reminder not found")
+
+// reminderColumns is the column list read by queryReminders
+const reminderColumns = "id, user_id, application_id, status, status_since, message, due_at, delivered_at, dismissed_at, created_at, delivery_attempts"
+
+type ReminderRepository struct {
+	db *sql.DB
+}
+
+func NewReminderRepository(db *sql.DB) *ReminderRepository {
+	return &ReminderRepository{db: db}
+}
+
+// GetFollowUpRules lists the follow-up rules set on one of a user's
+// applications
+func (r *ReminderRepository) GetFollowUpRules(applicationID, userID uuid.UUID) ([]models.FollowUpRule, error) {
+	query := `
+		SELECT f.status, f.after_days
+		FROM follow_up_rules f
+		JOIN applications a ON a.id = f.application_id
+		WHERE f.application_id = $1 AND a.user_id = $2
+		ORDER BY f.status
+	`
+
+	rows, err := r.db.Query(query, applicationID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follow-up rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []models.FollowUpRule{}
+	for rows.Next() {
+		var rule models.FollowUpRule
+		if err := rows.Scan(&rule.Status, &rule.AfterDays); err != nil {
+			return nil, fmt.Errorf("failed to scan follow-up rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// ReplaceFollowUpRules replaces all follow-up rules of one of a user's
+// applications
+func (r *ReminderRepository) ReplaceFollowUpRules(applicationID, userID uuid.UUID, rules []models.FollowUpRule) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM applications WHERE id = $1 AND user_id = $2)`, applicationID, userID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to replace follow-up rules: %w", err)
+	}
+	if !exists {
+		return ErrApplicationNotFound
+	}
+
+	if _, err := tx.Exec(`DELETE FROM follow_up_rules WHERE application_id = $1`, applicationID); err != nil {
+		return fmt.Errorf("failed to replace follow-up rules: %w", err)
+	}
+	for _, rule := range rules {
+		_, err := tx.Exec(
+			`INSERT INTO follow_up_rules (application_id, status, after_days, created_at) VALUES ($1, $2, $3, NOW())`,
+			applicationID, rule.Status, rule.AfterDays,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to replace follow-up rules: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetFollowUpCandidates lists the applications that have no reminder yet for
+// their current stay in a status, and are either in one of statuses or have a
+// follow-up rule for their status
+func (r *ReminderRepository) GetFollowUpCandidates(statuses []string) ([]*models.FollowUpCandidate, error) {
+	query := `
+		SELECT a.id, a.user_id, a.company_name, a.job_title, a.status, a.status_changed_at, f.after_days
+		FROM applications a
+		LEFT JOIN follow_up_rules f ON f.application_id = a.id AND f.status = a.status
+		WHERE (a.status = ANY($1) OR f.after_days > 0)
+		  AND NOT EXISTS (
+		      SELECT 1 FROM reminders rm
+		      WHERE rm.application_id = a.id AND rm.status_since = a.status_changed_at
+		  )
+		ORDER BY a.status_changed_at
+	`
+
+	rows, err := r.db.Query(query, pq.Array(statuses))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follow-up candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []*models.FollowUpCandidate
+	for rows.Next() {
+		candidate := &models.FollowUpCandidate{}
+		var afterDays sql.NullInt64
+		err := rows.Scan(
+			&candidate.ApplicationID,
+			&candidate.UserID,
+			&candidate.CompanyName,
+			&candidate.JobTitle,
+			&candidate.Status,
+			&candidate.StatusChangedAt,
+			&afterDays,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan follow-up candidate: %w", err)
+		}
+		if afterDays.Valid {
+			days := int(afterDays.Int64)
+			candidate.AfterDays = &days
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, rows.Err()
+}
+
+// CreateReminder saves a reminder unless the application already has one for
+// the same stay in a status. It reports whether the reminder was created.
+func (r *ReminderRepository) CreateReminder(reminder *models.Reminder) (bool, error) {
+	query := `
+		INSERT INTO reminders (id, user_id, application_id, status, status_since, message, due_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		ON CONFLICT (application_id, status_since) DO NOTHING
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		reminder.ID,
+		reminder.UserID,
+		reminder.ApplicationID,
+		reminder.Status,
+		reminder.StatusSince,
+		reminder.Message,
+		reminder.DueAt,
+	).Scan(&reminder.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to create reminder: %w", err)
+	}
+
+	return true, nil
+}
+
+// GetUndeliveredReminders lists up to limit reminders to deliver at now,
+// oldest first: not delivered or dismissed yet, with fewer than maxAttempts
+// failed deliveries and no retry scheduled after now
+func (r *ReminderRepository) GetUndeliveredReminders(now time.Time, maxAttempts, limit int) ([]*models.Reminder, error) {
+	query := `
+		SELECT ` + reminderColumns + `
+		FROM reminders
+		WHERE delivered_at IS NULL AND dismissed_at IS NULL
+		  AND delivery_attempts < $2
+		  AND (next_attempt_at IS NULL OR next_attempt_at <= $1)
+		ORDER BY created_at
+		LIMIT $3
+	`
+
+	return r.queryReminders(query, now, maxAttempts, limit)
+}
+
+// RecordDeliveryFailure counts a failed delivery of a reminder and schedules
+// the next attempt
+func (r *ReminderRepository) RecordDeliveryFailure(id uuid.UUID, nextAttemptAt time.Time) error {
+	query := `UPDATE reminders SET delivery_attempts = delivery_attempts + 1, next_attempt_at = $2 WHERE id = $1`
+
+	result, err := r.db.Exec(query, id, nextAttemptAt)
+	if err != nil {
+		return fmt.Errorf("failed to record reminder delivery failure: %w", err)
+	}
+
+	return expectReminderRow(result)
+}
+
+// MarkReminderDelivered records that a reminder was delivered
+func (r *ReminderRepository) MarkReminderDelivered(id uuid.UUID) error {
+	result, err := r.db.Exec(`UPDATE reminders SET delivered_at = NOW() WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark reminder delivered: %w", err)
+	}
+
+	return expectReminderRow(result)
+}
+
+// GetReminderChannels lists the channels a reminder was delivered through
+func (r *ReminderRepository) GetReminderChannels(reminderID uuid.UUID) ([]string, error) {
+	rows, err := r.db.Query(`SELECT channel FROM reminder_deliveries WHERE reminder_id = $1 ORDER BY channel`, reminderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reminder channels: %w", err)
+	}
+	defer rows.Close()
+
+	channels := []string{}
+	for rows.Next() {
+		var channel string
+		if err := rows.Scan(&channel); err != nil {
+			return nil, fmt.Errorf("failed to scan reminder channel: %w", err)
+		}
+		channels = append(channels, channel)
+	}
+
+	return channels, rows.Err()
+}
+
+// MarkChannelDelivered records that a reminder was delivered through a
+// channel
+func (r *ReminderRepository) MarkChannelDelivered(reminderID uuid.UUID, channel string) error {
+	query := `
+		INSERT INTO reminder_deliveries (reminder_id, channel, delivered_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (reminder_id, channel) DO NOTHING
+	`
+
+	if _, err := r.db.Exec(query, reminderID, channel); err != nil {
+		return fmt.Errorf("failed to mark reminder channel delivered: %w", err)
+	}
+
+	return nil
+}
+
+// GetReminders lists a user's reminders, most recently due first. Dismissed
+// reminders are left out unless includeDismissed is set.
+func (r *ReminderRepository) GetReminders(userID uuid.UUID, includeDismissed bool) ([]*models.Reminder, error) {
+	query := `
+		SELECT ` + reminderColumns + `
+		FROM reminders
+		WHERE user_id = $1 AND ($2 OR dismissed_at IS NULL)
+		ORDER BY due_at DESC
+	`
+
+	return r.queryReminders(query, userID, includeDismissed)
+}
+
+// DismissReminder records that the user has dealt with a reminder
+func (r *ReminderRepository) DismissReminder(id, userID uuid.UUID) error {
+	query := `UPDATE reminders SET dismissed_at = COALESCE(dismissed_at, NOW()) WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.Exec(query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to dismiss reminder: %w", err)
+	}
+
+	return expectReminderRow(result)
+}
+
+func (r *ReminderRepository) queryReminders(query string, args ...interface{}) ([]*models.Reminder, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reminders: %w", err)
+	}
+	defer rows.Close()
+
+	var reminders []*models.Reminder
+	for rows.Next() {
+		reminder := &models.Reminder{}
+		var deliveredAt, dismissedAt sql.NullTime
+		err := rows.Scan(
+			&reminder.ID,
+			&reminder.UserID,
+			&reminder.ApplicationID,
+			&reminder.Status,
+			&reminder.StatusSince,
+			&reminder.Message,
+			&reminder.DueAt,
+			&deliveredAt,
+			&dismissedAt,
+			&reminder.CreatedAt,
+			&reminder.DeliveryAttempts,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reminder: %w", err)
+		}
+		if deliveredAt.Valid {
+			reminder.DeliveredAt = &deliveredAt.Time
+		}
+		if dismissedAt.Valid {
+			reminder.DismissedAt = &dismissedAt.Time
+		}
+		reminders = append(reminders, reminder)
+	}
+
+	return reminders, rows.Err()
+}
+
+func expectReminderRow(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrReminderNotFound
+	}
+
+	return nil
+}
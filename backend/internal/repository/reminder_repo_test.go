@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestReminderRepository_FollowUpRules(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewReminderRepository(db)
+	user, _ := createTestUser(t, db, "rules@example.com")
+
+	app := newTestApplication(user.ID, "Acme", date(2024, time.March, 4))
+	if err := NewApplicationRepository(db).CreateApplication(app); err != nil {
+		t.Fatalf("CreateApplication: %v", err)
+	}
+
+	rules, err := repo.GetFollowUpRules(app.ID, user.ID)
+	if err != nil {
+		t.Fatalf("GetFollowUpRules: %v", err)
+	}
+	if rules == nil || len(rules) != 0 {
+		t.Errorf("GetFollowUpRules without rules = %#v, want empty slice", rules)
+	}
+
+	replace := []models.FollowUpRule{{Status: models.ApplicationInterview, AfterDays: 3}, {Status: models.ApplicationApplied, AfterDays: 0}}
+	if err := repo.ReplaceFollowUpRules(app.ID, user.ID, replace); err != nil {
+		t.Fatalf("ReplaceFollowUpRules: %v", err)
+	}
+	if err := repo.ReplaceFollowUpRules(app.ID, user.ID, replace[:1]); err != nil {
+		t.Fatalf("ReplaceFollowUpRules again: %v", err)
+	}
+	rules, _ = repo.GetFollowUpRules(app.ID, user.ID)
+	if len(rules) != 1 || rules[0] != replace[0] {
+		t.Errorf("rules after replace = %+v", rules)
+	}
+
+	if err := repo.ReplaceFollowUpRules(app.ID, uuid.New(), replace); err != ErrApplicationNotFound {
+		t.Errorf("ReplaceFollowUpRules other user = %v, want ErrApplicationNotFound", err)
+	}
+	if rules, _ = repo.GetFollowUpRules(app.ID, uuid.New()); len(rules) != 0 {
+		t.Errorf("GetFollowUpRules other user = %+v, want none", rules)
+	}
+}
+
+func TestReminderRepository_Reminders(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewReminderRepository(db)
+	appRepo := NewApplicationRepository(db)
+	user, _ := createTestUser(t, db, "reminders@example.com")
+
+	app := newTestApplication(user.ID, "Acme", date(2024, time.March, 4))
+	offer := newTestApplication(user.ID, "Globex", date(2024, time.March, 4))
+	offer.Status = models.ApplicationOffer
+	for _, a := range []*models.Application{app, offer} {
+		if err := appRepo.CreateApplication(a); err != nil {
+			t.Fatalf("CreateApplication: %v", err)
+		}
+	}
+	if err := repo.ReplaceFollowUpRules(app.ID, user.ID, []models.FollowUpRule{{Status: models.ApplicationApplied, AfterDays: 10}}); err != nil {
+		t.Fatalf("ReplaceFollowUpRules: %v", err)
+	}
+
+	candidates, err := repo.GetFollowUpCandidates([]string{models.ApplicationApplied, models.ApplicationInterview})
+	if err != nil {
+		t.Fatalf("GetFollowUpCandidates: %v", err)
+	}
+	if len(candidates) != 1 || candidates[0].ApplicationID != app.ID || candidates[0].UserID != user.ID ||
+		candidates[0].CompanyName != "Acme" || candidates[0].AfterDays == nil || *candidates[0].AfterDays != 10 {
+		t.Fatalf("candidates = %+v", candidates)
+	}
+
+	// A rule makes a status without default follow-ups count too
+	offerRule := []models.FollowUpRule{{Status: models.ApplicationOffer, AfterDays: 3}}
+	if err := repo.ReplaceFollowUpRules(offer.ID, user.ID, offerRule); err != nil {
+		t.Fatalf("ReplaceFollowUpRules: %v", err)
+	}
+	withRule, err := repo.GetFollowUpCandidates([]string{models.ApplicationApplied, models.ApplicationInterview})
+	if err != nil || len(withRule) != 2 {
+		t.Fatalf("candidates with an offer rule = %+v, %v", withRule, err)
+	}
+	for _, c := range withRule {
+		if c.ApplicationID == offer.ID && (c.Status != models.ApplicationOffer || *c.AfterDays != 3) {
+			t.Errorf("offer candidate = %+v", c)
+		}
+	}
+	if err := repo.ReplaceFollowUpRules(offer.ID, user.ID, nil); err != nil {
+		t.Fatalf("ReplaceFollowUpRules: %v", err)
+	}
+
+	candidate := candidates[0]
+	reminder := &models.Reminder{
+		ID:            uuid.New(),
+		UserID:        user.ID,
+		ApplicationID: app.ID,
+		Status:        candidate.Status,
+		StatusSince:   candidate.StatusChangedAt,
+		Message:       "Follow up with Acme",
+		DueAt:         candidate.StatusChangedAt.AddDate(0, 0, 10),
+	}
+	created, err := repo.CreateReminder(reminder)
+	if err != nil || !created {
+		t.Fatalf("CreateReminder = %v, %v", created, err)
+	}
+
+	// A second reminder for the same stay in a status is dropped
+	duplicate := *reminder
+	duplicate.ID = uuid.New()
+	if created, err := repo.CreateReminder(&duplicate); err != nil || created {
+		t.Errorf("duplicate CreateReminder = %v, %v, want false, nil", created, err)
+	}
+	if candidates, _ = repo.GetFollowUpCandidates([]string{models.ApplicationApplied}); len(candidates) != 0 {
+		t.Errorf("candidates after reminder = %+v, want none", candidates)
+	}
+
+	now := time.Now().UTC()
+	undelivered, err := repo.GetUndeliveredReminders(now, 3, 10)
+	if err != nil {
+		t.Fatalf("GetUndeliveredReminders: %v", err)
+	}
+	if len(undelivered) != 1 || undelivered[0].ID != reminder.ID || undelivered[0].DeliveredAt != nil {
+		t.Fatalf("undelivered = %+v", undelivered)
+	}
+
+	// Dismissed reminders aren't delivered any more
+	dismissed := &models.Reminder{
+		ID:            uuid.New(),
+		UserID:        user.ID,
+		ApplicationID: offer.ID,
+		Status:        models.ApplicationOffer,
+		StatusSince:   candidate.StatusChangedAt,
+		Message:       "Follow up with Globex",
+		DueAt:         candidate.StatusChangedAt,
+	}
+	if _, err := repo.CreateReminder(dismissed); err != nil {
+		t.Fatalf("CreateReminder: %v", err)
+	}
+	if err := repo.DismissReminder(dismissed.ID, user.ID); err != nil {
+		t.Fatalf("DismissReminder: %v", err)
+	}
+	if undelivered, _ = repo.GetUndeliveredReminders(now, 3, 10); len(undelivered) != 1 || undelivered[0].ID != reminder.ID {
+		t.Errorf("undelivered with a dismissed reminder = %+v", undelivered)
+	}
+
+	// A failed delivery waits for its next attempt, up to the maximum
+	if err := repo.RecordDeliveryFailure(reminder.ID, now.Add(time.Hour)); err != nil {
+		t.Fatalf("RecordDeliveryFailure: %v", err)
+	}
+	if undelivered, _ = repo.GetUndeliveredReminders(now, 3, 10); len(undelivered) != 0 {
+		t.Errorf("undelivered before the next attempt = %+v", undelivered)
+	}
+	if undelivered, _ = repo.GetUndeliveredReminders(now.Add(time.Hour), 3, 10); len(undelivered) != 1 || undelivered[0].DeliveryAttempts != 1 {
+		t.Errorf("undelivered at the next attempt = %+v", undelivered)
+	}
+	if undelivered, _ = repo.GetUndeliveredReminders(now.Add(time.Hour), 1, 10); len(undelivered) != 0 {
+		t.Errorf("undelivered after the last attempt = %+v", undelivered)
+	}
+
+	if err := repo.MarkReminderDelivered(reminder.ID); err != nil {
+		t.Fatalf("MarkReminderDelivered: %v", err)
+	}
+	if err := repo.MarkChannelDelivered(reminder.ID, "email"); err != nil {
+		t.Fatalf("MarkChannelDelivered: %v", err)
+	}
+	if err := repo.MarkChannelDelivered(reminder.ID, "email"); err != nil {
+		t.Fatalf("repeated MarkChannelDelivered: %v", err)
+	}
+	if channels, err := repo.GetReminderChannels(reminder.ID); err != nil || len(channels) != 1 || channels[0] != "email" {
+		t.Errorf("GetReminderChannels = %v, %v, want [email]", channels, err)
+	}
+	if undelivered, _ = repo.GetUndeliveredReminders(now.Add(time.Hour), 3, 10); len(undelivered) != 0 {
+		t.Errorf("undelivered after delivery = %+v", undelivered)
+	}
+
+	reminders, err := repo.GetReminders(user.ID, false)
+	if err != nil {
+		t.Fatalf("GetReminders: %v", err)
+	}
+	if len(reminders) != 1 || reminders[0].DeliveredAt == nil || reminders[0].Message != "Follow up with Acme" {
+		t.Errorf("reminders = %+v", reminders)
+	}
+
+	if err := repo.DismissReminder(reminder.ID, uuid.New()); err != ErrReminderNotFound {
+		t.Errorf("DismissReminder other user = %v, want ErrReminderNotFound", err)
+	}
+	if err := repo.DismissReminder(reminder.ID, user.ID); err != nil {
+		t.Fatalf("DismissReminder: %v", err)
+	}
+	if reminders, _ = repo.GetReminders(user.ID, false); len(reminders) != 0 {
+		t.Errorf("reminders after dismiss = %+v", reminders)
+	}
+	if reminders, _ = repo.GetReminders(user.ID, true); len(reminders) != 2 || reminders[0].DismissedAt == nil || reminders[1].DismissedAt == nil {
+		t.Errorf("reminders including dismissed = %+v", reminders)
+	}
+}
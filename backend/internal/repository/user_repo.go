@@ -28,7 +28,7 @@ func (r *UserRepository) CreateUser(user *models.User) error {
 	query := `
 		INSERT INTO users (id, email, password_hash, full_name, free_generations_left, is_premium, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
-		RETURNING id, created_at, updated_at
+		RETURNING id, free_regenerations_left, created_at, updated_at
 	`
 
 	err := r.db.QueryRow(
@@ -39,7 +39,7 @@ func (r *UserRepository) CreateUser(user *models.User) error {
 		user.FullName,
 		user.FreeGenerationsLeft,
 		user.IsPremium,
-	).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+	).Scan(&user.ID, &user.FreeRegenerationsLeft, &user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
 		// Check for unique constraint violation
@@ -55,7 +55,7 @@ func (r *UserRepository) CreateUser(user *models.User) error {
 // GetUserByEmail retrieves a user by email
 func (r *UserRepository) GetUserByEmail(email string) (*models.User, error) {
 	query := `
-		SELECT id, email, password_hash, full_name, free_generations_left, is_premium, created_at, updated_at
+		SELECT id, email, password_hash, full_name, free_generations_left, free_regenerations_left, is_premium, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
@@ -67,6 +67,7 @@ func (r *UserRepository) GetUserByEmail(email string) (*models.User, error) {
 		&user.PasswordHash,
 		&user.FullName,
 		&user.FreeGenerationsLeft,
+		&user.FreeRegenerationsLeft,
 		&user.IsPremium,
 		&user.CreatedAt,
 		&user.UpdatedAt,
@@ -85,7 +86,7 @@ func (r *UserRepository) GetUserByEmail(email string) (*models.User, error) {
 // GetUserByID retrieves a user by ID
 func (r *UserRepository) GetUserByID(id uuid.UUID) (*models.User, error) {
 	query := `
-		SELECT id, email, password_hash, full_name, free_generations_left, is_premium, created_at, updated_at
+		SELECT id, email, password_hash, full_name, free_generations_left, free_regenerations_left, is_premium, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
@@ -97,6 +98,7 @@ func (r *UserRepository) GetUserByID(id uuid.UUID) (*models.User, error) {
 		&user.PasswordHash,
 		&user.FullName,
 		&user.FreeGenerationsLeft,
+		&user.FreeRegenerationsLeft,
 		&user.IsPremium,
 		&user.CreatedAt,
 		&user.UpdatedAt,
@@ -116,11 +118,11 @@ func (r *UserRepository) GetUserByID(id uuid.UUID) (*models.User, error) {
 func (r *UserRepository) UpdateUser(user *models.User) error {
 	query := `
 		UPDATE users
-		SET email = $1, full_name = $2, free_generations_left = $3, is_premium = $4, updated_at = NOW()
-		WHERE id = $5
+		SET email = $1, full_name = $2, free_generations_left = $3, free_regenerations_left = $4, is_premium = $5, updated_at = NOW()
+		WHERE id = $6
 	`
 
-	result, err := r.db.Exec(query, user.Email, user.FullName, user.FreeGenerationsLeft, user.IsPremium, user.ID)
+	result, err := r.db.Exec(query, user.Email, user.FullName, user.FreeGenerationsLeft, user.FreeRegenerationsLeft, user.IsPremium, user.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update user: %w", err)
 	}
@@ -162,11 +164,36 @@ func (r *UserRepository) DecrementFreeGenerations(userID uuid.UUID) error {
 	return nil
 }
 
-// CreateProfile creates a profile for a user
+// DecrementFreeRegenerations decrements the free section regenerations count
+func (r *UserRepository) DecrementFreeRegenerations(userID uuid.UUID) error {
+	query := `
+		UPDATE users
+		SET free_regenerations_left = free_regenerations_left - 1, updated_at = NOW()
+		WHERE id = $1 AND free_regenerations_left > 0
+	`
+
+	result, err := r.db.Exec(query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to decrement free regenerations: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("no free regenerations left or user not found")
+	}
+
+	return nil
+}
+
+// CreateProfile creates the default profile for a new user
 func (r *UserRepository) CreateProfile(userID uuid.UUID) error {
 	query := `
-		INSERT INTO profiles (user_id, created_at, updated_at)
-		VALUES ($1, NOW(), NOW())
+		INSERT INTO profiles (user_id, name, is_default, created_at, updated_at)
+		VALUES ($1, 'Default', TRUE, NOW(), NOW())
 	`
 
 	_, err := r.db.Exec(query, userID)
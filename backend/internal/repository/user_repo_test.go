@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestUserRepository_CreateAndGet(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewUserRepository(db)
+
+	user := &models.User{
+		ID:                  uuid.New(),
+		Email:               "jane@example.com",
+		PasswordHash:        "hash",
+		FullName:            "Jane Doe",
+		FreeGenerationsLeft: 2,
+	}
+	if err := repo.CreateUser(user); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if user.CreatedAt.IsZero() || user.UpdatedAt.IsZero() {
+		t.Fatal("CreateUser did not populate timestamps")
+	}
+
+	byEmail, err := repo.GetUserByEmail("jane@example.com")
+	if err != nil {
+		t.Fatalf("GetUserByEmail: %v", err)
+	}
+	if byEmail.ID != user.ID || byEmail.PasswordHash != "hash" || byEmail.FullName != "Jane Doe" {
+		t.Errorf("GetUserByEmail = %+v", byEmail)
+	}
+
+	byID, err := repo.GetUserByID(user.ID)
+	if err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	}
+	if byID.Email != user.Email || byID.FreeGenerationsLeft != 2 || byID.IsPremium {
+		t.Errorf("GetUserByID = %+v", byID)
+	}
+}
+
+func TestUserRepository_CreateUserDuplicateEmail(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewUserRepository(db)
+	createTestUser(t, db, "dup@example.com")
+
+	err := repo.CreateUser(&models.User{
+		ID:           uuid.New(),
+		Email:        "dup@example.com",
+		PasswordHash: "hash",
+		FullName:     "Someone Else",
+	})
+	if err != ErrUserAlreadyExists {
+		t.Fatalf("CreateUser duplicate = %v, want ErrUserAlreadyExists", err)
+	}
+}
+
+func TestUserRepository_NotFound(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewUserRepository(db)
+
+	if _, err := repo.GetUserByEmail("missing@example.com"); err != ErrUserNotFound {
+		t.Errorf("GetUserByEmail = %v, want ErrUserNotFound", err)
+	}
+	if _, err := repo.GetUserByID(uuid.New()); err != ErrUserNotFound {
+		t.Errorf("GetUserByID = %v, want ErrUserNotFound", err)
+	}
+	if err := repo.UpdateUser(&models.User{ID: uuid.New(), Email: "x@example.com"}); err != ErrUserNotFound {
+		t.Errorf("UpdateUser = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestUserRepository_UpdateUser(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewUserRepository(db)
+	user, _ := createTestUser(t, db, "old@example.com")
+
+	user.Email = "new@example.com"
+	user.FullName = "New Name"
+	user.FreeGenerationsLeft = 10
+	user.IsPremium = true
+	if err := repo.UpdateUser(user); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+
+	got, err := repo.GetUserByID(user.ID)
+	if err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	}
+	if got.Email != "new@example.com" || got.FullName != "New Name" || got.FreeGenerationsLeft != 10 || !got.IsPremium {
+		t.Errorf("GetUserByID after update = %+v", got)
+	}
+}
+
+func TestUserRepository_DecrementFreeGenerations(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewUserRepository(db)
+	user, _ := createTestUser(t, db, "gen@example.com")
+
+	for i := 0; i < 2; i++ {
+		if err := repo.DecrementFreeGenerations(user.ID); err != nil {
+			t.Fatalf("DecrementFreeGenerations #%d: %v", i+1, err)
+		}
+	}
+
+	got, err := repo.GetUserByID(user.ID)
+	if err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	}
+	if got.FreeGenerationsLeft != 0 {
+		t.Errorf("FreeGenerationsLeft = %d, want 0", got.FreeGenerationsLeft)
+	}
+
+	if err := repo.DecrementFreeGenerations(user.ID); err == nil {
+		t.Error("DecrementFreeGenerations at zero returned nil error")
+	}
+	if err := repo.DecrementFreeGenerations(uuid.New()); err == nil {
+		t.Error("DecrementFreeGenerations for unknown user returned nil error")
+	}
+}
+
+func TestUserRepository_DecrementFreeRegenerations(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewUserRepository(db)
+	user, _ := createTestUser(t, db, "regen@example.com")
+
+	if user.FreeRegenerationsLeft != 10 {
+		t.Fatalf("FreeRegenerationsLeft of a new user = %d, want 10", user.FreeRegenerationsLeft)
+	}
+	if err := repo.DecrementFreeRegenerations(user.ID); err != nil {
+		t.Fatalf("DecrementFreeRegenerations: %v", err)
+	}
+
+	got, err := repo.GetUserByID(user.ID)
+	if err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	}
+	if got.FreeRegenerationsLeft != 9 || got.FreeGenerationsLeft != 2 {
+		t.Errorf("after a regeneration = %d regenerations, %d generations, want 9, 2", got.FreeRegenerationsLeft, got.FreeGenerationsLeft)
+	}
+
+	got.FreeRegenerationsLeft = 0
+	if err := repo.UpdateUser(got); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if err := repo.DecrementFreeRegenerations(user.ID); err == nil {
+		t.Error("DecrementFreeRegenerations at zero returned nil error")
+	}
+}
+
+func TestUserRepository_CreateProfile(t *testing.T) {
+	db := newTestDB(t)
+	user, profile := createTestUser(t, db, "profile@example.com")
+
+	if profile.UserID != user.ID {
+		t.Errorf("profile.UserID = %s, want %s", profile.UserID, user.ID)
+	}
+
+	if profile.Name != "Default" || !profile.IsDefault {
+		t.Errorf("profile = %q, default %v; want the default profile", profile.Name, profile.IsDefault)
+	}
+
+	// A user has exactly one default profile, a second must be rejected
+	if err := NewUserRepository(db).CreateProfile(user.ID); err == nil {
+		t.Error("second CreateProfile returned nil error")
+	}
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidApplicationStatus   = errors.New("status must be applied, interview, offer or rejected")
+	ErrInvalidStatusTransition    = errors.New("application can't move to this status")
+	ErrInvalidApplicationDocument = errors.New("linked documents must be your own resume and cover letter")
+)
+
+// applicationTransitions lists the statuses each status can move to.
+// Interviews may be skipped, offer and rejected are final.
+var applicationTransitions = map[string][]string{
+	models.ApplicationApplied:   {models.ApplicationInterview, models.ApplicationOffer, models.ApplicationRejected},
+	models.ApplicationInterview: {models.ApplicationOffer, models.ApplicationRejected},
+	models.ApplicationOffer:     {},
+	models.ApplicationRejected:  {},
+}
+
+// IsApplicationStatus reports whether status is one of the application statuses
+func IsApplicationStatus(status string) bool {
+	_, ok := applicationTransitions[status]
+	return ok
+}
+
+// CanChangeApplicationStatus reports whether an application may move from
+// one status to another
+func CanChangeApplicationStatus(from, to string) bool {
+	for _, next := range applicationTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+type ApplicationService struct {
+	applicationRepo *repository.ApplicationRepository
+	documentRepo    *repository.DocumentRepository
+}
+
+func NewApplicationService(applicationRepo *repository.ApplicationRepository, documentRepo *repository.DocumentRepository) *ApplicationService {
+	return &ApplicationService{
+		applicationRepo: applicationRepo,
+		documentRepo:    documentRepo,
+	}
+}
+
+// CreateApplication tracks a new application. Without a status it starts as
+// applied and without an applied date it is dated today. A later status is
+// allowed for applications entered after the fact.
+func (s *ApplicationService) CreateApplication(userID uuid.UUID, app *models.Application) (*models.Application, error) {
+	if app.Status == "" {
+		app.Status = models.ApplicationApplied
+	}
+	if !IsApplicationStatus(app.Status) {
+		return nil, ErrInvalidApplicationStatus
+	}
+	if err := s.checkDocuments(userID, app); err != nil {
+		return nil, err
+	}
+	if app.AppliedDate.IsZero() {
+		app.AppliedDate = models.Date{Time: time.Now()}
+	}
+
+	app.ID = uuid.New()
+	app.UserID = userID
+
+	if err := s.applicationRepo.CreateApplication(app); err != nil {
+		return nil, fmt.Errorf("failed to create application: %w", err)
+	}
+
+	return app, nil
+}
+
+// GetApplications lists the user's applications matching filter
+func (s *ApplicationService) GetApplications(userID uuid.UUID, filter models.ApplicationFilter) ([]*models.Application, error) {
+	for _, status := range filter.Statuses {
+		if !IsApplicationStatus(status) {
+			return nil, ErrInvalidApplicationStatus
+		}
+	}
+
+	applications, err := s.applicationRepo.GetApplications(userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applications: %w", err)
+	}
+
+	return applications, nil
+}
+
+func (s *ApplicationService) GetApplication(userID, appID uuid.UUID) (*models.Application, error) {
+	return s.applicationRepo.GetApplicationByID(appID, userID)
+}
+
+// UpdateApplication replaces everything but the status and returns the
+// updated application
+func (s *ApplicationService) UpdateApplication(userID, appID uuid.UUID, app *models.Application) (*models.Application, error) {
+	if err := s.checkDocuments(userID, app); err != nil {
+		return nil, err
+	}
+
+	app.ID = appID
+	app.UserID = userID
+
+	if err := s.applicationRepo.UpdateApplication(app); err != nil {
+		return nil, fmt.Errorf("failed to update application: %w", err)
+	}
+
+	return s.applicationRepo.GetApplicationByID(appID, userID)
+}
+
+// ChangeStatus moves an application along the pipeline and returns it
+func (s *ApplicationService) ChangeStatus(userID, appID uuid.UUID, req *models.ApplicationStatusRequest) (*models.Application, error) {
+	if !IsApplicationStatus(req.Status) {
+		return nil, ErrInvalidApplicationStatus
+	}
+
+	app, err := s.applicationRepo.GetApplicationByID(appID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !CanChangeApplicationStatus(app.Status, req.Status) {
+		return nil, fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, app.Status, req.Status)
+	}
+
+	change := &models.ApplicationStatusChange{
+		ID:            uuid.New(),
+		ApplicationID: appID,
+		FromStatus:    app.Status,
+		ToStatus:      req.Status,
+		Note:          req.Note,
+	}
+	if err := s.applicationRepo.ChangeApplicationStatus(userID, change); err != nil {
+		return nil, fmt.Errorf("failed to change application status: %w", err)
+	}
+
+	return s.applicationRepo.GetApplicationByID(appID, userID)
+}
+
+// GetApplicationHistory lists the status changes of an application, oldest
+// first
+func (s *ApplicationService) GetApplicationHistory(userID, appID uuid.UUID) ([]*models.ApplicationStatusChange, error) {
+	// Tell a missing application apart from one without history
+	if _, err := s.applicationRepo.GetApplicationByID(appID, userID); err != nil {
+		return nil, err
+	}
+
+	history, err := s.applicationRepo.GetApplicationHistory(appID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application history: %w", err)
+	}
+
+	return history, nil
+}
+
+func (s *ApplicationService) DeleteApplication(userID, appID uuid.UUID) error {
+	return s.applicationRepo.DeleteApplication(appID, userID)
+}
+
+// checkDocuments makes sure the linked documents belong to the user and are
+// a resume and a cover letter respectively
+func (s *ApplicationService) checkDocuments(userID uuid.UUID, app *models.Application) error {
+	links := []struct {
+		id      *uuid.UUID
+		docType string
+	}{
+		{app.ResumeDocumentID, "resume"},
+		{app.CoverLetterDocumentID, "cover_letter"},
+	}
+
+	for _, link := range links {
+		if link.id == nil {
+			continue
+		}
+		doc, err := s.documentRepo.GetDocumentByID(*link.id, userID)
+		if err != nil {
+			if err == repository.ErrUserNotFound {
+				return ErrInvalidApplicationDocument
+			}
+			return fmt.Errorf("failed to get document: %w", err)
+		}
+		if doc.Type != link.docType {
+			return ErrInvalidApplicationDocument
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/taxonomy"
+)
+
+// Points available per ATS scoring category
+const (
+	atsRequiredSkillsPoints = 40.0
+	atsKeywordsPoints       = 25.0
+	atsNiceToHavePoints     = 10.0
+	atsSectionsPoints       = 15.0
+	atsLengthPoints         = 10.0
+)
+
+// atsDocumentRules are the sections and word count an ATS expects per document type
+var atsDocumentRules = map[string]struct {
+	sections     []string
+	minWords     int // full points from here...
+	maxWords     int // ...up to here
+	hardMinWords int // no points below
+	hardMaxWords int // no points above
+}{
+	"resume":       {sections: []string{"summary", "experience", "education", "skills"}, minWords: 300, maxWords: 900, hardMinWords: 100, hardMaxWords: 1400},
+	"cover_letter": {sections: []string{"opening", "body1", "closing"}, minWords: 250, maxWords: 450, hardMinWords: 100, hardMaxWords: 700},
+}
+
+// atsInput is the text being scored and the sections it contains
+type atsInput struct {
+	docType  string
+	source   string
+	text     string
+	sections map[string]bool
+}
+
+// ScoreATS compares a resume or cover letter with a job analysis. Everything
+// is computed locally, so scoring is free and repeatable.
+func ScoreATS(input atsInput, analysis *models.JobAnalysis) *models.ATSScore {
+	score := &models.ATSScore{
+		Source:                  input.source,
+		MatchedSkills:           []string{},
+		MissingRequiredSkills:   []string{},
+		MissingNiceToHaveSkills: []string{},
+		MatchedKeywords:         []string{},
+		MissingKeywords:         []string{},
+		MissingSections:         []string{},
+		Gaps:                    []models.ATSGap{},
+	}
+
+	skills := taxonomy.Default()
+	present := map[string]bool{}
+	for _, skill := range skills.Find(input.text) {
+		present[skill] = true
+	}
+	contains := func(term string) bool {
+		// Known skills go through their alias-aware matchers only, so the
+		// verb "go" never counts as the language Go
+		if skill, ok := skills.Lookup(term); ok {
+			return present[skill.Name]
+		}
+		return containsTerm(input.text, term)
+	}
+
+	// Required skills
+	for _, skill := range analysis.RequiredSkills {
+		if contains(skill) {
+			score.MatchedSkills = append(score.MatchedSkills, skill)
+		} else {
+			score.MissingRequiredSkills = append(score.MissingRequiredSkills, skill)
+			score.Gaps = append(score.Gaps, models.ATSGap{
+				Type:     "missing_skill",
+				Severity: "high",
+				Message:  fmt.Sprintf("Required skill %q is not mentioned. Add it to your skills or describe where you used it, if you have it.", skill),
+			})
+		}
+	}
+	score.Breakdown.RequiredSkills = ratioPoints(len(analysis.RequiredSkills)-len(score.MissingRequiredSkills), len(analysis.RequiredSkills), atsRequiredSkillsPoints)
+
+	// Nice-to-have skills
+	for _, skill := range analysis.NiceToHaveSkills {
+		if contains(skill) {
+			score.MatchedSkills = append(score.MatchedSkills, skill)
+		} else {
+			score.MissingNiceToHaveSkills = append(score.MissingNiceToHaveSkills, skill)
+			score.Gaps = append(score.Gaps, models.ATSGap{
+				Type:     "missing_skill",
+				Severity: "low",
+				Message:  fmt.Sprintf("Nice-to-have skill %q is not mentioned.", skill),
+			})
+		}
+	}
+	score.Breakdown.NiceToHaveSkills = ratioPoints(len(analysis.NiceToHaveSkills)-len(score.MissingNiceToHaveSkills), len(analysis.NiceToHaveSkills), atsNiceToHavePoints)
+
+	// Keywords (skills are already reported above)
+	skillSet := map[string]bool{}
+	for _, skill := range append(append([]string{}, analysis.RequiredSkills...), analysis.NiceToHaveSkills...) {
+		skillSet[strings.ToLower(skill)] = true
+	}
+	var missingTerms []string
+	for _, keyword := range analysis.Keywords {
+		if contains(keyword) {
+			score.MatchedKeywords = append(score.MatchedKeywords, keyword)
+			continue
+		}
+		score.MissingKeywords = append(score.MissingKeywords, keyword)
+		if !skillSet[strings.ToLower(keyword)] {
+			missingTerms = append(missingTerms, keyword)
+		}
+	}
+	score.Breakdown.Keywords = ratioPoints(len(score.MatchedKeywords), len(analysis.Keywords), atsKeywordsPoints)
+	if len(missingTerms) > 0 {
+		score.Gaps = append(score.Gaps, models.ATSGap{
+			Type:     "missing_keyword",
+			Severity: "medium",
+			Message:  fmt.Sprintf("Job description terms missing from your text: %s.", strings.Join(missingTerms, ", ")),
+		})
+	}
+
+	// Sections
+	rules, ok := atsDocumentRules[input.docType]
+	if !ok {
+		rules = atsDocumentRules["resume"]
+	}
+	for _, section := range rules.sections {
+		if !input.sections[section] {
+			score.MissingSections = append(score.MissingSections, section)
+			score.Gaps = append(score.Gaps, models.ATSGap{
+				Type:     "missing_section",
+				Severity: "high",
+				Message:  fmt.Sprintf("The %s section is missing or empty.", section),
+			})
+		}
+	}
+	score.Breakdown.Sections = ratioPoints(len(rules.sections)-len(score.MissingSections), len(rules.sections), atsSectionsPoints)
+
+	// Length
+	score.WordCount = len(strings.Fields(input.text))
+	switch {
+	case score.WordCount < rules.minWords:
+		score.Breakdown.Length = rangePoints(score.WordCount, rules.hardMinWords, rules.minWords, atsLengthPoints)
+		score.Gaps = append(score.Gaps, models.ATSGap{
+			Type:     "length",
+			Severity: "medium",
+			Message:  fmt.Sprintf("At %d words the text is short; aim for %d-%d words.", score.WordCount, rules.minWords, rules.maxWords),
+		})
+	case score.WordCount > rules.maxWords:
+		score.Breakdown.Length = rangePoints(rules.hardMaxWords-score.WordCount, 0, rules.hardMaxWords-rules.maxWords, atsLengthPoints)
+		score.Gaps = append(score.Gaps, models.ATSGap{
+			Type:     "length",
+			Severity: "medium",
+			Message:  fmt.Sprintf("At %d words the text is long; aim for %d-%d words.", score.WordCount, rules.minWords, rules.maxWords),
+		})
+	default:
+		score.Breakdown.Length = atsLengthPoints
+	}
+
+	total := score.Breakdown.RequiredSkills + score.Breakdown.Keywords + score.Breakdown.NiceToHaveSkills +
+		score.Breakdown.Sections + score.Breakdown.Length
+	score.Score = int(math.Round(total))
+
+	return score
+}
+
+// ratioPoints awards points in proportion to matched/total; an empty
+// category earns full points because nothing was asked for
+func ratioPoints(matched, total int, points float64) float64 {
+	if total == 0 {
+		return points
+	}
+	return round1(points * float64(matched) / float64(total))
+}
+
+// rangePoints scales points linearly from 0 at low to full at high
+func rangePoints(value, low, high int, points float64) float64 {
+	if value <= low || high <= low {
+		return 0
+	}
+	if value >= high {
+		return points
+	}
+	return round1(points * float64(value-low) / float64(high-low))
+}
+
+func round1(value float64) float64 {
+	return math.Round(value*10) / 10
+}
+
+// containsTerm reports whether text mentions term as a whole word, ignoring case
+func containsTerm(text, term string) bool {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return false
+	}
+	pattern, err := regexp.Compile(`(?i)(^|[^\pL\pN])` + regexp.QuoteMeta(term) + `($|[^\pL\pN])`)
+	if err != nil {
+		return false
+	}
+	return pattern.MatchString(text)
+}
+
+// contentText flattens every string in Document.Content into one text
+func contentText(value interface{}) string {
+	var parts []string
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch typed := v.(type) {
+		case string:
+			parts = append(parts, typed)
+		case []interface{}:
+			for _, item := range typed {
+				walk(item)
+			}
+		case map[string]interface{}:
+			for _, item := range typed {
+				walk(item)
+			}
+		}
+	}
+	walk(value)
+	return strings.Join(parts, "\n")
+}
+
+// contentSections reports which top-level Content sections hold any text
+func contentSections(content map[string]interface{}) map[string]bool {
+	sections := map[string]bool{}
+	for key, value := range content {
+		if strings.TrimSpace(contentText(value)) != "" {
+			sections[key] = true
+		}
+	}
+	return sections
+}
+
+// profileATSInput builds scoring input from a raw profile, before any
+// document has been generated
+func profileATSInput(profile *ProfileData) atsInput {
+	var parts []string
+	sections := map[string]bool{}
+
+	if profile.Summary != "" {
+		parts = append(parts, profile.Summary)
+		sections["summary"] = true
+	}
+	for _, exp := range profile.Experiences {
+		parts = append(parts, exp.Position, exp.Company, exp.Description)
+		parts = append(parts, exp.Achievements...)
+		sections["experience"] = true
+	}
+	for _, edu := range profile.Education {
+		parts = append(parts, edu.Degree, edu.FieldOfStudy, edu.Institution)
+		sections["education"] = true
+	}
+	for _, skill := range profile.Skills {
+		parts = append(parts, skill.Name)
+		sections["skills"] = true
+	}
+	for _, project := range profile.Projects {
+		parts = append(parts, project.Name, project.Description)
+		parts = append(parts, project.TechStack...)
+	}
+	for _, cert := range profile.Certifications {
+		parts = append(parts, cert.Name)
+	}
+
+	return atsInput{
+		docType:  "resume",
+		source:   "profile",
+		text:     strings.Join(parts, "\n"),
+		sections: sections,
+	}
+}
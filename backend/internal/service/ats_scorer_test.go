@@ -0,0 +1,130 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+)
+
+// words returns n filler words
+func words(n int) string {
+	return strings.TrimSpace(strings.Repeat("word ", n))
+}
+
+func TestScoreATS(t *testing.T) {
+	analysis := &models.JobAnalysis{
+		RequiredSkills:   []string{"Go", "PostgreSQL"},
+		NiceToHaveSkills: []string{"Terraform"},
+		Keywords:         []string{"Go", "payments", "latency"},
+	}
+	allSections := map[string]bool{"summary": true, "experience": true, "education": true, "skills": true}
+
+	tests := []struct {
+		name    string
+		input   atsInput
+		score   int
+		matched []string
+		missing []string
+	}{
+		{
+			name:    "full match",
+			input:   atsInput{docType: "resume", text: "Golang and Postgres with Terraform for payments latency " + words(400), sections: allSections},
+			score:   100,
+			matched: []string{"Go", "PostgreSQL", "Terraform"},
+			missing: []string{},
+		},
+		{
+			// The verb "go" is not the language, so Go is missing
+			name:  "missing skills",
+			input: atsInput{docType: "resume", text: "Ready to go with PostgreSQL on payments " + words(400), sections: allSections},
+			score: 53, // half the required skills, a third of the keywords, no nice-to-have
+
+			matched: []string{"PostgreSQL"},
+			missing: []string{"Go"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := ScoreATS(tt.input, analysis)
+			if score.Score != tt.score {
+				t.Errorf("Score = %d, want %d (%+v)", score.Score, tt.score, score.Breakdown)
+			}
+			if !reflect.DeepEqual(score.MatchedSkills, tt.matched) {
+				t.Errorf("MatchedSkills = %v, want %v", score.MatchedSkills, tt.matched)
+			}
+			if !reflect.DeepEqual(score.MissingRequiredSkills, tt.missing) {
+				t.Errorf("MissingRequiredSkills = %v, want %v", score.MissingRequiredSkills, tt.missing)
+			}
+		})
+	}
+}
+
+func TestScoreATS_SectionsAndLength(t *testing.T) {
+	empty := &models.JobAnalysis{}
+
+	score := ScoreATS(atsInput{docType: "resume", text: words(50), sections: map[string]bool{"summary": true}}, empty)
+	if want := []string{"experience", "education", "skills"}; !reflect.DeepEqual(score.MissingSections, want) {
+		t.Errorf("MissingSections = %v, want %v", score.MissingSections, want)
+	}
+	// Nothing asked for in skills or keywords earns full points; too short for any length points
+	if score.Breakdown.RequiredSkills != 40 || score.Breakdown.Keywords != 25 || score.Breakdown.Length != 0 || score.Breakdown.Sections != 3.8 {
+		t.Errorf("Breakdown = %+v", score.Breakdown)
+	}
+	if score.WordCount != 50 {
+		t.Errorf("WordCount = %d, want 50", score.WordCount)
+	}
+
+	tests := []struct {
+		docType string
+		words   int
+		want    float64
+	}{
+		{"resume", 200, 5},
+		{"resume", 600, 10},
+		{"resume", 1150, 5},
+		{"resume", 1500, 0},
+		{"cover_letter", 300, 10},
+		{"cover_letter", 800, 0},
+		{"unknown", 600, 10},
+	}
+	for _, tt := range tests {
+		score := ScoreATS(atsInput{docType: tt.docType, text: words(tt.words)}, empty)
+		if score.Breakdown.Length != tt.want {
+			t.Errorf("%s of %d words: Length = %v, want %v", tt.docType, tt.words, score.Breakdown.Length, tt.want)
+		}
+	}
+}
+
+func TestContainsTerm(t *testing.T) {
+	tests := []struct {
+		text string
+		term string
+		want bool
+	}{
+		{"Built payment APIs", "payment", true},
+		{"Built PAYMENT APIs", "payment", true},
+		{"Built payments APIs", "payment", false},
+		{"Used C++ daily", "C++", true},
+		{"anything", " ", false},
+	}
+	for _, tt := range tests {
+		if got := containsTerm(tt.text, tt.term); got != tt.want {
+			t.Errorf("containsTerm(%q, %q) = %v, want %v", tt.text, tt.term, got, tt.want)
+		}
+	}
+}
+
+func TestContentSections(t *testing.T) {
+	content := map[string]interface{}{
+		"summary":    "Backend engineer",
+		"experience": []interface{}{map[string]interface{}{"highlights": []interface{}{"Built APIs"}}},
+		"education":  []interface{}{},
+		"skills":     "  ",
+	}
+	want := map[string]bool{"summary": true, "experience": true}
+	if got := contentSections(content); !reflect.DeepEqual(got, want) {
+		t.Errorf("contentSections = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+)
+
+// DiffContent compares two Document.Content values and returns every leaf
+// that was added, removed or changed. Lists are compared index by index.
+func DiffContent(from, to map[string]interface{}) []models.ContentChange {
+	changes := []models.ContentChange{}
+	diffValue("", from, to, &changes)
+	return changes
+}
+
+func diffValue(path string, from, to interface{}, changes *[]models.ContentChange) {
+	fromMap, fromIsMap := from.(map[string]interface{})
+	toMap, toIsMap := to.(map[string]interface{})
+	if fromIsMap && toIsMap {
+		diffMaps(path, fromMap, toMap, changes)
+		return
+	}
+
+	fromList, fromIsList := from.([]interface{})
+	toList, toIsList := to.([]interface{})
+	if fromIsList && toIsList {
+		diffLists(path, fromList, toList, changes)
+		return
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		*changes = append(*changes, models.ContentChange{
+			Path:     path,
+			Type:     "changed",
+			OldValue: from,
+			NewValue: to,
+		})
+	}
+}
+
+func diffMaps(path string, from, to map[string]interface{}, changes *[]models.ContentChange) {
+	keys := make([]string, 0, len(from)+len(to))
+	for key := range from {
+		keys = append(keys, key)
+	}
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		childPath := key
+		if path != "" {
+			childPath = path + "." + key
+		}
+
+		fromValue, inFrom := from[key]
+		toValue, inTo := to[key]
+		switch {
+		case !inTo:
+			*changes = append(*changes, models.ContentChange{Path: childPath, Type: "removed", OldValue: fromValue})
+		case !inFrom:
+			*changes = append(*changes, models.ContentChange{Path: childPath, Type: "added", NewValue: toValue})
+		default:
+			diffValue(childPath, fromValue, toValue, changes)
+		}
+	}
+}
+
+func diffLists(path string, from, to []interface{}, changes *[]models.ContentChange) {
+	for i := 0; i < len(from) || i < len(to); i++ {
+		childPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= len(to):
+			*changes = append(*changes, models.ContentChange{Path: childPath, Type: "removed", OldValue: from[i]})
+		case i >= len(from):
+			*changes = append(*changes, models.ContentChange{Path: childPath, Type: "added", NewValue: to[i]})
+		default:
+			diffValue(childPath, from[i], to[i], changes)
+		}
+	}
+}
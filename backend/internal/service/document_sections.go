@@ -0,0 +1,212 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+)
+
+var (
+	ErrInvalidSection  = errors.New("invalid section for this document type")
+	ErrSectionNotFound = errors.New("section not found in document content")
+)
+
+// regenerableSections lists the Content keys that can be regenerated per document type
+var regenerableSections = map[string][]string{
+	"resume":       {"summary", "experience", "skills"},
+	"cover_letter": {"opening", "body1", "body2", "closing"},
+}
+
+// sectionTarget locates one section inside Document.Content
+type sectionTarget struct {
+	label   string
+	current interface{}
+	set     func(value interface{})
+}
+
+// findSection resolves a regeneration target. For "experience" the index
+// selects the entry whose highlights are rewritten.
+func findSection(doc *models.Document, content map[string]interface{}, section string, index *int) (*sectionTarget, error) {
+	allowed := false
+	for _, name := range regenerableSections[doc.Type] {
+		if name == section {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, ErrInvalidSection
+	}
+
+	if section != "experience" {
+		current, ok := content[section]
+		if !ok {
+			return nil, ErrSectionNotFound
+		}
+		return &sectionTarget{
+			label:   section,
+			current: current,
+			set:     func(value interface{}) { content[section] = value },
+		}, nil
+	}
+
+	if index == nil {
+		return nil, fmt.Errorf("%w: index is required for experience", ErrSectionNotFound)
+	}
+
+	entries, ok := content["experience"].([]interface{})
+	if !ok || *index < 0 || *index >= len(entries) {
+		return nil, ErrSectionNotFound
+	}
+
+	entry, ok := entries[*index].(map[string]interface{})
+	if !ok {
+		return nil, ErrSectionNotFound
+	}
+
+	label := fmt.Sprintf("experience[%d].highlights", *index)
+	if position, _ := entry["position"].(string); position != "" {
+		company, _ := entry["company"].(string)
+		label = fmt.Sprintf("%s (highlights of %s at %s)", label, position, company)
+	}
+
+	return &sectionTarget{
+		label:   label,
+		current: entry["highlights"],
+		set:     func(value interface{}) { entry["highlights"] = value },
+	}, nil
+}
+
+// parseGeneratedContent turns the model's JSON answer into structured content,
+// falling back to raw_content when the answer isn't a JSON object
+func parseGeneratedContent(raw string) map[string]interface{} {
+	content, err := parseJSONObject(raw)
+	if err != nil {
+		return map[string]interface{}{
+			"raw_content": raw,
+		}
+	}
+	return content
+}
+
+// structuredContent returns the document's content as structured JSON,
+// upgrading older documents that only stored raw_content
+func structuredContent(content map[string]interface{}) map[string]interface{} {
+	raw, ok := content["raw_content"].(string)
+	if !ok || len(content) != 1 {
+		return content
+	}
+	if parsed, err := parseJSONObject(raw); err == nil {
+		return parsed
+	}
+	return content
+}
+
+// parseSectionResult extracts the rewritten section and checks that it has
+// the same JSON shape as the section it replaces
+func parseSectionResult(raw string, current interface{}) (interface{}, error) {
+	result, err := parseJSONObject(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := result["section"]
+	if !ok {
+		return nil, errors.New("model response has no section field")
+	}
+
+	if current != nil && jsonKind(value) != jsonKind(current) {
+		return nil, fmt.Errorf("model returned %s, expected %s", jsonKind(value), jsonKind(current))
+	}
+
+	return value, nil
+}
+
+// parseTranslation decodes a translated document and checks that it has the
+// same structure as the original: the same keys and the same number of
+// entries, with only the text changed
+func parseTranslation(raw string, original map[string]interface{}) (map[string]interface{}, error) {
+	translated, err := parseJSONObject(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sameShape("content", translated, original); err != nil {
+		return nil, err
+	}
+
+	return translated, nil
+}
+
+// sameShape reports where value differs in structure from want
+func sameShape(path string, value, want interface{}) error {
+	if jsonKind(value) != jsonKind(want) {
+		return fmt.Errorf("model returned %s for %s, expected %s", jsonKind(value), path, jsonKind(want))
+	}
+
+	switch want := want.(type) {
+	case map[string]interface{}:
+		object := value.(map[string]interface{})
+		if len(object) != len(want) {
+			return fmt.Errorf("model returned %d keys for %s, expected %d", len(object), path, len(want))
+		}
+		for key, w := range want {
+			v, ok := object[key]
+			if !ok {
+				return fmt.Errorf("model response has no %s.%s", path, key)
+			}
+			if err := sameShape(path+"."+key, v, w); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		array := value.([]interface{})
+		if len(array) != len(want) {
+			return fmt.Errorf("model returned %d entries for %s, expected %d", len(array), path, len(want))
+		}
+		for i := range want {
+			if err := sameShape(fmt.Sprintf("%s[%d]", path, i), array[i], want[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseJSONObject decodes a JSON object, tolerating markdown code fences
+// around it
+func parseJSONObject(raw string) (map[string]interface{}, error) {
+	text := strings.TrimSpace(raw)
+	if strings.HasPrefix(text, "```") {
+		text = strings.TrimPrefix(text, "```json")
+		text = strings.TrimPrefix(text, "```")
+		text = strings.TrimSuffix(strings.TrimSpace(text), "```")
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(text), &object); err != nil {
+		return nil, fmt.Errorf("failed to parse model response: %w", err)
+	}
+	return object, nil
+}
+
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+)
+
+func TestParseTranslation(t *testing.T) {
+	original := map[string]interface{}{
+		"summary": "Backend engineer",
+		"experience": []interface{}{
+			map[string]interface{}{"company": "Acme", "highlights": []interface{}{"Built the API", "Cut costs"}},
+		},
+	}
+
+	tests := []struct {
+		name string
+		raw  string
+		want string // part of the error, empty for success
+	}{
+		{"translated", `{"summary": "Backend-Entwickler", "experience": [{"company": "Acme", "highlights": ["Die API gebaut", "Kosten gesenkt"]}]}`, ""},
+		{"code fence", "```json\n{\"summary\": \"x\", \"experience\": [{\"company\": \"Acme\", \"highlights\": [\"a\", \"b\"]}]}\n```", ""},
+		{"not JSON", `Hier ist die Übersetzung`, "failed to parse"},
+		{"translated key", `{"zusammenfassung": "x", "experience": [{"company": "Acme", "highlights": ["a", "b"]}]}`, "no content.summary"},
+		{"dropped entry", `{"summary": "x", "experience": [{"company": "Acme", "highlights": ["a"]}]}`, "content.experience[0].highlights"},
+		{"extra key", `{"summary": "x", "skills": [], "experience": [{"company": "Acme", "highlights": ["a", "b"]}]}`, "3 keys"},
+		{"wrong type", `{"summary": ["x"], "experience": [{"company": "Acme", "highlights": ["a", "b"]}]}`, "array for content.summary"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseTranslation(tt.raw, original)
+			if tt.want == "" && err != nil {
+				t.Errorf("parseTranslation: %v", err)
+			}
+			if tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)) {
+				t.Errorf("parseTranslation = %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindSection(t *testing.T) {
+	resume := &models.Document{Type: "resume"}
+	coverLetter := &models.Document{Type: "cover_letter"}
+	index := func(i int) *int { return &i }
+
+	newContent := func() map[string]interface{} {
+		return map[string]interface{}{
+			"summary": "Backend engineer",
+			"experience": []interface{}{
+				map[string]interface{}{"company": "Acme", "position": "Engineer", "highlights": []interface{}{"Built the API"}},
+			},
+			"opening": "Dear team",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		doc     *models.Document
+		section string
+		index   *int
+		label   string
+		err     error
+	}{
+		{"summary", resume, "summary", nil, "summary", nil},
+		{"experience entry", resume, "experience", index(0), "experience[0].highlights (highlights of Engineer at Acme)", nil},
+		{"cover letter opening", coverLetter, "opening", nil, "opening", nil},
+		{"not regenerable", resume, "education", nil, "", ErrInvalidSection},
+		{"other document type", coverLetter, "summary", nil, "", ErrInvalidSection},
+		{"missing section", resume, "skills", nil, "", ErrSectionNotFound},
+		{"experience without index", resume, "experience", nil, "", ErrSectionNotFound},
+		{"index out of range", resume, "experience", index(1), "", ErrSectionNotFound},
+		{"negative index", resume, "experience", index(-1), "", ErrSectionNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := findSection(tt.doc, newContent(), tt.section, tt.index)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("findSection error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("findSection: %v", err)
+			}
+			if target.label != tt.label {
+				t.Errorf("label = %q, want %q", target.label, tt.label)
+			}
+		})
+	}
+
+	// set patches the value into the content
+	content := newContent()
+	target, _ := findSection(resume, content, "experience", index(0))
+	target.set([]interface{}{"Rebuilt the API"})
+	entry := content["experience"].([]interface{})[0].(map[string]interface{})
+	if highlights := entry["highlights"].([]interface{}); len(highlights) != 1 || highlights[0] != "Rebuilt the API" {
+		t.Errorf("highlights after set = %v", entry["highlights"])
+	}
+}
+
+func TestParseSectionResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		current interface{}
+		want    interface{}
+		err     string
+	}{
+		{"string", `{"section": "New summary"}`, "Old summary", "New summary", ""},
+		{"code fence", "```json\n{\"section\": \"New summary\"}\n```", "Old summary", "New summary", ""},
+		{"array", `{"section": ["a", "b"]}`, []interface{}{"c"}, []interface{}{"a", "b"}, ""},
+		{"no current value", `{"section": "Anything"}`, nil, "Anything", ""},
+		{"no section field", `{"summary": "x"}`, "Old", nil, "no section field"},
+		{"wrong shape", `{"section": "a, b"}`, []interface{}{"c"}, nil, "returned string, expected array"},
+		{"not JSON", `Here is the section`, "Old", nil, "failed to parse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSectionResult(tt.raw, tt.current)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("parseSectionResult error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSectionResult: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSectionResult = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,65 +1,131 @@
 package service
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/feijoa-master/ai-resume-builder/internal/conventions"
+	"github.com/feijoa-master/ai-resume-builder/internal/experiments"
+	"github.com/feijoa-master/ai-resume-builder/internal/i18n"
 	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/pricing"
+	"github.com/feijoa-master/ai-resume-builder/internal/prompts"
 	"github.com/feijoa-master/ai-resume-builder/internal/repository"
 	"github.com/google/uuid"
 )
 
 var (
-	ErrNoFreeGenerationsLeft = errors.New("no free generations left")
-	ErrInvalidDocumentType   = errors.New("invalid document type")
+	ErrNoFreeGenerationsLeft   = errors.New("no free generations left")
+	ErrNoFreeRegenerationsLeft = errors.New("no free section regenerations left")
+	ErrInvalidDocumentType     = errors.New("invalid document type")
+	ErrInvalidScoreSource      = errors.New("source must be document or profile")
+	ErrInvalidCustomSection    = errors.New("custom sections must be projects, certifications, publications, languages, volunteering or awards")
+	ErrSameLanguage            = errors.New("document is already in that language")
 )
 
+// maxRefinementHistory is how many earlier chat messages are replayed to the model
+const maxRefinementHistory = 10
+
 type DocumentService struct {
-	documentRepo  *repository.DocumentRepository
-	profileRepo   *repository.ProfileRepository
-	userRepo      *repository.UserRepository
-	openaiService *OpenAIService
+	documentRepo   *repository.DocumentRepository
+	profileRepo    *repository.ProfileRepository
+	userRepo       *repository.UserRepository
+	jobPostingRepo *repository.JobPostingRepository
+	experimentRepo *repository.ExperimentRepository
+	openaiService  *OpenAIService
+	experiments    *experiments.Set
+	prices         *pricing.Table
 }
 
 func NewDocumentService(
 	documentRepo *repository.DocumentRepository,
 	profileRepo *repository.ProfileRepository,
 	userRepo *repository.UserRepository,
+	jobPostingRepo *repository.JobPostingRepository,
+	experimentRepo *repository.ExperimentRepository,
 	openaiService *OpenAIService,
+	experimentSet *experiments.Set,
+	prices *pricing.Table,
 ) *DocumentService {
 	return &DocumentService{
-		documentRepo:  documentRepo,
-		profileRepo:   profileRepo,
-		userRepo:      userRepo,
-		openaiService: openaiService,
+		documentRepo:   documentRepo,
+		profileRepo:    profileRepo,
+		userRepo:       userRepo,
+		jobPostingRepo: jobPostingRepo,
+		experimentRepo: experimentRepo,
+		openaiService:  openaiService,
+		experiments:    experimentSet,
+		prices:         prices,
 	}
 }
 
 // GenerateDocument generates a resume or cover letter
 func (s *DocumentService) GenerateDocument(userID uuid.UUID, req *models.GenerateRequest) (*models.Document, error) {
+	language, err := i18n.Normalize(req.Language)
+	if err != nil {
+		return nil, err
+	}
+	convention, err := conventions.Get(req.Convention)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if user has free generations left
-	user, err := s.userRepo.GetUserByID(userID)
+	user, err := s.checkQuota(userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user: %w", err)
+		return nil, err
 	}
 
-	if !user.IsPremium && user.FreeGenerationsLeft <= 0 {
-		return nil, ErrNoFreeGenerationsLeft
+	// Take the job from the saved posting if one was given, else from the pasted text
+	if req.JobPostingID != nil {
+		posting, err := s.jobPostingRepo.GetJobPostingByID(*req.JobPostingID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get job posting: %w", err)
+		}
+		req.JobDescription = posting.Description
+		if req.JobTitle == "" {
+			req.JobTitle = posting.Title
+		}
+		if req.CompanyName == "" {
+			req.CompanyName = posting.CompanyName
+		}
+	} else {
+		// Strip job board boilerplate from a pasted posting
+		parsed := ParseJobPosting(req.JobDescription)
+		if parsed.Description != "" {
+			req.JobDescription = parsed.Description
+		}
+		if req.JobTitle == "" {
+			req.JobTitle = parsed.Title
+		}
+		if req.CompanyName == "" {
+			req.CompanyName = parsed.CompanyName
+		}
 	}
 
-	// Get user profile data
-	profileData, err := s.getProfileData(userID)
+	// Get data of the chosen profile
+	profileData, err := s.getProfileData(userID, req.ProfileID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get profile data: %w", err)
 	}
+	if err := selectProfileSections(profileData, req.CustomSections); err != nil {
+		return nil, err
+	}
+
+	// Extract requirements to steer the prompt and later scoring
+	analysis := AnalyzeJobDescription(req.JobTitle, req.JobDescription)
 
-	// Generate document using OpenAI
+	// Generate document using OpenAI, with the user's experiment variant if
+	// one runs on the document type
+	opts := PromptOptions{TemplateID: req.TemplateID, Locale: language, Convention: convention.ID, Experiment: s.experiments.Assign(req.Type, userID), Fresh: req.Fresh}
 	var generated *GeneratedDocument
 	switch req.Type {
 	case "resume":
-		generated, err = s.openaiService.GenerateResume(profileData, req.JobDescription)
+		generated, err = s.openaiService.GenerateResume(profileData, req.JobDescription, analysis, opts)
 	case "cover_letter":
-		generated, err = s.openaiService.GenerateCoverLetter(profileData, req.JobDescription, req.CompanyName)
+		generated, err = s.openaiService.GenerateCoverLetter(profileData, req.JobDescription, req.CompanyName, analysis, opts)
 	default:
 		return nil, ErrInvalidDocumentType
 	}
@@ -68,16 +134,23 @@ func (s *DocumentService) GenerateDocument(userID uuid.UUID, req *models.Generat
 		return nil, fmt.Errorf("failed to generate document: %w", err)
 	}
 
+	// The same request answered before: hand back its document
+	if doc := s.cachedDocument(userID, generated); doc != nil {
+		return doc, nil
+	}
+
 	// Parse generated content
-	var content map[string]interface{}
-	content = map[string]interface{}{
-		"raw_content": generated.Content,
+	content := parseGeneratedContent(generated.Content)
+	if req.Type == "resume" {
+		applyConvention(content, profileData, language, convention)
 	}
 
 	// Create document record
 	doc := &models.Document{
 		ID:             uuid.New(),
 		UserID:         userID,
+		ProfileID:      &profileData.ProfileID,
+		JobPostingID:   req.JobPostingID,
 		Type:           req.Type,
 		Title:          s.generateTitle(req),
 		Content:        content,
@@ -85,6 +158,9 @@ func (s *DocumentService) GenerateDocument(userID uuid.UUID, req *models.Generat
 		JobTitle:       req.JobTitle,
 		CompanyName:    req.CompanyName,
 		JobDescription: req.JobDescription,
+		JobAnalysis:    analysis,
+		Language:       language,
+		Convention:     convention.ID,
 		Status:         "final",
 	}
 
@@ -93,31 +169,375 @@ func (s *DocumentService) GenerateDocument(userID uuid.UUID, req *models.Generat
 	}
 
 	// Save generation history
-	cost := s.calculateCost(generated.PromptTokens, generated.CompletionTokens)
-	history := &models.GenerationHistory{
-		ID:               uuid.New(),
-		UserID:           userID,
-		DocumentID:       doc.ID,
-		PromptTokens:     generated.PromptTokens,
-		CompletionTokens: generated.CompletionTokens,
-		TotalCost:        cost,
-		GenerationTimeMs: generated.GenerationTimeMs,
+	s.recordGeneration(userID, &doc.ID, generated)
+
+	// Decrement free generations if not premium
+	s.chargeGeneration(user, generated)
+
+	return doc, nil
+}
+
+// AnalyzeJob extracts structured requirements from a job description. With
+// Enrich set the LLM adds to the deterministic result and the call is recorded
+// in generation history, against the document if DocumentID is set; with
+// DocumentID set the analysis is also stored on that document.
+func (s *DocumentService) AnalyzeJob(userID uuid.UUID, req *models.AnalyzeJobRequest) (*models.JobAnalysis, error) {
+	if req.DocumentID != nil {
+		// Make sure the document exists and belongs to the user
+		if _, err := s.documentRepo.GetDocumentByID(*req.DocumentID, userID); err != nil {
+			return nil, err
+		}
 	}
 
-	if err := s.documentRepo.CreateGenerationHistory(history); err != nil {
-		// Log error but don't fail the request
-		fmt.Printf("Failed to save generation history: %v\n", err)
+	analysis := AnalyzeJobDescription(req.JobTitle, req.JobDescription)
+
+	if req.Enrich {
+		// Enriching calls the model, so it takes a generation like the rest
+		user, err := s.checkQuota(userID)
+		if err != nil {
+			return nil, err
+		}
+
+		opts := PromptOptions{Experiment: s.experiments.Assign(prompts.AnalyzeJob, userID)}
+		generated, err := s.openaiService.AnalyzeJobDescription(req.JobTitle, req.JobDescription, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		var enrichment models.JobAnalysis
+		object, err := parseJSONObject(generated.Content)
+		if err == nil {
+			raw, _ := json.Marshal(object)
+			err = json.Unmarshal(raw, &enrichment)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse job analysis: %w", err)
+		}
+		mergeJobAnalysis(analysis, &enrichment)
+
+		s.recordGeneration(userID, req.DocumentID, generated)
+		s.chargeGeneration(user, generated)
 	}
 
-	// Decrement free generations if not premium
-	if !user.IsPremium {
-		if err := s.userRepo.DecrementFreeGenerations(userID); err != nil {
-			// Log error but don't fail since document is already created
-			fmt.Printf("Failed to decrement free generations: %v\n", err)
+	if req.DocumentID != nil {
+		if err := s.documentRepo.UpdateDocumentJobAnalysis(*req.DocumentID, userID, analysis); err != nil {
+			return nil, fmt.Errorf("failed to save job analysis: %w", err)
 		}
 	}
 
-	return doc, nil
+	return analysis, nil
+}
+
+// ScoreDocument computes an ATS match score for a document, or for the raw
+// profile, against the document's job description. No LLM is involved.
+func (s *DocumentService) ScoreDocument(userID, docID uuid.UUID, req *models.ATSScoreRequest) (*models.ATSScore, error) {
+	doc, err := s.documentRepo.GetDocumentByID(docID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prefer the stored analysis unless the caller supplies another posting
+	analysis := doc.JobAnalysis
+	if req.JobDescription != "" {
+		analysis = AnalyzeJobDescription(doc.JobTitle, req.JobDescription)
+	} else if analysis == nil {
+		analysis = AnalyzeJobDescription(doc.JobTitle, doc.JobDescription)
+	}
+
+	var input atsInput
+	switch req.Source {
+	case "", "document":
+		content := structuredContent(doc.Content)
+		input = atsInput{
+			docType:  doc.Type,
+			source:   "document",
+			text:     contentText(content),
+			sections: contentSections(content),
+		}
+	case "profile":
+		profileData, err := s.getProfileData(userID, doc.ProfileID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get profile data: %w", err)
+		}
+		input = profileATSInput(profileData)
+	default:
+		return nil, ErrInvalidScoreSource
+	}
+
+	return ScoreATS(input, analysis), nil
+}
+
+// SkillGap compares the user's skills with a job description, or with the
+// job a document was generated for. No LLM is involved.
+func (s *DocumentService) SkillGap(userID uuid.UUID, req *models.SkillGapRequest) (*models.SkillGapReport, error) {
+	jobTitle := req.JobTitle
+	var analysis *models.JobAnalysis
+	var profileID *uuid.UUID
+	if req.DocumentID != nil {
+		doc, err := s.documentRepo.GetDocumentByID(*req.DocumentID, userID)
+		if err != nil {
+			return nil, err
+		}
+		profileID = doc.ProfileID
+		if jobTitle == "" {
+			jobTitle = doc.JobTitle
+		}
+		// Prefer the stored analysis unless the caller supplies another posting
+		analysis = doc.JobAnalysis
+		if analysis == nil && req.JobDescription == "" {
+			analysis = AnalyzeJobDescription(jobTitle, doc.JobDescription)
+		}
+	}
+	if req.JobDescription != "" {
+		analysis = AnalyzeJobDescription(jobTitle, req.JobDescription)
+	}
+
+	profileData, err := s.getProfileData(userID, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile data: %w", err)
+	}
+
+	report := AnalyzeSkillGap(profileData, analysis)
+	report.JobTitle = jobTitle
+	return report, nil
+}
+
+// RegenerateSection rewrites a single section of a document and patches it
+// into the structured content. The previous content is kept as a version.
+// Like any model call it uses up a free generation.
+func (s *DocumentService) RegenerateSection(userID, docID uuid.UUID, req *models.RegenerateSectionRequest) (*models.Document, error) {
+	doc, err := s.documentRepo.GetDocumentByID(docID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.checkRegenerationQuota(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	content := structuredContent(doc.Content)
+	target, err := findSection(doc, content, req.Section, req.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	profileData, err := s.getProfileData(userID, doc.ProfileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile data: %w", err)
+	}
+
+	opts := PromptOptions{TemplateID: doc.TemplateID, Locale: doc.Language, Convention: doc.Convention, Experiment: s.experiments.Assign(prompts.Section, userID), Fresh: req.Fresh}
+	generated, err := s.openaiService.RegenerateSection(profileData, doc.JobDescription, doc.Type, content, target.label, target.current, req.Instructions, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to regenerate section: %w", err)
+	}
+
+	value, err := parseSectionResult(generated.Content, target.current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to regenerate section: %w", err)
+	}
+	target.set(value)
+	if doc.Type == "resume" {
+		applyConvention(content, profileData, doc.Language, conventions.Lookup(doc.Convention))
+	}
+
+	if err := s.documentRepo.UpdateDocument(&models.Document{ID: docID, UserID: userID, Content: content}); err != nil {
+		return nil, fmt.Errorf("failed to save document: %w", err)
+	}
+
+	s.recordGeneration(userID, &docID, generated)
+	s.chargeRegeneration(user, generated)
+	recordSignal(s.experimentRepo, userID, docID, models.SignalRegenerated)
+
+	return s.documentRepo.GetDocumentByID(docID, userID)
+}
+
+// RefineDocument applies a chat instruction to the whole document. Both chat
+// turns are stored once the model has answered, so a failed call leaves no
+// unanswered instruction in the conversation. The previous content is kept as
+// a version, the model call is recorded in generation history and uses up a
+// free generation.
+func (s *DocumentService) RefineDocument(userID, docID uuid.UUID, message string) (*models.RefineDocumentResponse, error) {
+	doc, err := s.documentRepo.GetDocumentByID(docID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.checkQuota(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.documentRepo.GetDocumentMessages(docID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat history: %w", err)
+	}
+	if len(history) > maxRefinementHistory {
+		history = history[len(history)-maxRefinementHistory:]
+	}
+
+	profileData, err := s.getProfileData(userID, doc.ProfileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile data: %w", err)
+	}
+
+	content := structuredContent(doc.Content)
+	opts := PromptOptions{TemplateID: doc.TemplateID, Locale: doc.Language, Convention: doc.Convention, Experiment: s.experiments.Assign(prompts.Refine, userID)}
+	generated, err := s.openaiService.RefineDocument(profileData, doc.JobDescription, doc.Type, content, history, message, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := parseJSONObject(generated.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refine document: %w", err)
+	}
+	refined, ok := result["content"].(map[string]interface{})
+	if !ok || len(refined) == 0 {
+		return nil, errors.New("failed to refine document: model response has no content")
+	}
+	if doc.Type == "resume" {
+		applyConvention(refined, profileData, doc.Language, conventions.Lookup(doc.Convention))
+	}
+	reply, _ := result["reply"].(string)
+	if reply == "" {
+		reply = "Document updated."
+	}
+
+	if err := s.documentRepo.UpdateDocument(&models.Document{ID: docID, UserID: userID, Content: refined}); err != nil {
+		return nil, fmt.Errorf("failed to save document: %w", err)
+	}
+
+	userMsg := &models.DocumentMessage{
+		ID:         uuid.New(),
+		DocumentID: docID,
+		Role:       "user",
+		Content:    message,
+	}
+	assistantMsg := &models.DocumentMessage{
+		ID:         uuid.New(),
+		DocumentID: docID,
+		Role:       "assistant",
+		Content:    reply,
+	}
+	if generation := s.recordGeneration(userID, &docID, generated); generation != nil {
+		assistantMsg.GenerationID = &generation.ID
+	}
+	s.chargeGeneration(user, generated)
+	for _, msg := range []*models.DocumentMessage{userMsg, assistantMsg} {
+		if err := s.documentRepo.CreateDocumentMessage(msg); err != nil {
+			return nil, fmt.Errorf("failed to save message: %w", err)
+		}
+	}
+	recordSignal(s.experimentRepo, userID, docID, models.SignalRegenerated)
+
+	updated, err := s.documentRepo.GetDocumentByID(docID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RefineDocumentResponse{
+		Messages: []*models.DocumentMessage{userMsg, assistantMsg},
+		Document: updated,
+	}, nil
+}
+
+// TranslateDocument saves a copy of a document translated into another
+// language, linked to the original by SourceID. It uses up a free generation
+// like generating a new document.
+func (s *DocumentService) TranslateDocument(userID, docID uuid.UUID, req *models.TranslateDocumentRequest) (*models.Document, error) {
+	language, err := i18n.Normalize(req.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := s.documentRepo.GetDocumentByID(docID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := doc.Language
+	if current == "" {
+		current = i18n.Default
+	}
+	if language == current {
+		return nil, ErrSameLanguage
+	}
+
+	user, err := s.checkQuota(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	content := structuredContent(doc.Content)
+	opts := PromptOptions{TemplateID: doc.TemplateID, Locale: language, Convention: doc.Convention, Experiment: s.experiments.Assign(prompts.Translate, userID)}
+	generated, err := s.openaiService.TranslateDocument(doc.Type, content, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if translation := s.cachedDocument(userID, generated); translation != nil {
+		return translation, nil
+	}
+
+	translated, err := parseTranslation(generated.Content, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate document: %w", err)
+	}
+
+	// Periods come from the profile, so they are formatted rather than
+	// translated. Translated entries are matched to the profile through the
+	// original ones, since the translation keeps their order.
+	if doc.Type == "resume" && doc.ProfileID != nil {
+		if profileData, err := s.getProfileData(userID, doc.ProfileID); err == nil {
+			applyConventionFrom(translated, content, profileData, language, conventions.Lookup(doc.Convention))
+		}
+	}
+
+	translation := &models.Document{
+		ID:             uuid.New(),
+		UserID:         userID,
+		ProfileID:      doc.ProfileID,
+		JobPostingID:   doc.JobPostingID,
+		SourceID:       &doc.ID,
+		Type:           doc.Type,
+		Title:          fmt.Sprintf("%s (%s)", doc.Title, i18n.Name(language)),
+		Content:        translated,
+		TemplateID:     doc.TemplateID,
+		JobTitle:       doc.JobTitle,
+		CompanyName:    doc.CompanyName,
+		JobDescription: doc.JobDescription,
+		JobAnalysis:    doc.JobAnalysis,
+		Language:       language,
+		Convention:     doc.Convention,
+		Status:         "final",
+	}
+
+	if err := s.documentRepo.CreateDocument(translation); err != nil {
+		return nil, fmt.Errorf("failed to save document: %w", err)
+	}
+
+	s.recordGeneration(userID, &translation.ID, generated)
+
+	s.chargeGeneration(user, generated)
+
+	return translation, nil
+}
+
+// ListConventions lists the regional resume conventions documents can follow
+func (s *DocumentService) ListConventions() []*conventions.Convention {
+	return conventions.List()
+}
+
+// GetDocumentMessages retrieves a document's refinement chat
+func (s *DocumentService) GetDocumentMessages(userID, docID uuid.UUID) ([]*models.DocumentMessage, error) {
+	// Make sure the document exists and belongs to the user
+	if _, err := s.documentRepo.GetDocumentByID(docID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.documentRepo.GetDocumentMessages(docID, userID)
 }
 
 // GetDocument retrieves a document by ID
@@ -130,10 +550,18 @@ func (s *DocumentService) GetDocuments(userID uuid.UUID) ([]*models.Document, er
 	return s.documentRepo.GetDocuments(userID)
 }
 
-// UpdateDocument updates a document
+// UpdateDocument updates a document. Changing the title or content counts
+// as an edit for experiment reports.
 func (s *DocumentService) UpdateDocument(userID uuid.UUID, doc *models.Document) error {
 	doc.UserID = userID
-	return s.documentRepo.UpdateDocument(doc)
+	if err := s.documentRepo.UpdateDocument(doc); err != nil {
+		return err
+	}
+
+	if doc.Title != "" || doc.Content != nil {
+		recordSignal(s.experimentRepo, userID, doc.ID, models.SignalEdited)
+	}
+	return nil
 }
 
 // DeleteDocument deletes a document
@@ -141,8 +569,83 @@ func (s *DocumentService) DeleteDocument(userID, docID uuid.UUID) error {
 	return s.documentRepo.DeleteDocument(docID, userID)
 }
 
-// getProfileData gathers all profile data for generation
-func (s *DocumentService) getProfileData(userID uuid.UUID) (*ProfileData, error) {
+// GetDocumentVersions lists the saved versions of a document
+func (s *DocumentService) GetDocumentVersions(userID, docID uuid.UUID) ([]*models.DocumentVersion, error) {
+	// Make sure the document exists and belongs to the user
+	if _, err := s.documentRepo.GetDocumentByID(docID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.documentRepo.GetDocumentVersions(docID, userID)
+}
+
+// GetDocumentVersion retrieves a single saved version of a document
+func (s *DocumentService) GetDocumentVersion(userID, docID uuid.UUID, version int) (*models.DocumentVersion, error) {
+	return s.documentRepo.GetDocumentVersion(docID, userID, version)
+}
+
+// DiffDocumentVersions compares two versions of a document.
+// A toVersion of 0 compares against the current document.
+func (s *DocumentService) DiffDocumentVersions(userID, docID uuid.UUID, fromVersion, toVersion int) (*models.DocumentDiff, error) {
+	from, err := s.documentRepo.GetDocumentVersion(docID, userID, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var toTitle string
+	var toContent map[string]interface{}
+	if toVersion == 0 {
+		doc, err := s.documentRepo.GetDocumentByID(docID, userID)
+		if err != nil {
+			return nil, err
+		}
+		toTitle, toContent = doc.Title, doc.Content
+	} else {
+		to, err := s.documentRepo.GetDocumentVersion(docID, userID, toVersion)
+		if err != nil {
+			return nil, err
+		}
+		toTitle, toContent = to.Title, to.Content
+	}
+
+	diff := &models.DocumentDiff{
+		FromVersion: fromVersion,
+		ToVersion:   toVersion,
+		Changes:     DiffContent(from.Content, toContent),
+	}
+	if from.Title != toTitle {
+		diff.TitleFrom = from.Title
+		diff.TitleTo = toTitle
+	}
+
+	return diff, nil
+}
+
+// RestoreDocumentVersion makes an old version the current document.
+// The current content is snapshotted first, so a restore can itself be undone.
+func (s *DocumentService) RestoreDocumentVersion(userID, docID uuid.UUID, version int) (*models.Document, error) {
+	old, err := s.documentRepo.GetDocumentVersion(docID, userID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.documentRepo.UpdateDocument(&models.Document{
+		ID:      docID,
+		UserID:  userID,
+		Title:   old.Title,
+		Content: old.Content,
+		Status:  old.Status,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore version: %w", err)
+	}
+
+	return s.documentRepo.GetDocumentByID(docID, userID)
+}
+
+// getProfileData gathers all data of one of the user's profiles for
+// generation; profileID nil means the default profile
+func (s *DocumentService) getProfileData(userID uuid.UUID, profileID *uuid.UUID) (*ProfileData, error) {
 	// Get user
 	user, err := s.userRepo.GetUserByID(userID)
 	if err != nil {
@@ -150,7 +653,12 @@ func (s *DocumentService) getProfileData(userID uuid.UUID) (*ProfileData, error)
 	}
 
 	// Get profile
-	profile, err := s.profileRepo.GetProfileByUserID(userID)
+	var profile *models.Profile
+	if profileID != nil {
+		profile, err = s.profileRepo.GetProfileByID(*profileID, userID)
+	} else {
+		profile, err = s.profileRepo.GetProfileByUserID(userID)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -173,7 +681,8 @@ func (s *DocumentService) getProfileData(userID uuid.UUID) (*ProfileData, error)
 		return nil, err
 	}
 
-	return &ProfileData{
+	data := &ProfileData{
+		ProfileID:   profile.ID,
 		FullName:    user.FullName,
 		Email:       user.Email,
 		Phone:       profile.Phone,
@@ -182,10 +691,189 @@ func (s *DocumentService) getProfileData(userID uuid.UUID) (*ProfileData, error)
 		GithubURL:   profile.GithubURL,
 		WebsiteURL:  profile.WebsiteURL,
 		Summary:     profile.Summary,
+		DateOfBirth: profile.DateOfBirth,
+		Nationality: profile.Nationality,
+		PhotoURL:    profile.PhotoURL,
 		Experiences: experiences,
 		Education:   education,
 		Skills:      skills,
-	}, nil
+	}
+
+	// Get optional sections
+	if data.Projects, err = s.profileRepo.GetProjects(profile.ID); err != nil {
+		return nil, err
+	}
+	if data.Certifications, err = s.profileRepo.GetCertifications(profile.ID); err != nil {
+		return nil, err
+	}
+	if data.Publications, err = s.profileRepo.GetPublications(profile.ID); err != nil {
+		return nil, err
+	}
+	if data.Languages, err = s.profileRepo.GetLanguages(profile.ID); err != nil {
+		return nil, err
+	}
+	if data.Volunteering, err = s.profileRepo.GetVolunteering(profile.ID); err != nil {
+		return nil, err
+	}
+	if data.Awards, err = s.profileRepo.GetAwards(profile.ID); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// selectProfileSections drops the optional sections not listed in sections.
+// No sections at all keeps every optional section.
+func selectProfileSections(profile *ProfileData, sections []string) error {
+	if len(sections) == 0 {
+		return nil
+	}
+
+	selected := map[string]bool{}
+	for _, name := range sections {
+		name = strings.ToLower(strings.TrimSpace(name))
+		known := false
+		for _, section := range optionalSectionFormats {
+			if section.name == name {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("%w: %q", ErrInvalidCustomSection, name)
+		}
+		selected[name] = true
+	}
+
+	if !selected["projects"] {
+		profile.Projects = nil
+	}
+	if !selected["certifications"] {
+		profile.Certifications = nil
+	}
+	if !selected["publications"] {
+		profile.Publications = nil
+	}
+	if !selected["languages"] {
+		profile.Languages = nil
+	}
+	if !selected["volunteering"] {
+		profile.Volunteering = nil
+	}
+	if !selected["awards"] {
+		profile.Awards = nil
+	}
+
+	return nil
+}
+
+// recordGeneration saves token usage and cost for a model call.
+// Failures are logged only, the generated content is already saved, in which
+// case nil is returned.
+func (s *DocumentService) recordGeneration(userID uuid.UUID, docID *uuid.UUID, generated *GeneratedDocument) *models.GenerationHistory {
+	cost := s.calculateCost(generated)
+	history := &models.GenerationHistory{
+		ID:               uuid.New(),
+		UserID:           userID,
+		DocumentID:       docID,
+		PromptTokens:     generated.PromptTokens,
+		CompletionTokens: generated.CompletionTokens,
+		CachedTokens:     generated.CachedTokens,
+		CacheKey:         generated.CacheKey,
+		TotalCost:        cost,
+		GenerationTimeMs: generated.GenerationTimeMs,
+		PromptVersion:    generated.PromptVersion,
+		Provider:         generated.Provider,
+		Model:            generated.Model,
+		Experiment:       generated.Experiment,
+		Variant:          generated.Variant,
+		Cached:           generated.Cached,
+	}
+
+	if err := s.documentRepo.CreateGenerationHistory(history); err != nil {
+		// Log error but don't fail the request
+		fmt.Printf("Failed to save generation history: %v\n", err)
+		return nil
+	}
+
+	return history
+}
+
+// checkQuota returns the user if they may make a model call: premium users
+// always, others while they have free generations left
+func (s *DocumentService) checkQuota(userID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if !user.IsPremium && user.FreeGenerationsLeft <= 0 {
+		return nil, ErrNoFreeGenerationsLeft
+	}
+
+	return user, nil
+}
+
+// checkRegenerationQuota loads the user and checks that they may regenerate
+// a section. Section regenerations have their own free allowance, so
+// rewriting one section doesn't cost a whole free generation.
+func (s *DocumentService) checkRegenerationQuota(userID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if !user.IsPremium && user.FreeRegenerationsLeft <= 0 {
+		return nil, ErrNoFreeRegenerationsLeft
+	}
+
+	return user, nil
+}
+
+// cachedDocument returns the document saved from an earlier generation with
+// the same response, when generated came from the response cache and that
+// document still exists unedited, or nil. The reuse is recorded in generation
+// history at no cost and isn't charged. On nil the caller saves a new
+// document from the cached response, which isn't charged either.
+func (s *DocumentService) cachedDocument(userID uuid.UUID, generated *GeneratedDocument) *models.Document {
+	if !generated.Cached || generated.CacheKey == "" {
+		return nil
+	}
+
+	doc, err := s.documentRepo.GetDocumentByCacheKey(userID, generated.CacheKey)
+	if err != nil {
+		if !errors.Is(err, repository.ErrUserNotFound) {
+			fmt.Printf("Failed to get cached document: %v\n", err)
+		}
+		return nil
+	}
+
+	s.recordGeneration(userID, &doc.ID, generated)
+	return doc
+}
+
+// chargeGeneration uses up a free generation of a user who isn't premium.
+// Responses from the cache didn't call the model and are free.
+func (s *DocumentService) chargeGeneration(user *models.User, generated *GeneratedDocument) {
+	if user.IsPremium || generated.Cached {
+		return
+	}
+	if err := s.userRepo.DecrementFreeGenerations(user.ID); err != nil {
+		// Log error but don't fail since the result is already saved
+		fmt.Printf("Failed to decrement free generations: %v\n", err)
+	}
+}
+
+// chargeRegeneration uses up a free section regeneration of a user who isn't
+// premium; like chargeGeneration, cached responses are free
+func (s *DocumentService) chargeRegeneration(user *models.User, generated *GeneratedDocument) {
+	if user.IsPremium || generated.Cached {
+		return
+	}
+	if err := s.userRepo.DecrementFreeRegenerations(user.ID); err != nil {
+		// Log error but don't fail since the result is already saved
+		fmt.Printf("Failed to decrement free regenerations: %v\n", err)
+	}
 }
 
 // generateTitle creates a title for the document
@@ -205,12 +893,22 @@ func (s *DocumentService) generateTitle(req *models.GenerateRequest) string {
 	return "Cover Letter"
 }
 
-// calculateCost estimates the cost of generation (simplified)
-// Real pricing: https://openai.com/pricing
-func (s *DocumentService) calculateCost(promptTokens, completionTokens int) float64 {
-	// Example pricing for gpt-4o-mini (as of 2024):
-	// $0.150 per 1M input tokens, $0.600 per 1M output tokens
-	inputCost := float64(promptTokens) * 0.00000015
-	outputCost := float64(completionTokens) * 0.0000006
-	return inputCost + outputCost
+// calculateCost prices a generation from the pricing table; a model without
+// a price is logged and recorded at no cost
+func (s *DocumentService) calculateCost(generated *GeneratedDocument) float64 {
+	if generated.Cached {
+		return 0
+	}
+
+	price, err := s.prices.Lookup(generated.Provider, generated.Model)
+	if err != nil {
+		fmt.Printf("Failed to price generation: %v\n", err)
+		return 0
+	}
+
+	return price.Cost(pricing.Usage{
+		PromptTokens:     generated.PromptTokens,
+		CachedTokens:     generated.CachedTokens,
+		CompletionTokens: generated.CompletionTokens,
+	})
 }
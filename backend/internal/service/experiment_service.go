@@ -0,0 +1,120 @@
+package service
+
+import (
+	"errors"
+	"log"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/experiments"
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/repository"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidSignal = errors.New("signal must be downloaded, thumbs_up or thumbs_down")
+
+// clientSignals are the signals users may report; edited and regenerated are
+// recorded by the document service itself
+var clientSignals = map[string]bool{
+	models.SignalDownloaded: true,
+	models.SignalThumbsUp:   true,
+	models.SignalThumbsDown: true,
+}
+
+type ExperimentService struct {
+	experimentRepo *repository.ExperimentRepository
+	documentRepo   *repository.DocumentRepository
+	experiments    *experiments.Set
+}
+
+func NewExperimentService(experimentRepo *repository.ExperimentRepository, documentRepo *repository.DocumentRepository, set *experiments.Set) *ExperimentService {
+	return &ExperimentService{
+		experimentRepo: experimentRepo,
+		documentRepo:   documentRepo,
+		experiments:    set,
+	}
+}
+
+// RecordSignal stores a signal the client reports on one of the user's documents
+func (s *ExperimentService) RecordSignal(userID, docID uuid.UUID, signal string) error {
+	if !clientSignals[signal] {
+		return ErrInvalidSignal
+	}
+
+	// Make sure the document exists and belongs to the user
+	if _, err := s.documentRepo.GetDocumentByID(docID, userID); err != nil {
+		return err
+	}
+
+	return s.experimentRepo.CreateDocumentSignal(&models.DocumentSignal{
+		ID:         uuid.New(),
+		DocumentID: docID,
+		UserID:     userID,
+		Signal:     signal,
+	})
+}
+
+// ListExperiments returns the experiment definitions
+func (s *ExperimentService) ListExperiments() []*experiments.Experiment {
+	return s.experiments.List()
+}
+
+// GetExperimentReport compares the variants of an experiment. Variants
+// without generations yet are listed with zero counts.
+func (s *ExperimentService) GetExperimentReport(name string) (*models.ExperimentReport, error) {
+	experiment, err := s.experiments.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := s.experimentRepo.GetVariantReports(name)
+	if err != nil {
+		return nil, err
+	}
+	byVariant := make(map[string]*models.VariantReport, len(reports))
+	for _, report := range reports {
+		byVariant[report.Variant] = report
+	}
+
+	result := &models.ExperimentReport{
+		Experiment: experiment.Name,
+		Kind:       experiment.Kind,
+		Enabled:    experiment.Enabled,
+		Variants:   make([]*models.VariantReport, 0, len(experiment.Variants)),
+	}
+	for _, variant := range experiment.Variants {
+		report, ok := byVariant[variant.Name]
+		if !ok {
+			report = &models.VariantReport{Variant: variant.Name, Signals: map[string]int{}}
+		}
+		report.Weight = variant.Weight
+		report.SignalRates = signalRates(report)
+		result.Variants = append(result.Variants, report)
+	}
+
+	return result, nil
+}
+
+// signalRates divides each signal's document count by the variant's documents
+func signalRates(report *models.VariantReport) map[string]float64 {
+	rates := make(map[string]float64, len(report.Signals))
+	for signal, documents := range report.Signals {
+		if report.Documents > 0 {
+			rates[signal] = float64(documents) / float64(report.Documents)
+		}
+	}
+	return rates
+}
+
+// recordSignal stores a signal the server observed. Failures are logged
+// only, the action itself already succeeded.
+func recordSignal(repo *repository.ExperimentRepository, userID, docID uuid.UUID, signal string) {
+	err := repo.CreateDocumentSignal(&models.DocumentSignal{
+		ID:         uuid.New(),
+		DocumentID: docID,
+		UserID:     userID,
+		Signal:     signal,
+	})
+	if err != nil {
+		log.Printf("Failed to record %s signal: %v", signal, err)
+	}
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/repository"
+	"github.com/google/uuid"
+)
+
+// maxFeedbackComment caps the length of a feedback comment
+const maxFeedbackComment = 2000
+
+var (
+	ErrInvalidRating         = errors.New("rating must be up or down")
+	ErrInvalidFeedbackReason = errors.New("unknown feedback reason")
+	ErrFeedbackTooLong       = errors.New("comment must be at most 2000 characters")
+	ErrInvalidFeedbackScope  = errors.New("scope must be document or section")
+)
+
+// FeedbackReasons are the reason codes a rating may carry
+var FeedbackReasons = map[string][]string{
+	models.RatingUp:   {"accurate", "relevant", "well_written", "good_format", "other"},
+	models.RatingDown: {"inaccurate", "made_up", "irrelevant", "generic", "too_long", "too_short", "wrong_tone", "poor_format", "other"},
+}
+
+type FeedbackService struct {
+	feedbackRepo   *repository.FeedbackRepository
+	documentRepo   *repository.DocumentRepository
+	experimentRepo *repository.ExperimentRepository
+}
+
+func NewFeedbackService(feedbackRepo *repository.FeedbackRepository, documentRepo *repository.DocumentRepository, experimentRepo *repository.ExperimentRepository) *FeedbackService {
+	return &FeedbackService{
+		feedbackRepo:   feedbackRepo,
+		documentRepo:   documentRepo,
+		experimentRepo: experimentRepo,
+	}
+}
+
+// SaveFeedback rates a document or one of its sections. A rating of the
+// whole document also counts as a thumbs up or down in experiment reports.
+func (s *FeedbackService) SaveFeedback(userID, docID uuid.UUID, req *models.FeedbackRequest) (*models.GenerationFeedback, error) {
+	reasons, err := checkFeedback(req)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := s.documentRepo.GetDocumentByID(docID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	section, err := feedbackSection(doc, req.Section, req.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	feedback := &models.GenerationFeedback{
+		ID:         uuid.New(),
+		UserID:     userID,
+		DocumentID: docID,
+		Section:    section,
+		Rating:     req.Rating,
+		Reasons:    reasons,
+		Comment:    strings.TrimSpace(req.Comment),
+	}
+	if err := s.feedbackRepo.SaveFeedback(feedback); err != nil {
+		return nil, err
+	}
+
+	if section == "" {
+		signal := models.SignalThumbsUp
+		if req.Rating == models.RatingDown {
+			signal = models.SignalThumbsDown
+		}
+		recordSignal(s.experimentRepo, userID, docID, signal)
+	}
+
+	return feedback, nil
+}
+
+// GetDocumentFeedback lists the ratings of a document and its sections
+func (s *FeedbackService) GetDocumentFeedback(userID, docID uuid.UUID) ([]*models.GenerationFeedback, error) {
+	// Make sure the document exists and belongs to the user
+	if _, err := s.documentRepo.GetDocumentByID(docID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.feedbackRepo.GetDocumentFeedback(docID, userID)
+}
+
+// DeleteFeedback removes the rating of a document or one of its sections
+func (s *FeedbackService) DeleteFeedback(userID, docID uuid.UUID, section string, index *int) error {
+	doc, err := s.documentRepo.GetDocumentByID(docID, userID)
+	if err != nil {
+		return err
+	}
+
+	key, err := feedbackSection(doc, section, index)
+	if err != nil {
+		return err
+	}
+
+	return s.feedbackRepo.DeleteFeedback(docID, userID, key)
+}
+
+// GetFeedbackSummaries aggregates feedback by model, prompt and template.
+// Scope is empty for all feedback, document or section.
+func (s *FeedbackService) GetFeedbackSummaries(scope string) ([]*models.FeedbackSummary, error) {
+	switch scope {
+	case repository.FeedbackScopeAll, repository.FeedbackScopeDocument, repository.FeedbackScopeSection:
+	default:
+		return nil, ErrInvalidFeedbackScope
+	}
+
+	return s.feedbackRepo.GetFeedbackSummaries(scope)
+}
+
+// checkFeedback validates the rating, reasons and comment and returns the
+// reasons normalised and without duplicates
+func checkFeedback(req *models.FeedbackRequest) ([]string, error) {
+	allowed, ok := FeedbackReasons[req.Rating]
+	if !ok {
+		return nil, ErrInvalidRating
+	}
+	if len([]rune(req.Comment)) > maxFeedbackComment {
+		return nil, ErrFeedbackTooLong
+	}
+
+	reasons := []string{}
+	seen := make(map[string]bool, len(req.Reasons))
+	for _, reason := range req.Reasons {
+		reason = strings.ToLower(strings.TrimSpace(reason))
+		if seen[reason] {
+			continue
+		}
+		known := false
+		for _, a := range allowed {
+			if a == reason {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("%w %q for rating %s", ErrInvalidFeedbackReason, reason, req.Rating)
+		}
+		seen[reason] = true
+		reasons = append(reasons, reason)
+	}
+
+	return reasons, nil
+}
+
+// feedbackSection checks that a rated section exists in the document and
+// returns the key it is stored under: empty for the whole document, the
+// section name, or experience[i] for one experience entry
+func feedbackSection(doc *models.Document, section string, index *int) (string, error) {
+	if section == "" {
+		return "", nil
+	}
+
+	if _, err := findSection(doc, structuredContent(doc.Content), section, index); err != nil {
+		return "", err
+	}
+	if section == "experience" {
+		return fmt.Sprintf("experience[%d]", *index), nil
+	}
+	return section, nil
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+)
+
+func TestCheckFeedback(t *testing.T) {
+	tests := []struct {
+		name string
+		req  models.FeedbackRequest
+		want error
+	}{
+		{"thumbs up", models.FeedbackRequest{Rating: "up"}, nil},
+		{"down with reasons", models.FeedbackRequest{Rating: "down", Reasons: []string{"made_up", "too_long"}}, nil},
+		{"no rating", models.FeedbackRequest{}, ErrInvalidRating},
+		{"unknown rating", models.FeedbackRequest{Rating: "meh"}, ErrInvalidRating},
+		{"unknown reason", models.FeedbackRequest{Rating: "down", Reasons: []string{"boring"}}, ErrInvalidFeedbackReason},
+		{"reason of the other rating", models.FeedbackRequest{Rating: "up", Reasons: []string{"made_up"}}, ErrInvalidFeedbackReason},
+		{"long comment", models.FeedbackRequest{Rating: "down", Comment: strings.Repeat("x", 2001)}, ErrFeedbackTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := checkFeedback(&tt.req); !errors.Is(err, tt.want) {
+				t.Errorf("checkFeedback = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	reasons, err := checkFeedback(&models.FeedbackRequest{Rating: "down", Reasons: []string{" Too_Long", "too_long", "generic"}})
+	if err != nil || !reflect.DeepEqual(reasons, []string{"too_long", "generic"}) {
+		t.Errorf("reasons = %v, %v", reasons, err)
+	}
+}
+
+func TestFeedbackSection(t *testing.T) {
+	doc := &models.Document{
+		Type: "resume",
+		Content: map[string]interface{}{
+			"summary":    "Engineer",
+			"experience": []interface{}{map[string]interface{}{"position": "Dev", "highlights": []interface{}{"Built it"}}},
+		},
+	}
+	index := func(i int) *int { return &i }
+
+	tests := []struct {
+		section string
+		index   *int
+		want    string
+		err     error
+	}{
+		{"", nil, "", nil},
+		{"summary", nil, "summary", nil},
+		{"experience", index(0), "experience[0]", nil},
+		{"experience", index(3), "", ErrSectionNotFound},
+		{"experience", nil, "", ErrSectionNotFound},
+		{"skills", nil, "", ErrSectionNotFound},
+		{"closing", nil, "", ErrInvalidSection},
+	}
+	for _, tt := range tests {
+		got, err := feedbackSection(doc, tt.section, tt.index)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("feedbackSection(%q) = %q, %v, want %q, %v", tt.section, got, err, tt.want, tt.err)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/taxonomy"
+)
+
+const (
+	maxKeywords         = 25
+	maxResponsibilities = 15
+)
+
+type jobSection int
+
+const (
+	sectionNone jobSection = iota
+	sectionRequired
+	sectionNiceToHave
+	sectionResponsibilities
+	sectionOther
+)
+
+var (
+	niceToHaveHeading       = regexp.MustCompile(`(?i)\b(nice[- ]to[- ]haves?|preferred|bonus|desirable|pluses|extra credit)\b`)
+	requiredHeading         = regexp.MustCompile(`(?i)\b(requirements?|required|qualifications|must[- ]haves?|what you('ll)? (need|bring)|about you|you have|skills)\b`)
+	responsibilitiesHeading = regexp.MustCompile(`(?i)\b(responsibilities|what you('ll)? do|duties|the role|your role|you will|day[- ]to[- ]day)\b`)
+	otherHeading            = regexp.MustCompile(`(?i)\b(benefits|perks|about us|we offer|compensation|salary|how to apply)\b`)
+
+	niceToHaveLine = regexp.MustCompile(`(?i)\b(nice[- ]to[- ]have|preferred|bonus|a plus|is a plus|desirable|ideally)\b`)
+	bulletPrefix   = regexp.MustCompile(`^\s*([-*•·▪●–]|\d+[.)])\s+`)
+
+	yearsPattern = regexp.MustCompile(`(?i)(\d{1,2})\s*\+?\s*(?:-\s*\d{1,2}\s*)?(?:years?|yrs?)`)
+
+	seniorityPatterns = []struct {
+		level   string
+		pattern *regexp.Regexp
+	}{
+		{"lead", regexp.MustCompile(`(?i)\b(lead|principal|staff|head of|architect)\b`)},
+		{"senior", regexp.MustCompile(`(?i)\b(senior|sr\.?)(\s|$)`)},
+		{"mid", regexp.MustCompile(`(?i)\b(mid[- ]level|intermediate|middle)\b`)},
+		{"junior", regexp.MustCompile(`(?i)\b(junior|jr\.?|entry[- ]level|graduate)(\s|$)`)},
+		{"intern", regexp.MustCompile(`(?i)\b(intern|internship|trainee)\b`)},
+	}
+)
+
+// AnalyzeJobDescription extracts structured requirements from a job
+// description using deterministic text processing only
+func AnalyzeJobDescription(jobTitle, description string) *models.JobAnalysis {
+	analysis := &models.JobAnalysis{
+		RequiredSkills:   []string{},
+		NiceToHaveSkills: []string{},
+		Responsibilities: []string{},
+		Keywords:         []string{},
+	}
+
+	required := map[string]bool{}
+	nice := map[string]bool{}
+	var requiredOrder, niceOrder []string
+
+	section := sectionNone
+	for _, rawLine := range strings.Split(description, "\n") {
+		line := strings.TrimSpace(rawLine)
+		if line == "" {
+			continue
+		}
+
+		isBullet := bulletPrefix.MatchString(line)
+		if !isBullet {
+			if heading, ok := detectHeading(line); ok {
+				section = heading
+				continue
+			}
+		}
+		text := strings.TrimSpace(bulletPrefix.ReplaceAllString(line, ""))
+
+		if section == sectionResponsibilities && isBullet && len(analysis.Responsibilities) < maxResponsibilities {
+			analysis.Responsibilities = append(analysis.Responsibilities, strings.TrimRight(text, ".;"))
+		}
+		if section == sectionOther {
+			continue
+		}
+
+		lineIsNice := section == sectionNiceToHave || niceToHaveLine.MatchString(text)
+		for _, skill := range taxonomy.Default().Find(text) {
+			if lineIsNice {
+				if !nice[skill] {
+					nice[skill] = true
+					niceOrder = append(niceOrder, skill)
+				}
+				continue
+			}
+			if !required[skill] {
+				required[skill] = true
+				requiredOrder = append(requiredOrder, skill)
+			}
+		}
+	}
+
+	analysis.RequiredSkills = append(analysis.RequiredSkills, requiredOrder...)
+	for _, skill := range niceOrder {
+		// A skill that is required anywhere is required
+		if !required[skill] {
+			analysis.NiceToHaveSkills = append(analysis.NiceToHaveSkills, skill)
+		}
+	}
+
+	analysis.YearsExperience = detectYears(description)
+	analysis.Seniority = detectSeniority(jobTitle, description, analysis.YearsExperience)
+	analysis.Keywords = extractKeywords(description, append(append([]string{}, analysis.RequiredSkills...), analysis.NiceToHaveSkills...))
+
+	return analysis
+}
+
+// mergeJobAnalysis adds an LLM analysis to a deterministic one. Deterministic
+// results win where both have a value; lists are unioned case-insensitively.
+func mergeJobAnalysis(base, extra *models.JobAnalysis) {
+	base.RequiredSkills = unionStrings(base.RequiredSkills, canonicalSkills(extra.RequiredSkills), 0)
+
+	// Skills required by either side aren't nice-to-have
+	required := map[string]bool{}
+	for _, skill := range base.RequiredSkills {
+		required[strings.ToLower(skill)] = true
+	}
+	nice := []string{}
+	for _, skill := range unionStrings(base.NiceToHaveSkills, canonicalSkills(extra.NiceToHaveSkills), 0) {
+		if !required[strings.ToLower(skill)] {
+			nice = append(nice, skill)
+		}
+	}
+	base.NiceToHaveSkills = nice
+
+	if base.Seniority == "" {
+		base.Seniority = extra.Seniority
+	}
+	if extra.YearsExperience > base.YearsExperience && extra.YearsExperience <= 20 {
+		base.YearsExperience = extra.YearsExperience
+	}
+	if len(base.Responsibilities) == 0 {
+		base.Responsibilities = unionStrings(nil, extra.Responsibilities, maxResponsibilities)
+	}
+	base.Keywords = unionStrings(base.Keywords, extra.Keywords, maxKeywords)
+	base.Enriched = true
+}
+
+// canonicalSkills maps skill names to their taxonomy names, so that an LLM's
+// "Golang" merges with a detected "Go"
+func canonicalSkills(names []string) []string {
+	result := make([]string, len(names))
+	for i, name := range names {
+		result[i] = taxonomy.Default().Canonical(name)
+	}
+	return result
+}
+
+// unionStrings appends the items of b missing from a, ignoring case and
+// blanks. A limit of 0 means no limit.
+func unionStrings(a, b []string, limit int) []string {
+	result := []string{}
+	seen := map[string]bool{}
+	for _, item := range append(append([]string{}, a...), b...) {
+		item = strings.TrimSpace(item)
+		key := strings.ToLower(item)
+		if item == "" || seen[key] {
+			continue
+		}
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+		seen[key] = true
+		result = append(result, item)
+	}
+	return result
+}
+
+// detectHeading reports whether a line is a section heading and which one
+func detectHeading(line string) (jobSection, bool) {
+	trimmed := strings.TrimRight(strings.Trim(line, "#*_ "), ":")
+	if len(trimmed) == 0 || len(trimmed) > 60 {
+		return sectionNone, false
+	}
+	// Headings are short and don't read like sentences
+	if !strings.HasSuffix(line, ":") && strings.Count(trimmed, " ") > 5 {
+		return sectionNone, false
+	}
+
+	switch {
+	case niceToHaveHeading.MatchString(trimmed):
+		return sectionNiceToHave, true
+	case responsibilitiesHeading.MatchString(trimmed):
+		return sectionResponsibilities, true
+	case requiredHeading.MatchString(trimmed):
+		return sectionRequired, true
+	case otherHeading.MatchString(trimmed):
+		return sectionOther, true
+	}
+	return sectionNone, false
+}
+
+// detectYears returns the largest plausible "N years" requirement
+func detectYears(text string) int {
+	years := 0
+	for _, match := range yearsPattern.FindAllStringSubmatch(text, -1) {
+		n, err := strconv.Atoi(match[1])
+		if err == nil && n <= 20 && n > years {
+			years = n
+		}
+	}
+	return years
+}
+
+// detectSeniority prefers the job title, then years of experience, then
+// wording in the description
+func detectSeniority(jobTitle, description string, years int) string {
+	for _, candidate := range seniorityPatterns {
+		if candidate.pattern.MatchString(jobTitle) {
+			return candidate.level
+		}
+	}
+
+	switch {
+	case years >= 8:
+		return "lead"
+	case years >= 5:
+		return "senior"
+	case years >= 2:
+		return "mid"
+	case years >= 1:
+		return "junior"
+	}
+
+	for _, candidate := range seniorityPatterns {
+		if candidate.pattern.MatchString(description) {
+			return candidate.level
+		}
+	}
+	return ""
+}
+
+// extractKeywords returns the detected skills followed by the most frequent
+// meaningful words of the description
+func extractKeywords(text string, skills []string) []string {
+	keywords := []string{}
+	seen := map[string]bool{}
+	for _, skill := range skills {
+		key := strings.ToLower(skill)
+		if !seen[key] && len(keywords) < maxKeywords {
+			seen[key] = true
+			keywords = append(keywords, skill)
+		}
+	}
+
+	counts := map[string]int{}
+	for _, word := range tokenize(text) {
+		if len(word) < 3 || stopWords[word] || seen[word] {
+			continue
+		}
+		counts[word]++
+	}
+
+	words := make([]string, 0, len(counts))
+	for word, count := range counts {
+		if count >= 2 {
+			words = append(words, word)
+		}
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if counts[words[i]] != counts[words[j]] {
+			return counts[words[i]] > counts[words[j]]
+		}
+		return words[i] < words[j]
+	})
+
+	for _, word := range words {
+		if len(keywords) >= maxKeywords {
+			break
+		}
+		keywords = append(keywords, word)
+	}
+
+	return keywords
+}
+
+// tokenize lowercases text and splits it into words
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	})
+}
+
+var stopWords = func() map[string]bool {
+	words := strings.Fields(`
+		a about above after again all also am an and any are as at be because been before being
+		below between both but by can could did do does doing down during each etc few for from
+		further had has have having he her here hers herself him himself his how i if in into is it
+		its itself just me more most my myself no nor not now of off on once only or other our ours
+		ourselves out over own same she should so some such than that the their theirs them
+		themselves then there these they this those through to too under until up very was we were
+		what when where which while who whom why will with would you your yours yourself yourselves
+		able across ability etc within without well new strong good great work working team teams
+		role job company us join looking ideal candidate including include includes using use used
+		must should required requirements preferred experience years year plus nice bonus across
+		make help based per like via what's we're you'll you're we'll it's
+	`)
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}()
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+)
+
+const testJobDescription = `Senior Backend Engineer
+
+What you'll do:
+- Design and build APIs in Go
+- Run services on Kubernetes.
+
+Requirements:
+- 5+ years of experience with Golang and PostgreSQL
+- Experience with Docker
+
+Nice to have:
+- Terraform
+- PostgreSQL tuning
+
+Benefits:
+- Python workshops for everyone`
+
+func TestAnalyzeJobDescription(t *testing.T) {
+	analysis := AnalyzeJobDescription("Backend Engineer", testJobDescription)
+
+	if want := []string{"Go", "Kubernetes", "PostgreSQL", "Docker"}; !sameStrings(analysis.RequiredSkills, want) {
+		t.Errorf("RequiredSkills = %v, want %v", analysis.RequiredSkills, want)
+	}
+	// PostgreSQL is required elsewhere, Python only appears under benefits
+	if want := []string{"Terraform"}; !reflect.DeepEqual(analysis.NiceToHaveSkills, want) {
+		t.Errorf("NiceToHaveSkills = %v, want %v", analysis.NiceToHaveSkills, want)
+	}
+	if want := []string{"Design and build APIs in Go", "Run services on Kubernetes"}; !reflect.DeepEqual(analysis.Responsibilities, want) {
+		t.Errorf("Responsibilities = %v, want %v", analysis.Responsibilities, want)
+	}
+	if analysis.YearsExperience != 5 || analysis.Seniority != "senior" {
+		t.Errorf("YearsExperience, Seniority = %d, %q, want 5, senior", analysis.YearsExperience, analysis.Seniority)
+	}
+	if len(analysis.Keywords) == 0 || analysis.Keywords[0] != analysis.RequiredSkills[0] {
+		t.Errorf("Keywords = %v, want the skills first", analysis.Keywords)
+	}
+	if analysis.Enriched {
+		t.Error("deterministic analysis is marked enriched")
+	}
+}
+
+func TestAnalyzeJobDescription_Seniority(t *testing.T) {
+	tests := []struct {
+		title       string
+		description string
+		want        string
+	}{
+		{"Staff Engineer", "3 years of experience", "lead"},
+		{"Sr. Developer", "", "senior"},
+		{"Developer", "8+ years of experience", "lead"},
+		{"Developer", "2-4 years of experience", "mid"},
+		{"Developer", "A junior role in a small team", "junior"},
+		{"Summer Internship", "", "intern"},
+		{"Developer", "Build things", ""},
+	}
+	for _, tt := range tests {
+		if got := AnalyzeJobDescription(tt.title, tt.description).Seniority; got != tt.want {
+			t.Errorf("Seniority(%q, %q) = %q, want %q", tt.title, tt.description, got, tt.want)
+		}
+	}
+}
+
+func TestAnalyzeJobDescription_Empty(t *testing.T) {
+	analysis := AnalyzeJobDescription("", "")
+	if analysis.RequiredSkills == nil || analysis.NiceToHaveSkills == nil || analysis.Responsibilities == nil || analysis.Keywords == nil {
+		t.Errorf("empty analysis has nil lists: %+v", analysis)
+	}
+}
+
+func TestMergeJobAnalysis(t *testing.T) {
+	base := &models.JobAnalysis{
+		RequiredSkills:   []string{"Go"},
+		NiceToHaveSkills: []string{"Terraform"},
+		Responsibilities: []string{},
+		Keywords:         []string{"Go", "payments"},
+		YearsExperience:  3,
+	}
+	extra := &models.JobAnalysis{
+		RequiredSkills:   []string{"golang", "Terraform", "gRPC"},
+		NiceToHaveSkills: []string{"Rust", "Go"},
+		Seniority:        "senior",
+		YearsExperience:  5,
+		Responsibilities: []string{"Own the payments API", " "},
+		Keywords:         []string{"PAYMENTS", "latency"},
+	}
+
+	mergeJobAnalysis(base, extra)
+
+	want := &models.JobAnalysis{
+		RequiredSkills:   []string{"Go", "Terraform", "gRPC"},
+		NiceToHaveSkills: []string{"Rust"},
+		Seniority:        "senior",
+		YearsExperience:  5,
+		Responsibilities: []string{"Own the payments API"},
+		Keywords:         []string{"Go", "payments", "latency"},
+		Enriched:         true,
+	}
+	if !reflect.DeepEqual(base, want) {
+		t.Errorf("mergeJobAnalysis = %+v, want %+v", base, want)
+	}
+}
+
+func TestMergeJobAnalysis_DeterministicWins(t *testing.T) {
+	base := &models.JobAnalysis{Seniority: "mid", YearsExperience: 3, Responsibilities: []string{"Build APIs"}}
+	extra := &models.JobAnalysis{Seniority: "lead", YearsExperience: 40, Responsibilities: []string{"Lead the team"}}
+
+	mergeJobAnalysis(base, extra)
+
+	if base.Seniority != "mid" || base.YearsExperience != 3 || !reflect.DeepEqual(base.Responsibilities, []string{"Build APIs"}) {
+		t.Errorf("mergeJobAnalysis replaced deterministic values: %+v", base)
+	}
+}
+
+// sameStrings compares two lists ignoring order
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := map[string]int{}
+	for _, s := range a {
+		counts[s]++
+	}
+	for _, s := range b {
+		counts[s]--
+		if counts[s] < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidSalaryRange    = errors.New("salary range must be non-negative with salary_min at most salary_max")
+	ErrInvalidSalaryCurrency = errors.New("salary currency must be a three-letter code such as USD")
+	ErrDuplicateJobPosting   = errors.New("another saved job posting has the same description")
+)
+
+// JobPostingHash identifies a job description regardless of letter case and
+// whitespace, so the same posting pasted twice hashes the same
+func JobPostingHash(description string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(description)), " ")
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+type JobPostingService struct {
+	jobPostingRepo *repository.JobPostingRepository
+}
+
+func NewJobPostingService(jobPostingRepo *repository.JobPostingRepository) *JobPostingService {
+	return &JobPostingService{
+		jobPostingRepo: jobPostingRepo,
+	}
+}
+
+// CreateJobPosting saves a posting. If the user already saved one with the
+// same description, that one is returned instead and created is false.
+func (s *JobPostingService) CreateJobPosting(userID uuid.UUID, posting *models.JobPosting) (saved *models.JobPosting, created bool, err error) {
+	if err := checkJobPosting(posting); err != nil {
+		return nil, false, err
+	}
+
+	posting.ID = uuid.New()
+	posting.UserID = userID
+	posting.ContentHash = JobPostingHash(posting.Description)
+
+	created, err = s.jobPostingRepo.CreateJobPosting(posting)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create job posting: %w", err)
+	}
+
+	return posting, created, nil
+}
+
+func (s *JobPostingService) GetJobPostings(userID uuid.UUID) ([]*models.JobPosting, error) {
+	postings, err := s.jobPostingRepo.GetJobPostings(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job postings: %w", err)
+	}
+
+	return postings, nil
+}
+
+func (s *JobPostingService) GetJobPosting(userID, postingID uuid.UUID) (*models.JobPosting, error) {
+	return s.jobPostingRepo.GetJobPostingByID(postingID, userID)
+}
+
+// UpdateJobPosting replaces a posting's details. The new description may not
+// duplicate another saved posting.
+func (s *JobPostingService) UpdateJobPosting(userID, postingID uuid.UUID, posting *models.JobPosting) (*models.JobPosting, error) {
+	if err := checkJobPosting(posting); err != nil {
+		return nil, err
+	}
+
+	posting.ID = postingID
+	posting.UserID = userID
+	posting.ContentHash = JobPostingHash(posting.Description)
+
+	existing, err := s.jobPostingRepo.GetJobPostingByHash(userID, posting.ContentHash)
+	if err == nil && existing.ID != postingID {
+		return nil, ErrDuplicateJobPosting
+	}
+	if err != nil && !errors.Is(err, repository.ErrJobPostingNotFound) {
+		return nil, fmt.Errorf("failed to check for duplicate job posting: %w", err)
+	}
+
+	if err := s.jobPostingRepo.UpdateJobPosting(posting); err != nil {
+		if errors.Is(err, repository.ErrJobPostingNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update job posting: %w", err)
+	}
+
+	return posting, nil
+}
+
+func (s *JobPostingService) DeleteJobPosting(userID, postingID uuid.UUID) error {
+	return s.jobPostingRepo.DeleteJobPosting(postingID, userID)
+}
+
+// checkJobPosting validates the salary range and normalises the currency
+func checkJobPosting(posting *models.JobPosting) error {
+	if (posting.SalaryMin != nil && *posting.SalaryMin < 0) || (posting.SalaryMax != nil && *posting.SalaryMax < 0) ||
+		(posting.SalaryMin != nil && posting.SalaryMax != nil && *posting.SalaryMin > *posting.SalaryMax) {
+		return ErrInvalidSalaryRange
+	}
+
+	posting.SalaryCurrency = strings.ToUpper(strings.TrimSpace(posting.SalaryCurrency))
+	if posting.SalaryCurrency != "" {
+		if len(posting.SalaryCurrency) != 3 || strings.Trim(posting.SalaryCurrency, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+			return ErrInvalidSalaryCurrency
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+)
+
+func TestJobPostingHash(t *testing.T) {
+	base := JobPostingHash("Senior Go Engineer\n\nWe build payment APIs.")
+	if len(base) != 64 {
+		t.Fatalf("hash %q is not hex SHA-256", base)
+	}
+	if got := JobPostingHash("  senior go engineer we BUILD\tpayment   APIs.\n"); got != base {
+		t.Errorf("case and whitespace changed the hash")
+	}
+	if got := JobPostingHash("Senior Go Engineer. We build payment APIs."); got == base {
+		t.Errorf("different text has the same hash")
+	}
+}
+
+func TestCheckJobPosting(t *testing.T) {
+	n := func(v int) *int { return &v }
+
+	tests := []struct {
+		name    string
+		posting models.JobPosting
+		want    error
+	}{
+		{"no salary", models.JobPosting{}, nil},
+		{"range", models.JobPosting{SalaryMin: n(50), SalaryMax: n(60), SalaryCurrency: " eur "}, nil},
+		{"open range", models.JobPosting{SalaryMin: n(50)}, nil},
+		{"reversed range", models.JobPosting{SalaryMin: n(60), SalaryMax: n(50)}, ErrInvalidSalaryRange},
+		{"negative", models.JobPosting{SalaryMax: n(-1)}, ErrInvalidSalaryRange},
+		{"long currency", models.JobPosting{SalaryCurrency: "EURO"}, ErrInvalidSalaryCurrency},
+		{"currency symbol", models.JobPosting{SalaryCurrency: "US$"}, ErrInvalidSalaryCurrency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkJobPosting(&tt.posting); err != tt.want {
+				t.Errorf("checkJobPosting = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	posting := models.JobPosting{SalaryCurrency: " eur "}
+	if err := checkJobPosting(&posting); err != nil || posting.SalaryCurrency != "EUR" {
+		t.Errorf("currency = %q, %v, want EUR", posting.SalaryCurrency, err)
+	}
+}
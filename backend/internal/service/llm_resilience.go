@@ -0,0 +1,156 @@
+package service
+
+import (
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// ErrModelUnavailable means every model of the fallback chain failed with a
+// transient error or is cut off by its circuit breaker
+var ErrModelUnavailable = errors.New("model unavailable")
+
+// errCircuitOpen is returned for a model whose circuit breaker is open
+var errCircuitOpen = errors.New("circuit breaker open")
+
+// Resilience is how OpenAIService handles transient model errors: rate
+// limits, server errors and network failures
+type Resilience struct {
+	// MaxRetries is the number of retries of a model after the first attempt
+	MaxRetries int
+	// BaseDelay is the delay before the first retry, doubled for each next
+	// one up to MaxDelay, with jitter. A Retry-After header is honoured up to
+	// MaxDelay; a longer one moves on to the next model.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// FallbackModels are tried in order when a model keeps failing
+	FallbackModels []string
+
+	// A model's circuit breaker opens after BreakerThreshold consecutive
+	// transient failures and fails fast for BreakerCooldown
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+// CircuitBreaker stops calls to a failing dependency for a cooldown after a
+// run of consecutive failures. After the cooldown it is half open: a single
+// probe call is let through while the others still fail fast. The probe's
+// failure reopens it, its success closes it.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	clock     Clock
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+	probing   bool // a half-open probe call is in flight
+}
+
+func NewCircuitBreaker(threshold int, cooldown time.Duration, clock Clock) *CircuitBreaker {
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown, clock: clock}
+}
+
+// Allow reports whether a call may be made; a breaker without a threshold
+// never opens. A caller that is allowed must report the outcome with Success
+// or Failure, which ends a half-open probe.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.threshold <= 0 || b.failures < b.threshold {
+		return true
+	}
+	if b.probing || b.clock.Now().Before(b.openUntil) {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.probing = false
+	if b.threshold > 0 && b.failures >= b.threshold {
+		b.openUntil = b.clock.Now().Add(b.cooldown)
+	}
+}
+
+// transient reports whether a model error may go away on retry: rate limits
+// other than an exhausted quota, server errors and network failures
+func transient(err error) bool {
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.Code == "insufficient_quota" {
+			return false
+		}
+		return transientStatus(apiErr.HTTPStatusCode)
+	}
+	var reqErr *openai.RequestError
+	if errors.As(err, &reqErr) {
+		return transientStatus(reqErr.HTTPStatusCode)
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+func transientStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// retryAfter parses a Retry-After header in seconds or as an HTTP date, or
+// returns 0
+func retryAfter(header http.Header, now time.Time) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+// backoff returns the delay before a retry, counted from 0: the exponential
+// delay with half of it jittered
+func (r Resilience) backoff(retry int) time.Duration {
+	delay := r.BaseDelay
+	for i := 0; i < retry && delay < r.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > r.MaxDelay {
+		delay = r.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// modelChain returns the model followed by the fallback models
+func (r Resilience) modelChain(model string) []string {
+	chain := []string{model}
+	for _, fallback := range r.FallbackModels {
+		if fallback != model {
+			chain = append(chain, fallback)
+		}
+	}
+	return chain
+}
@@ -0,0 +1,328 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// fakeOpenAI is a chat completion API whose reply to each call is decided by
+// the test; it records the model of each call
+type fakeOpenAI struct {
+	mu     sync.Mutex
+	models []string
+	reply  func(w http.ResponseWriter, model string, call int)
+}
+
+func (f *fakeOpenAI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req openai.ChatCompletionRequest
+	json.NewDecoder(r.Body).Decode(&req)
+
+	f.mu.Lock()
+	f.models = append(f.models, req.Model)
+	call := len(f.models)
+	f.mu.Unlock()
+
+	f.reply(w, req.Model, call)
+}
+
+func (f *fakeOpenAI) calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.models...)
+}
+
+func replyOK(w http.ResponseWriter, model string) {
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"model": %q, "choices": [{"message": {"role": "assistant", "content": "ok"}}],
+		"usage": {"prompt_tokens": 10, "completion_tokens": 5, "total_tokens": 15}}`, model)
+}
+
+func replyError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `{"error": {"message": "failed", "type": "error", "code": %q}}`, code)
+}
+
+// newResilientService returns a service calling the fake API, whose sleeps
+// are recorded instead of waited
+func newResilientService(t *testing.T, fake *fakeOpenAI, resilience Resilience) (*OpenAIService, *[]time.Duration) {
+	t.Helper()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	s := NewOpenAIService("test-key", "gpt-4o-mini", 100, 0.7, nil, nil, 0, resilience)
+	config := openai.DefaultConfig("test-key")
+	config.BaseURL = server.URL + "/v1"
+	s.client = openai.NewClientWithConfig(config)
+
+	var sleeps []time.Duration
+	s.sleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+	return s, &sleeps
+}
+
+var testMessages = []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "hello"}}
+
+func TestOpenAIService_RetriesTransientErrors(t *testing.T) {
+	fake := &fakeOpenAI{reply: func(w http.ResponseWriter, model string, call int) {
+		switch call {
+		case 1:
+			w.Header().Set("Retry-After", "2")
+			replyError(w, http.StatusTooManyRequests, "rate_limit_exceeded")
+		case 2:
+			replyError(w, http.StatusBadGateway, "")
+		default:
+			replyOK(w, model)
+		}
+	}}
+	s, sleeps := newResilientService(t, fake, Resilience{
+		MaxRetries: 2, BaseDelay: 100 * time.Millisecond, MaxDelay: 5 * time.Second,
+	})
+
+	generated, err := s.createChatCompletion("gpt-4o-mini", testMessages, 100)
+	if err != nil {
+		t.Fatalf("createChatCompletion: %v", err)
+	}
+	if generated.Content != "ok" || generated.Model != "gpt-4o-mini" || generated.TotalTokens != 15 {
+		t.Errorf("createChatCompletion = %+v", generated)
+	}
+	if calls := fake.calls(); len(calls) != 3 {
+		t.Errorf("calls = %v, want 3", calls)
+	}
+
+	// The first retry waits as long as Retry-After says, the second backs
+	// off twice the base delay, half of it jittered
+	if len(*sleeps) != 2 || (*sleeps)[0] != 2*time.Second ||
+		(*sleeps)[1] < 100*time.Millisecond || (*sleeps)[1] > 200*time.Millisecond {
+		t.Errorf("sleeps = %v", *sleeps)
+	}
+}
+
+func TestOpenAIService_FallbackModels(t *testing.T) {
+	fake := &fakeOpenAI{reply: func(w http.ResponseWriter, model string, call int) {
+		if model == "gpt-4o-mini" {
+			replyError(w, http.StatusServiceUnavailable, "")
+			return
+		}
+		replyOK(w, model)
+	}}
+	s, _ := newResilientService(t, fake, Resilience{MaxRetries: 1, FallbackModels: []string{"gpt-4o-mini", "gpt-4o"}})
+
+	generated, err := s.createChatCompletion("gpt-4o-mini", testMessages, 100)
+	if err != nil {
+		t.Fatalf("createChatCompletion: %v", err)
+	}
+	if generated.Model != "gpt-4o" {
+		t.Errorf("Model = %q, want the fallback gpt-4o", generated.Model)
+	}
+	want := []string{"gpt-4o-mini", "gpt-4o-mini", "gpt-4o"}
+	if calls := fake.calls(); fmt.Sprint(calls) != fmt.Sprint(want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestOpenAIService_PermanentErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		code   string
+	}{
+		{"bad request", http.StatusBadRequest, "invalid_request_error"},
+		{"unauthorized", http.StatusUnauthorized, "invalid_api_key"},
+		{"quota", http.StatusTooManyRequests, "insufficient_quota"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeOpenAI{reply: func(w http.ResponseWriter, model string, call int) {
+				replyError(w, tt.status, tt.code)
+			}}
+			s, sleeps := newResilientService(t, fake, Resilience{MaxRetries: 3, FallbackModels: []string{"gpt-4o"}})
+
+			_, err := s.createChatCompletion("gpt-4o-mini", testMessages, 100)
+			var apiErr *openai.APIError
+			if !errors.As(err, &apiErr) || apiErr.HTTPStatusCode != tt.status || errors.Is(err, ErrModelUnavailable) {
+				t.Errorf("createChatCompletion error = %v, want the API error", err)
+			}
+			if calls := fake.calls(); len(calls) != 1 || len(*sleeps) != 0 {
+				t.Errorf("calls = %v, sleeps = %v, want a single call", calls, *sleeps)
+			}
+		})
+	}
+}
+
+func TestOpenAIService_RetryAfterTooLong(t *testing.T) {
+	fake := &fakeOpenAI{reply: func(w http.ResponseWriter, model string, call int) {
+		if model == "gpt-4o-mini" {
+			w.Header().Set("Retry-After", "120")
+			replyError(w, http.StatusTooManyRequests, "rate_limit_exceeded")
+			return
+		}
+		replyOK(w, model)
+	}}
+	s, sleeps := newResilientService(t, fake, Resilience{MaxRetries: 3, MaxDelay: 10 * time.Second, FallbackModels: []string{"gpt-4o"}})
+
+	generated, err := s.createChatCompletion("gpt-4o-mini", testMessages, 100)
+	if err != nil {
+		t.Fatalf("createChatCompletion: %v", err)
+	}
+	if generated.Model != "gpt-4o" || len(*sleeps) != 0 {
+		t.Errorf("Model = %q, sleeps = %v, want gpt-4o without waiting", generated.Model, *sleeps)
+	}
+}
+
+func TestOpenAIService_CircuitBreaker(t *testing.T) {
+	fake := &fakeOpenAI{reply: func(w http.ResponseWriter, model string, call int) {
+		replyError(w, http.StatusInternalServerError, "")
+	}}
+	s, _ := newResilientService(t, fake, Resilience{BreakerThreshold: 2, BreakerCooldown: time.Minute})
+	clock := &fixedClock{now: time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)}
+	s.clock = clock
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.createChatCompletion("gpt-4o-mini", testMessages, 100); !errors.Is(err, ErrModelUnavailable) {
+			t.Fatalf("call %d error = %v, want ErrModelUnavailable", i+1, err)
+		}
+	}
+
+	// Open: fails fast without calling the API
+	_, err := s.createChatCompletion("gpt-4o-mini", testMessages, 100)
+	if !errors.Is(err, ErrModelUnavailable) || !errors.Is(err, errCircuitOpen) {
+		t.Errorf("open breaker error = %v", err)
+	}
+	if calls := fake.calls(); len(calls) != 2 {
+		t.Errorf("calls with open breaker = %d, want 2", len(calls))
+	}
+
+	// After the cooldown a probe goes through; an error that isn't the
+	// model's fault still shows it's up and closes the breaker
+	rejecting := true
+	fake.reply = func(w http.ResponseWriter, model string, call int) {
+		if rejecting {
+			replyError(w, http.StatusBadRequest, "invalid_request")
+			return
+		}
+		replyOK(w, model)
+	}
+	clock.now = clock.now.Add(time.Minute)
+	if _, err := s.createChatCompletion("gpt-4o-mini", testMessages, 100); err == nil || errors.Is(err, errCircuitOpen) {
+		t.Errorf("rejected probe error = %v", err)
+	}
+	rejecting = false
+	if _, err := s.createChatCompletion("gpt-4o-mini", testMessages, 100); err != nil {
+		t.Errorf("after the probe: %v", err)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	clock := &fixedClock{now: time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)}
+	breaker := NewCircuitBreaker(3, time.Minute, clock)
+
+	breaker.Failure()
+	breaker.Failure()
+	breaker.Success()
+	breaker.Failure()
+	breaker.Failure()
+	if !breaker.Allow() {
+		t.Fatal("breaker opened before the threshold of consecutive failures")
+	}
+
+	breaker.Failure()
+	if breaker.Allow() {
+		t.Fatal("breaker didn't open at the threshold")
+	}
+
+	// Half open after the cooldown: a single probe goes through and its
+	// failure reopens the breaker
+	clock.now = clock.now.Add(time.Minute)
+	if !breaker.Allow() {
+		t.Fatal("breaker still open after the cooldown")
+	}
+	if breaker.Allow() {
+		t.Fatal("half-open breaker let a second call through during the probe")
+	}
+	breaker.Failure()
+	if breaker.Allow() {
+		t.Fatal("failed probe didn't reopen the breaker")
+	}
+
+	// A successful probe closes it
+	clock.now = clock.now.Add(time.Minute)
+	if !breaker.Allow() {
+		t.Fatal("breaker still open after the second cooldown")
+	}
+	breaker.Success()
+	breaker.Failure()
+	if !breaker.Allow() || !breaker.Allow() {
+		t.Error("successful probe didn't close the breaker")
+	}
+}
+
+func TestCircuitBreaker_SingleProbe(t *testing.T) {
+	clock := &fixedClock{now: time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)}
+	breaker := NewCircuitBreaker(1, time.Minute, clock)
+	breaker.Failure()
+	clock.now = clock.now.Add(time.Minute)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allowed := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if breaker.Allow() {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowed != 1 {
+		t.Errorf("concurrent calls allowed after the cooldown = %d, want 1", allowed)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"3", 3 * time.Second},
+		{"0", 0},
+		{"soon", 0},
+		{now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+	}
+	for _, tt := range tests {
+		header := http.Header{}
+		if tt.value != "" {
+			header.Set("Retry-After", tt.value)
+		}
+		if got := retryAfter(header, now); got != tt.want {
+			t.Errorf("retryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestResilienceBackoff(t *testing.T) {
+	r := Resilience{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for retry, max := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		max *= time.Millisecond
+		for i := 0; i < 20; i++ {
+			if got := r.backoff(retry); got < max/2 || got > max {
+				t.Fatalf("backoff(%d) = %v, want between %v and %v", retry, got, max/2, max)
+			}
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/conventions"
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+)
+
+// promptProfile is a profile as the prompts see it: entries with dates also
+// carry their period formatted for the output language and convention, for
+// the model to copy, and personal details the convention leaves out are hidden
+type promptProfile struct {
+	*ProfileData
+	DateOfBirth  string                `json:"date_of_birth,omitempty"`
+	Nationality  string                `json:"nationality,omitempty"`
+	PhotoURL     string                `json:"photo_url,omitempty"`
+	Experiences  []*periodExperience   `json:"experiences"`
+	Education    []*periodEducation    `json:"education"`
+	Volunteering []*periodVolunteering `json:"volunteering,omitempty"`
+}
+
+type periodExperience struct {
+	*models.Experience
+	Period string `json:"period"`
+}
+
+type periodEducation struct {
+	*models.Education
+	Period string `json:"period"`
+}
+
+type periodVolunteering struct {
+	*models.Volunteering
+	Period string `json:"period"`
+}
+
+// localizeProfile prepares a profile for a prompt in the given language and
+// convention
+func localizeProfile(profile *ProfileData, language string, convention *conventions.Convention) *promptProfile {
+	localized := &promptProfile{
+		ProfileData:  profile,
+		Experiences:  make([]*periodExperience, 0, len(profile.Experiences)),
+		Education:    make([]*periodEducation, 0, len(profile.Education)),
+		Volunteering: make([]*periodVolunteering, 0, len(profile.Volunteering)),
+	}
+
+	if details := personalDetails(profile, convention); details != nil {
+		localized.DateOfBirth, _ = details["date_of_birth"].(string)
+		localized.Nationality, _ = details["nationality"].(string)
+		localized.PhotoURL, _ = details["photo_url"].(string)
+	}
+
+	for _, exp := range profile.Experiences {
+		localized.Experiences = append(localized.Experiences, &periodExperience{
+			Experience: exp,
+			Period:     formatPeriod(convention, exp.StartDate, exp.EndDate, exp.IsCurrent, language),
+		})
+	}
+	for _, edu := range profile.Education {
+		localized.Education = append(localized.Education, &periodEducation{
+			Education: edu,
+			Period:    formatPeriod(convention, edu.StartDate, edu.EndDate, false, language),
+		})
+	}
+	for _, vol := range profile.Volunteering {
+		localized.Volunteering = append(localized.Volunteering, &periodVolunteering{
+			Volunteering: vol,
+			Period:       formatPeriod(convention, vol.StartDate, vol.EndDate, vol.IsCurrent, language),
+		})
+	}
+
+	return localized
+}
+
+// applyConvention makes the parts of a generated resume that come from the
+// profile follow the language and convention, so they don't depend on how
+// the model wrote them:
+//   - experience and education periods are formatted from the profile dates
+//   - chronological conventions list those entries oldest first
+//   - personal_details holds the profile's personal details the convention
+//     shows, and is dropped for conventions without them
+//
+// Entries are matched to the profile on company and position, or
+// institution and degree; entries the model renamed keep their period and
+// go last when reordered.
+func applyConvention(content map[string]interface{}, profile *ProfileData, language string, convention *conventions.Convention) {
+	applyConventionFrom(content, content, profile, language, convention)
+}
+
+// applyConventionFrom is applyConvention for a copy of source with the same
+// structure, such as its translation, whose company names and positions may
+// no longer match the profile: each entry of content is matched through the
+// entry of source at the same index.
+func applyConventionFrom(content, source map[string]interface{}, profile *ProfileData, language string, convention *conventions.Convention) {
+	experiences := make(map[string]datedEntry, len(profile.Experiences))
+	for _, exp := range profile.Experiences {
+		experiences[periodKey(exp.Company, exp.Position)] = datedEntry{
+			start:  exp.StartDate.Time,
+			period: formatPeriod(convention, exp.StartDate, exp.EndDate, exp.IsCurrent, language),
+		}
+	}
+	education := make(map[string]datedEntry, len(profile.Education))
+	for _, edu := range profile.Education {
+		education[periodKey(edu.Institution, edu.Degree)] = datedEntry{
+			start:  edu.StartDate.Time,
+			period: formatPeriod(convention, edu.StartDate, edu.EndDate, false, language),
+		}
+	}
+
+	setPeriods(content["experience"], entryKeys(source["experience"], "company", "position"), experiences, convention.Chronological)
+	setPeriods(content["education"], entryKeys(source["education"], "institution", "degree"), education, convention.Chronological)
+
+	if details := personalDetails(profile, convention); details != nil {
+		content["personal_details"] = details
+	} else {
+		delete(content, "personal_details")
+	}
+}
+
+// datedEntry is what applyConvention knows about a profile entry
+type datedEntry struct {
+	start  time.Time
+	period string
+}
+
+// entryKeys returns the periodKey of the two name fields of each entry of a
+// section, "" for entries that aren't objects
+func entryKeys(section interface{}, first, second string) []string {
+	list, _ := section.([]interface{})
+	keys := make([]string, len(list))
+	for i, e := range list {
+		if entry, ok := e.(map[string]interface{}); ok {
+			a, _ := entry[first].(string)
+			b, _ := entry[second].(string)
+			keys[i] = periodKey(a, b)
+		}
+	}
+	return keys
+}
+
+// setPeriods sets the period of each entry of a section whose key, by index,
+// is a key of entries, and sorts the section oldest first if chronological
+// is set
+func setPeriods(section interface{}, keys []string, entries map[string]datedEntry, chronological bool) {
+	list, ok := section.([]interface{})
+	if !ok {
+		return
+	}
+
+	starts := make(map[int]time.Time, len(list))
+	for i, e := range list {
+		entry, ok := e.(map[string]interface{})
+		if !ok || i >= len(keys) {
+			continue
+		}
+		dated, ok := entries[keys[i]]
+		if !ok {
+			continue
+		}
+		if dated.period != "" {
+			entry["period"] = dated.period
+		}
+		starts[i] = dated.start
+	}
+
+	if !chronological {
+		return
+	}
+
+	order := make([]int, len(list))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, aok := starts[order[i]]
+		b, bok := starts[order[j]]
+		if aok != bok {
+			return aok
+		}
+		return a.Before(b)
+	})
+
+	sorted := make([]interface{}, len(list))
+	for i, index := range order {
+		sorted[i] = list[index]
+	}
+	copy(list, sorted)
+}
+
+// personalDetails returns the profile's personal details the convention
+// shows, or nil if it shows none or the profile has none of them
+func personalDetails(profile *ProfileData, convention *conventions.Convention) map[string]interface{} {
+	details := make(map[string]interface{})
+	if convention.DateOfBirth && profile.DateOfBirth.Valid {
+		details["date_of_birth"] = convention.FormatDate(profile.DateOfBirth.Time)
+	}
+	if convention.Nationality && profile.Nationality != "" {
+		details["nationality"] = profile.Nationality
+	}
+	if convention.Photo && profile.PhotoURL != "" {
+		details["photo_url"] = profile.PhotoURL
+	}
+
+	if len(details) == 0 {
+		return nil
+	}
+	return details
+}
+
+func periodKey(a, b string) string {
+	return strings.ToLower(strings.TrimSpace(a)) + "\x00" + strings.ToLower(strings.TrimSpace(b))
+}
+
+// formatPeriod formats profile dates; a missing end date means ongoing
+func formatPeriod(convention *conventions.Convention, start models.Date, end models.NullDate, current bool, language string) string {
+	return convention.FormatPeriod(start.Time, end.Time, current || !end.Valid, language)
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/conventions"
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+)
+
+func periodProfile() *ProfileData {
+	date := func(year int, month time.Month) models.Date {
+		return models.Date{Time: time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)}
+	}
+	return &ProfileData{
+		FullName: "Jo",
+		Experiences: []*models.Experience{
+			{Company: "Acme", Position: "Engineer", StartDate: date(2020, time.January), EndDate: models.NullDate{NullTime: sql.NullTime{Time: date(2022, time.March).Time, Valid: true}}},
+			{Company: "Globex", Position: "Lead", StartDate: date(2022, time.April), IsCurrent: true},
+		},
+		Education: []*models.Education{
+			{Institution: "MSU", Degree: "BSc", StartDate: date(2015, time.September), EndDate: models.NullDate{NullTime: sql.NullTime{Time: date(2019, time.June).Time, Valid: true}}},
+		},
+	}
+}
+
+func TestLocalizeProfile(t *testing.T) {
+	data, err := json.Marshal(localizeProfile(periodProfile(), "ru", conventions.Lookup("")))
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	for _, want := range []string{
+		`"full_name":"Jo"`,
+		`"company":"Acme","position":"Engineer"`,
+		`"period":"янв. 2020 – март 2022"`,
+		`"period":"апр. 2022 – настоящее время"`,
+		`"period":"сент. 2015 – июнь 2019"`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("profile JSON has no %s:\n%s", want, data)
+		}
+	}
+	if strings.Count(string(data), `"experiences"`) != 1 {
+		t.Errorf("experiences listed twice:\n%s", data)
+	}
+}
+
+func TestApplyConvention(t *testing.T) {
+	content := map[string]interface{}{
+		"experience": []interface{}{
+			map[string]interface{}{"company": "Globex", "position": "Lead", "period": "2022 - now"},
+			map[string]interface{}{"company": " acme", "position": "ENGINEER", "period": "2020-2022"},
+			map[string]interface{}{"company": "Initech", "position": "Intern", "period": "2019"},
+		},
+		"education": []interface{}{
+			map[string]interface{}{"institution": "MSU", "degree": "BSc", "period": "2015 - 2019"},
+		},
+	}
+
+	applyConvention(content, periodProfile(), "de", conventions.Lookup("us"))
+
+	experience := content["experience"].([]interface{})
+	tests := []struct {
+		entry interface{}
+		want  string
+	}{
+		{experience[0], "Apr. 2022 – heute"},
+		{experience[1], "Jan. 2020 – März 2022"},
+		{experience[2], "2019"}, // not in the profile, kept
+		{content["education"].([]interface{})[0], "Sept. 2015 – Juni 2019"},
+	}
+	for _, tt := range tests {
+		if got := tt.entry.(map[string]interface{})["period"]; got != tt.want {
+			t.Errorf("period = %q, want %q", got, tt.want)
+		}
+	}
+
+	// Content without the sections is left alone
+	applyConvention(map[string]interface{}{"summary": "Engineer"}, periodProfile(), "de", conventions.Lookup("us"))
+}
+
+func TestApplyConventionFrom(t *testing.T) {
+	source := map[string]interface{}{
+		"experience": []interface{}{
+			map[string]interface{}{"company": "Globex", "position": "Lead", "period": "Apr 2022 – present"},
+			map[string]interface{}{"company": "Acme", "position": "Engineer", "period": "Jan 2020 – Mar 2022"},
+		},
+		"education": []interface{}{
+			map[string]interface{}{"institution": "MSU", "degree": "BSc", "period": "Sep 2015 – Jun 2019"},
+		},
+	}
+	// The translation renamed every entry, so none matches the profile by name
+	translated := map[string]interface{}{
+		"experience": []interface{}{
+			map[string]interface{}{"company": "Globex", "position": "Leiter", "period": "Apr 2022 – present"},
+			map[string]interface{}{"company": "Acme", "position": "Ingenieur", "period": "Jan 2020 – Mar 2022"},
+		},
+		"education": []interface{}{
+			map[string]interface{}{"institution": "Staatliche Universität Moskau", "degree": "Bachelor", "period": "Sep 2015 – Jun 2019"},
+		},
+	}
+
+	applyConventionFrom(translated, source, periodProfile(), "de", conventions.Lookup("jp"))
+
+	experience := translated["experience"].([]interface{})
+	tests := []struct {
+		entry    interface{}
+		position string
+		want     string
+	}{
+		{experience[0], "Ingenieur", "2020/01 – 2022/03"}, // oldest first
+		{experience[1], "Leiter", "2022/04 – heute"},
+		{translated["education"].([]interface{})[0], "", "2015/09 – 2019/06"},
+	}
+	for _, tt := range tests {
+		entry := tt.entry.(map[string]interface{})
+		if entry["period"] != tt.want || (tt.position != "" && entry["position"] != tt.position) {
+			t.Errorf("entry = %v, want %s with period %q", entry, tt.position, tt.want)
+		}
+	}
+	if got := source["experience"].([]interface{})[0].(map[string]interface{})["period"]; got != "Apr 2022 – present" {
+		t.Errorf("source changed: period = %q", got)
+	}
+}
+
+func TestApplyConventionPersonalDetails(t *testing.T) {
+	profile := periodProfile()
+	profile.DateOfBirth = models.NullDate{NullTime: sql.NullTime{Time: time.Date(1990, time.May, 14, 0, 0, 0, 0, time.UTC), Valid: true}}
+	profile.Nationality = "German"
+
+	content := map[string]interface{}{
+		"experience": []interface{}{
+			map[string]interface{}{"company": "Globex", "position": "Lead"},
+			map[string]interface{}{"company": "Initech", "position": "Intern"},
+			map[string]interface{}{"company": "Acme", "position": "Engineer"},
+		},
+	}
+	applyConvention(content, profile, "de", conventions.Lookup("de"))
+
+	details, _ := content["personal_details"].(map[string]interface{})
+	if details["date_of_birth"] != "14.05.1990" || details["nationality"] != "German" || details["photo_url"] != nil {
+		t.Errorf("personal_details = %v", content["personal_details"])
+	}
+	if got := content["experience"].([]interface{})[0].(map[string]interface{})["period"]; got != "04.2022 – heute" {
+		t.Errorf("period = %q", got)
+	}
+
+	// A rirekisho lists history oldest first, unknown entries last
+	applyConvention(content, profile, "en", conventions.Lookup("jp"))
+	var order []string
+	for _, e := range content["experience"].([]interface{}) {
+		order = append(order, e.(map[string]interface{})["company"].(string))
+	}
+	if strings.Join(order, ",") != "Acme,Globex,Initech" {
+		t.Errorf("jp order = %v", order)
+	}
+	if got := content["experience"].([]interface{})[0].(map[string]interface{})["period"]; got != "2020/01 – 2022/03" {
+		t.Errorf("jp period = %q", got)
+	}
+
+	// US résumés carry no personal details, even if the model added them
+	applyConvention(content, profile, "en", conventions.Lookup("us"))
+	if _, ok := content["personal_details"]; ok {
+		t.Errorf("us personal_details = %v", content["personal_details"])
+	}
+	if data, _ := json.Marshal(localizeProfile(profile, "en", conventions.Lookup("us"))); strings.Contains(string(data), "German") {
+		t.Errorf("us prompt profile has personal details:\n%s", data)
+	}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/mailer"
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/google/uuid"
+)
+
+// Notifier delivers a reminder to its user
+type Notifier interface {
+	Notify(ctx context.Context, user *models.User, reminder *models.Reminder) error
+}
+
+// InAppNotifier leaves reminders in the in-app list, GET /reminders, which
+// shows every stored reminder. It never fails, so it never holds up the other
+// channels of a MultiNotifier.
+type InAppNotifier struct{}
+
+func (InAppNotifier) Notify(ctx context.Context, user *models.User, reminder *models.Reminder) error {
+	return nil
+}
+
+// EmailNotifier emails reminders to the user's address
+type EmailNotifier struct {
+	mailer mailer.Mailer
+}
+
+func NewEmailNotifier(m mailer.Mailer) *EmailNotifier {
+	return &EmailNotifier{mailer: m}
+}
+
+func (n *EmailNotifier) Notify(ctx context.Context, user *models.User, reminder *models.Reminder) error {
+	body := fmt.Sprintf("Hi %s,\n\n%s\n\nYou can dismiss this reminder in your application tracker.\n", user.FullName, reminder.Message)
+	return n.mailer.Send(user.Email, "Follow-up reminder", body)
+}
+
+// WebhookNotifier posts reminders as JSON to a URL, such as a chat or
+// automation service. Any 2xx response counts as delivered.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// webhookPayload is the body WebhookNotifier posts
+type webhookPayload struct {
+	Type      string           `json:"type"`
+	UserEmail string           `json:"user_email"`
+	Reminder  *models.Reminder `json:"reminder"`
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, user *models.User, reminder *models.Reminder) error {
+	payload, err := json.Marshal(webhookPayload{Type: "follow_up_reminder", UserEmail: user.Email, Reminder: reminder})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Channel is a notifier under the name its deliveries are recorded by
+type Channel struct {
+	Name     string
+	Notifier Notifier
+}
+
+// ReminderDeliveries records which channels each reminder went out on;
+// repository.ReminderRepository keeps them in Postgres
+type ReminderDeliveries interface {
+	GetReminderChannels(reminderID uuid.UUID) ([]string, error)
+	MarkChannelDelivered(reminderID uuid.UUID, channel string) error
+}
+
+// MultiNotifier delivers through every channel it holds. Deliveries are
+// recorded per channel, so a reminder is sent again only through the
+// channels that failed. It fails while any channel hasn't delivered.
+type MultiNotifier struct {
+	channels   []Channel
+	deliveries ReminderDeliveries
+}
+
+func NewMultiNotifier(deliveries ReminderDeliveries, channels ...Channel) *MultiNotifier {
+	return &MultiNotifier{channels: channels, deliveries: deliveries}
+}
+
+func (m *MultiNotifier) Notify(ctx context.Context, user *models.User, reminder *models.Reminder) error {
+	delivered, err := m.deliveries.GetReminderChannels(reminder.ID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, channel := range m.channels {
+		if slices.Contains(delivered, channel.Name) {
+			continue
+		}
+		if err := channel.Notifier.Notify(ctx, user, reminder); err != nil {
+			log.Printf("Failed to deliver reminder %s through %s: %v", reminder.ID, channel.Name, err)
+			errs = append(errs, fmt.Errorf("%s: %w", channel.Name, err))
+			continue
+		}
+		if err := m.deliveries.MarkChannelDelivered(reminder.ID, channel.Name); err != nil {
+			return err
+		}
+	}
+
+	return errors.Join(errs...)
+}
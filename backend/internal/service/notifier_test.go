@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	user := &models.User{ID: uuid.New(), Email: "jo@example.com"}
+	reminder := &models.Reminder{ID: uuid.New(), UserID: user.ID, Message: "Follow up with Acme"}
+
+	var got webhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("request = %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if err := NewWebhookNotifier(server.URL).Notify(context.Background(), user, reminder); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if got.Type != "follow_up_reminder" || got.UserEmail != user.Email || got.Reminder == nil || got.Reminder.ID != reminder.ID {
+		t.Errorf("payload = %+v", got)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+
+	if err := NewWebhookNotifier(failing.URL).Notify(context.Background(), user, reminder); err == nil {
+		t.Error("Notify with a 502 response succeeded, want an error")
+	}
+}
+
+type notifierFunc func() error
+
+func (f notifierFunc) Notify(ctx context.Context, user *models.User, reminder *models.Reminder) error {
+	return f()
+}
+
+// memoryDeliveries is ReminderDeliveries in a map
+type memoryDeliveries map[uuid.UUID][]string
+
+func (d memoryDeliveries) GetReminderChannels(reminderID uuid.UUID) ([]string, error) {
+	return d[reminderID], nil
+}
+
+func (d memoryDeliveries) MarkChannelDelivered(reminderID uuid.UUID, channel string) error {
+	d[reminderID] = append(d[reminderID], channel)
+	return nil
+}
+
+func TestMultiNotifier(t *testing.T) {
+	user := &models.User{}
+	reminder := &models.Reminder{ID: uuid.New()}
+
+	sent := map[string]int{}
+	emailDown := true
+	channel := func(name string, down *bool) Channel {
+		return Channel{Name: name, Notifier: notifierFunc(func() error {
+			if down != nil && *down {
+				return errors.New("unreachable")
+			}
+			sent[name]++
+			return nil
+		})}
+	}
+	deliveries := memoryDeliveries{}
+	notifier := NewMultiNotifier(deliveries, channel("in_app", nil), channel("email", &emailDown), channel("webhook", nil))
+
+	// A failing channel fails the delivery even though the others worked
+	if err := notifier.Notify(context.Background(), user, reminder); err == nil {
+		t.Fatal("Notify with the email channel down succeeded, want an error")
+	}
+	if want := []string{"in_app", "webhook"}; !reflect.DeepEqual(deliveries[reminder.ID], want) {
+		t.Errorf("delivered channels = %v, want %v", deliveries[reminder.ID], want)
+	}
+
+	// The retry only goes through the channel that failed
+	emailDown = false
+	if err := notifier.Notify(context.Background(), user, reminder); err != nil {
+		t.Fatalf("retry Notify: %v", err)
+	}
+	if want := map[string]int{"in_app": 1, "email": 1, "webhook": 1}; !reflect.DeepEqual(sent, want) {
+		t.Errorf("sent = %v, want %v", sent, want)
+	}
+}
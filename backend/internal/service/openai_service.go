@@ -3,10 +3,19 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/feijoa-master/ai-resume-builder/internal/conventions"
+	"github.com/feijoa-master/ai-resume-builder/internal/experiments"
+	"github.com/feijoa-master/ai-resume-builder/internal/i18n"
 	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/pricing"
+	"github.com/feijoa-master/ai-resume-builder/internal/prompts"
+	"github.com/google/uuid"
 	"github.com/sashabaranov/go-openai"
 )
 
@@ -15,187 +24,490 @@ type OpenAIService struct {
 	model       string
 	maxTokens   int
 	temperature float32
+	prompts     *prompts.Registry
+	cache       ResponseCache // nil turns caching off
+	cacheTTL    time.Duration
+	resilience  Resilience
+	clock       Clock
+	sleep       func(time.Duration)
+
+	breakersMu sync.Mutex
+	breakers   map[string]*CircuitBreaker // by model
 }
 
-func NewOpenAIService(apiKey, model string, maxTokens int, temperature float64) *OpenAIService {
+// NewOpenAIService creates the model client; responses are cached for
+// cacheTTL unless cache is nil
+func NewOpenAIService(apiKey, model string, maxTokens int, temperature float64, registry *prompts.Registry, cache ResponseCache, cacheTTL time.Duration, resilience Resilience) *OpenAIService {
 	client := openai.NewClient(apiKey)
 	return &OpenAIService{
 		client:      client,
 		model:       model,
 		maxTokens:   maxTokens,
 		temperature: float32(temperature),
+		prompts:     registry,
+		cache:       cache,
+		cacheTTL:    cacheTTL,
+		resilience:  resilience,
+		clock:       SystemClock{},
+		sleep:       time.Sleep,
+		breakers:    make(map[string]*CircuitBreaker),
 	}
 }
 
+// PromptOptions selects the prompt variant for a generation
+type PromptOptions struct {
+	TemplateID string // document template, e.g. classic
+	Locale     string // output language tag, e.g. ru; English if empty
+	Convention string // regional resume convention, e.g. de; the default if empty
+	// Experiment is the user's experiment variant, whose prompt and model
+	// replace the usual ones
+	Experiment *experiments.Assignment
+	// Fresh asks the model again instead of answering from the response
+	// cache; the new response replaces the cached one
+	Fresh bool
+}
+
+// promptData is what the prompt templates render
+type promptData struct {
+	Profile          string // candidate profile as JSON
+	JobTitle         string
+	JobDescription   string
+	KeyRequirements  string // formatJobAnalysis section, empty when nothing was extracted
+	OptionalSections string // formatOptionalSections JSON fragment
+	CompanyName      string
+	DocType          string // "resume" or "cover letter"
+	Document         string // current document as JSON
+	Section          string
+	CurrentSection   string // current section content as JSON
+	Instructions     string
+	Language         string // English name of the output language, empty for the default
+	Convention       string // rules of the regional convention, conventions.Instructions
+}
+
 // GenerateResume generates a resume based on profile and job description
-func (s *OpenAIService) GenerateResume(profile *ProfileData, jobDescription string) (*GeneratedDocument, error) {
-	prompt := s.buildResumePrompt(profile, jobDescription)
+func (s *OpenAIService) GenerateResume(profile *ProfileData, jobDescription string, analysis *models.JobAnalysis, opts PromptOptions) (*GeneratedDocument, error) {
+	data := &promptData{
+		Profile:          marshalPromptJSON(localizeProfile(profile, opts.Locale, conventions.Lookup(opts.Convention))),
+		JobDescription:   jobDescription,
+		KeyRequirements:  formatJobAnalysis(analysis),
+		OptionalSections: formatOptionalSections(profile),
+		Language:         i18n.Name(opts.Locale),
+		Convention:       conventions.Lookup(opts.Convention).Instructions(),
+	}
 
-	startTime := time.Now()
-	response, err := s.client.CreateChatCompletion(
-		context.Background(),
-		openai.ChatCompletionRequest{
-			Model:       s.model,
-			Temperature: s.temperature,
-			MaxTokens:   s.maxTokens,
-			Messages: []openai.ChatCompletionMessage{
-				{
-					Role:    openai.ChatMessageRoleSystem,
-					Content: "You are a professional resume writer with 10+ years of experience. Create ATS-friendly, impactful resumes that highlight candidates' strengths.",
-				},
-				{
-					Role:    openai.ChatMessageRoleUser,
-					Content: prompt,
-				},
-			},
+	generated, err := s.complete(prompts.Resume, opts, data, s.maxTokens)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate resume: %w", err)
+	}
+
+	return generated, nil
+}
+
+// GenerateCoverLetter generates a cover letter based on profile and job description
+func (s *OpenAIService) GenerateCoverLetter(profile *ProfileData, jobDescription, companyName string, analysis *models.JobAnalysis, opts PromptOptions) (*GeneratedDocument, error) {
+	data := &promptData{
+		Profile:         marshalPromptJSON(localizeProfile(profile, opts.Locale, conventions.Lookup(opts.Convention))),
+		JobDescription:  jobDescription,
+		KeyRequirements: formatJobAnalysis(analysis),
+		CompanyName:     companyName,
+		Language:        i18n.Name(opts.Locale),
+		Convention:      conventions.Lookup(opts.Convention).Instructions(),
+	}
+
+	generated, err := s.complete(prompts.CoverLetter, opts, data, 800) // Cover letters are shorter
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate cover letter: %w", err)
+	}
+
+	return generated, nil
+}
+
+// AnalyzeJobDescription asks the model for the same structure the
+// deterministic analyzer produces, to catch what text processing missed
+func (s *OpenAIService) AnalyzeJobDescription(jobTitle, jobDescription string, opts PromptOptions) (*GeneratedDocument, error) {
+	data := &promptData{
+		JobTitle:       jobTitle,
+		JobDescription: jobDescription,
+	}
+
+	generated, err := s.complete(prompts.AnalyzeJob, opts, data, s.maxTokens)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze job description: %w", err)
+	}
+
+	return generated, nil
+}
+
+// RegenerateSection rewrites a single section of an existing document,
+// using the rest of the document only as context
+func (s *OpenAIService) RegenerateSection(profile *ProfileData, jobDescription, docType string, document map[string]interface{}, section string, current interface{}, instructions string, opts PromptOptions) (*GeneratedDocument, error) {
+	if instructions == "" {
+		instructions = "None. Improve impact and relevance to the job description."
+	}
+
+	data := &promptData{
+		Profile:        marshalPromptJSON(localizeProfile(profile, opts.Locale, conventions.Lookup(opts.Convention))),
+		JobDescription: jobDescription,
+		DocType:        strings.ReplaceAll(docType, "_", " "),
+		Document:       marshalPromptJSON(document),
+		Section:        section,
+		CurrentSection: marshalPromptJSON(current),
+		Instructions:   instructions,
+		Language:       i18n.Name(opts.Locale),
+	}
+
+	generated, err := s.complete(prompts.Section, opts, data, s.maxTokens)
+	if err != nil {
+		return nil, fmt.Errorf("failed to regenerate section: %w", err)
+	}
+
+	return generated, nil
+}
+
+// TranslateDocument translates the text of a document into the language of
+// opts.Locale, keeping its JSON structure
+func (s *OpenAIService) TranslateDocument(docType string, document map[string]interface{}, opts PromptOptions) (*GeneratedDocument, error) {
+	data := &promptData{
+		DocType:  strings.ReplaceAll(docType, "_", " "),
+		Document: marshalPromptJSON(document),
+		Language: i18n.Name(opts.Locale),
+	}
+
+	generated, err := s.complete(prompts.Translate, opts, data, s.maxTokens)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate document: %w", err)
+	}
+
+	return generated, nil
+}
+
+// RefineDocument applies a chat instruction to the whole document. Earlier
+// turns of the conversation are replayed so follow-ups like "shorter still" work.
+func (s *OpenAIService) RefineDocument(profile *ProfileData, jobDescription, docType string, document map[string]interface{}, history []*models.DocumentMessage, instruction string, opts PromptOptions) (*GeneratedDocument, error) {
+	prompt, err := s.lookupPrompt(prompts.Refine, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refine document: %w", err)
+	}
+
+	data := &promptData{
+		Profile:        marshalPromptJSON(localizeProfile(profile, opts.Locale, conventions.Lookup(opts.Convention))),
+		JobDescription: jobDescription,
+		DocType:        strings.ReplaceAll(docType, "_", " "),
+		Document:       marshalPromptJSON(document),
+		Instructions:   instruction,
+		Language:       i18n.Name(opts.Locale),
+	}
+
+	// The system prompt, the context and the latest instruction are rendered
+	// from the prompt file, the turns in between come from the chat history
+	rendered := make(map[string]string, 3)
+	for _, part := range []string{"system", "context", "user"} {
+		if rendered[part], err = prompt.Render(part, data); err != nil {
+			return nil, fmt.Errorf("failed to refine document: %w", err)
+		}
+	}
+
+	messages := []openai.ChatCompletionMessage{
+		{Role: openai.ChatMessageRoleSystem, Content: rendered["system"]},
+		{Role: openai.ChatMessageRoleUser, Content: rendered["context"]},
+	}
+
+	for _, msg := range history {
+		role := openai.ChatMessageRoleUser
+		if msg.Role == "assistant" {
+			role = openai.ChatMessageRoleAssistant
+		}
+		messages = append(messages, openai.ChatCompletionMessage{Role: role, Content: msg.Content})
+	}
+
+	messages = append(messages, openai.ChatCompletionMessage{Role: openai.ChatMessageRoleUser, Content: rendered["user"]})
+
+	generated, err := s.chat(prompt, opts, messages, s.maxTokens)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refine document: %w", err)
+	}
+
+	return generated, nil
+}
+
+// complete renders the system and user prompts of a kind and sends them to
+// the chat completion API, recording which prompt version was used
+func (s *OpenAIService) complete(kind string, opts PromptOptions, data *promptData, maxTokens int) (*GeneratedDocument, error) {
+	prompt, err := s.lookupPrompt(kind, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	systemPrompt, err := prompt.Render("system", data)
+	if err != nil {
+		return nil, err
+	}
+	userPrompt, err := prompt.Render("user", data)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.chat(prompt, opts, []openai.ChatCompletionMessage{
+		{
+			Role:    openai.ChatMessageRoleSystem,
+			Content: systemPrompt,
 		},
-	)
+		{
+			Role:    openai.ChatMessageRoleUser,
+			Content: userPrompt,
+		},
+	}, maxTokens)
+}
 
+// chat sends rendered messages of a prompt to the model, answering from the
+// response cache when the same request was made within the cache TTL. Cache
+// failures are logged and the model is asked instead.
+func (s *OpenAIService) chat(prompt *prompts.Prompt, opts PromptOptions, messages []openai.ChatCompletionMessage, maxTokens int) (*GeneratedDocument, error) {
+	model := s.modelFor(opts)
+
+	var key string
+	if s.cache != nil {
+		key = responseCacheKey(model, prompt.Version(), s.temperature, maxTokens, messages)
+		if !opts.Fresh {
+			if generated := s.cached(key); generated != nil {
+				generated.CacheKey = key
+				generated.Model = model
+				generated.PromptVersion = prompt.Version()
+				generated.setExperiment(opts)
+				return generated, nil
+			}
+		}
+	}
+
+	generated, err := s.createChatCompletion(model, messages, maxTokens)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate resume: %w", err)
+		return nil, err
 	}
+	generated.PromptVersion = prompt.Version()
+	generated.setExperiment(opts)
+
+	// A fallback model's response isn't kept under the requested model
+	if s.cache != nil && generated.Model == model {
+		generated.CacheKey = key
+		value, _ := json.Marshal(&cachedResponse{Content: generated.Content})
+		if err := s.cache.Set(key, value, s.cacheTTL); err != nil {
+			fmt.Printf("Failed to cache model response: %v\n", err)
+		}
+	}
+
+	return generated, nil
+}
 
-	generationTime := time.Since(startTime).Milliseconds()
+// cached returns the cached response under key as a generation that used
+// no tokens, or nil if there is none
+func (s *OpenAIService) cached(key string) *GeneratedDocument {
+	value, ok, err := s.cache.Get(key)
+	if err != nil {
+		fmt.Printf("Failed to read cached model response: %v\n", err)
+		return nil
+	}
+	if !ok {
+		return nil
+	}
 
-	// Parse the generated content
-	content := response.Choices[0].Message.Content
+	var response cachedResponse
+	if err := json.Unmarshal(value, &response); err != nil {
+		fmt.Printf("Failed to read cached model response: %v\n", err)
+		return nil
+	}
 
-	return &GeneratedDocument{
-		Content:          content,
-		PromptTokens:     response.Usage.PromptTokens,
-		CompletionTokens: response.Usage.CompletionTokens,
-		TotalTokens:      response.Usage.TotalTokens,
-		GenerationTimeMs: int(generationTime),
-		Model:            s.model,
-	}, nil
+	return &GeneratedDocument{Content: response.Content, Provider: pricing.OpenAI, Cached: true}
 }
 
-// GenerateCoverLetter generates a cover letter based on profile and job description
-func (s *OpenAIService) GenerateCoverLetter(profile *ProfileData, jobDescription, companyName string) (*GeneratedDocument, error) {
-	prompt := s.buildCoverLetterPrompt(profile, jobDescription, companyName)
+// lookupPrompt returns the prompt forced by the experiment variant, if any,
+// else the best match for the template and locale
+func (s *OpenAIService) lookupPrompt(kind string, opts PromptOptions) (*prompts.Prompt, error) {
+	if opts.Experiment != nil && opts.Experiment.Variant.Prompt != "" {
+		return s.prompts.Get(opts.Experiment.Variant.Prompt)
+	}
+	return s.prompts.Lookup(kind, opts.TemplateID, opts.Locale)
+}
+
+// modelFor returns the model forced by the experiment variant, if any
+func (s *OpenAIService) modelFor(opts PromptOptions) string {
+	if opts.Experiment != nil && opts.Experiment.Variant.Model != "" {
+		return opts.Experiment.Variant.Model
+	}
+	return s.model
+}
+
+// marshalPromptJSON renders a value as indented JSON for a prompt
+func marshalPromptJSON(v interface{}) string {
+	data, _ := json.MarshalIndent(v, "", "  ")
+	return string(data)
+}
 
+// createChatCompletion sends a full conversation to the chat completion API,
+// moving on to the next model of the fallback chain when one keeps failing
+// with transient errors
+func (s *OpenAIService) createChatCompletion(model string, messages []openai.ChatCompletionMessage, maxTokens int) (*GeneratedDocument, error) {
+	var lastErr error
+	for _, candidate := range s.resilience.modelChain(model) {
+		generated, err := s.tryModel(candidate, messages, maxTokens)
+		if err == nil {
+			return generated, nil
+		}
+		if !transient(err) && !errors.Is(err, errCircuitOpen) {
+			return nil, err
+		}
+		lastErr = err
+	}
+
+	return nil, fmt.Errorf("%w: %w", ErrModelUnavailable, lastErr)
+}
+
+// tryModel sends a conversation to one model, retrying transient errors with
+// backoff while the model's circuit breaker allows, and records token usage
+// and timing
+func (s *OpenAIService) tryModel(model string, messages []openai.ChatCompletionMessage, maxTokens int) (*GeneratedDocument, error) {
+	breaker := s.breaker(model)
 	startTime := time.Now()
-	response, err := s.client.CreateChatCompletion(
-		context.Background(),
-		openai.ChatCompletionRequest{
-			Model:       s.model,
-			Temperature: s.temperature,
-			MaxTokens:   800, // Cover letters are shorter
-			Messages: []openai.ChatCompletionMessage{
-				{
-					Role:    openai.ChatMessageRoleSystem,
-					Content: "You are an expert cover letter writer. Create compelling, personalized cover letters that showcase the candidate's fit for the role.",
-				},
-				{
-					Role:    openai.ChatMessageRoleUser,
-					Content: prompt,
-				},
+
+	for retry := 0; ; retry++ {
+		if !breaker.Allow() {
+			return nil, fmt.Errorf("%s: %w", model, errCircuitOpen)
+		}
+
+		response, err := s.client.CreateChatCompletion(
+			context.Background(),
+			openai.ChatCompletionRequest{
+				Model:       model,
+				Temperature: s.temperature,
+				MaxTokens:   maxTokens,
+				Messages:    messages,
 			},
-		},
-	)
+		)
+
+		if err != nil {
+			if !transient(err) {
+				// The model is up and rejected this request
+				breaker.Success()
+				return nil, err
+			}
+			breaker.Failure()
+			if retry >= s.resilience.MaxRetries {
+				return nil, err
+			}
+
+			delay := s.resilience.backoff(retry)
+			if wait := retryAfter(response.Header(), s.clock.Now()); wait > 0 {
+				if wait > s.resilience.MaxDelay {
+					return nil, err
+				}
+				delay = wait
+			}
+			s.sleep(delay)
+			continue
+		}
+		breaker.Success()
+
+		if len(response.Choices) == 0 {
+			return nil, errors.New("empty response from model")
+		}
+
+		generationTime := time.Since(startTime).Milliseconds()
+
+		// Parse the generated content
+		content := response.Choices[0].Message.Content
+
+		generated := &GeneratedDocument{
+			Content:          content,
+			PromptTokens:     response.Usage.PromptTokens,
+			CompletionTokens: response.Usage.CompletionTokens,
+			TotalTokens:      response.Usage.TotalTokens,
+			GenerationTimeMs: int(generationTime),
+			Provider:         pricing.OpenAI,
+			Model:            model,
+		}
+		if details := response.Usage.PromptTokensDetails; details != nil {
+			generated.CachedTokens = details.CachedTokens
+		}
+
+		return generated, nil
+	}
+}
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate cover letter: %w", err)
+// breaker returns the circuit breaker of a model
+func (s *OpenAIService) breaker(model string) *CircuitBreaker {
+	s.breakersMu.Lock()
+	defer s.breakersMu.Unlock()
+
+	breaker, ok := s.breakers[model]
+	if !ok {
+		breaker = NewCircuitBreaker(s.resilience.BreakerThreshold, s.resilience.BreakerCooldown, s.clock)
+		s.breakers[model] = breaker
 	}
+	return breaker
+}
 
-	generationTime := time.Since(startTime).Milliseconds()
-
-	content := response.Choices[0].Message.Content
-
-	return &GeneratedDocument{
-		Content:          content,
-		PromptTokens:     response.Usage.PromptTokens,
-		CompletionTokens: response.Usage.CompletionTokens,
-		TotalTokens:      response.Usage.TotalTokens,
-		GenerationTimeMs: int(generationTime),
-		Model:            s.model,
-	}, nil
-}
-
-// buildResumePrompt creates the prompt for resume generation
-func (s *OpenAIService) buildResumePrompt(profile *ProfileData, jobDescription string) string {
-	profileJSON, _ := json.MarshalIndent(profile, "", "  ")
-
-	prompt := fmt.Sprintf(`Generate a professional, ATS-friendly resume based on the following candidate profile and job description.
-
-CANDIDATE PROFILE:
-%s
-
-JOB DESCRIPTION:
-%s
-
-REQUIREMENTS:
-1. Create a strong professional summary (3-4 sentences) that highlights key qualifications
-2. List relevant work experience with bullet points focusing on achievements and impact
-3. Include education and relevant skills
-4. Use action verbs and quantify achievements where possible
-5. Tailor the content to match the job requirements
-6. Keep it concise and professional
-7. Format in clean, readable sections
-
-Return the resume in JSON format with the following structure:
-{
-  "summary": "Professional summary paragraph",
-  "experience": [
-    {
-      "company": "Company Name",
-      "position": "Job Title",
-      "period": "Start - End",
-      "highlights": ["Achievement 1", "Achievement 2", "Achievement 3"]
-    }
-  ],
-  "education": [
-    {
-      "institution": "School Name",
-      "degree": "Degree",
-      "period": "Start - End"
-    }
-  ],
-  "skills": {
-    "technical": ["skill1", "skill2"],
-    "soft": ["skill1", "skill2"]
-  }
-}`, profileJSON, jobDescription)
-
-	return prompt
-}
-
-// buildCoverLetterPrompt creates the prompt for cover letter generation
-func (s *OpenAIService) buildCoverLetterPrompt(profile *ProfileData, jobDescription, companyName string) string {
-	profileJSON, _ := json.MarshalIndent(profile, "", "  ")
-
-	prompt := fmt.Sprintf(`Generate a compelling cover letter based on the candidate profile and job description.
-
-CANDIDATE PROFILE:
-%s
-
-JOB DESCRIPTION:
-%s
-
-COMPANY NAME: %s
-
-REQUIREMENTS:
-1. Start with a strong opening that shows enthusiasm and fit
-2. Highlight 2-3 key qualifications that match the job requirements
-3. Show understanding of the company/role
-4. Demonstrate value the candidate brings
-5. Close with a call to action
-6. Keep it to 3-4 paragraphs
-7. Professional but engaging tone
-
-Return the cover letter in JSON format:
-{
-  "opening": "Opening paragraph",
-  "body1": "First body paragraph",
-  "body2": "Second body paragraph (if needed)",
-  "closing": "Closing paragraph"
-}`, profileJSON, jobDescription, companyName)
-
-	return prompt
+// optionalSectionFormats is the resume JSON of each optional profile section
+var optionalSectionFormats = []struct {
+	name    string
+	present func(profile *ProfileData) bool
+	format  string
+}{
+	{"projects", func(p *ProfileData) bool { return len(p.Projects) > 0 },
+		`"projects": [{"name": "Project", "role": "Role", "url": "https://...", "tech_stack": ["tech"], "highlights": ["What it does and its impact"]}]`},
+	{"certifications", func(p *ProfileData) bool { return len(p.Certifications) > 0 },
+		`"certifications": [{"name": "Certification", "issuer": "Issuer", "date": "Issued - Expires"}]`},
+	{"publications", func(p *ProfileData) bool { return len(p.Publications) > 0 },
+		`"publications": [{"title": "Title", "publisher": "Journal or conference", "date": "Year", "url": "https://..."}]`},
+	{"languages", func(p *ProfileData) bool { return len(p.Languages) > 0 },
+		`"languages": [{"name": "Language", "level": "Native, C1, B2, ..."}]`},
+	{"volunteering", func(p *ProfileData) bool { return len(p.Volunteering) > 0 },
+		`"volunteering": [{"organization": "Organization", "role": "Role", "period": "Start - End", "highlights": ["Contribution"]}]`},
+	{"awards", func(p *ProfileData) bool { return len(p.Awards) > 0 },
+		`"awards": [{"title": "Award", "issuer": "Issuer", "date": "Year"}]`},
+}
+
+// formatOptionalSections adds the JSON format of the optional sections the
+// profile has data for to the resume structure
+func formatOptionalSections(profile *ProfileData) string {
+	var b strings.Builder
+	for _, section := range optionalSectionFormats {
+		if section.present(profile) {
+			b.WriteString(",\n  ")
+			b.WriteString(section.format)
+		}
+	}
+	return b.String()
+}
+
+// formatJobAnalysis renders the extracted requirements as a prompt section,
+// or an empty string when nothing was extracted
+func formatJobAnalysis(analysis *models.JobAnalysis) string {
+	if analysis == nil {
+		return ""
+	}
+
+	var lines []string
+	if len(analysis.RequiredSkills) > 0 {
+		lines = append(lines, "Required skills: "+strings.Join(analysis.RequiredSkills, ", "))
+	}
+	if len(analysis.NiceToHaveSkills) > 0 {
+		lines = append(lines, "Nice-to-have skills: "+strings.Join(analysis.NiceToHaveSkills, ", "))
+	}
+	if analysis.Seniority != "" {
+		lines = append(lines, "Seniority: "+analysis.Seniority)
+	}
+	if len(analysis.Keywords) > 0 {
+		lines = append(lines, "Keywords: "+strings.Join(analysis.Keywords, ", "))
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return "\nKEY REQUIREMENTS (extracted from the job description; prioritise matching experience and use these terms where truthful):\n" + strings.Join(lines, "\n") + "\n"
 }
 
 // ProfileData represents the complete user profile for generation
 type ProfileData struct {
+	ProfileID   uuid.UUID            `json:"-"`
 	FullName    string               `json:"full_name"`
 	Email       string               `json:"email"`
 	Phone       string               `json:"phone,omitempty"`
@@ -204,9 +516,20 @@ type ProfileData struct {
 	GithubURL   string               `json:"github_url,omitempty"`
 	WebsiteURL  string               `json:"website_url,omitempty"`
 	Summary     string               `json:"summary,omitempty"`
+	DateOfBirth models.NullDate      `json:"-"` // shown per convention, see localizeProfile
+	Nationality string               `json:"-"`
+	PhotoURL    string               `json:"-"`
 	Experiences []*models.Experience `json:"experiences"`
 	Education   []*models.Education  `json:"education"`
 	Skills      []*models.Skill      `json:"skills"`
+
+	// Optional sections, limited by GenerateRequest.CustomSections
+	Projects       []*models.Project       `json:"projects,omitempty"`
+	Certifications []*models.Certification `json:"certifications,omitempty"`
+	Publications   []*models.Publication   `json:"publications,omitempty"`
+	Languages      []*models.Language      `json:"languages,omitempty"`
+	Volunteering   []*models.Volunteering  `json:"volunteering,omitempty"`
+	Awards         []*models.Award         `json:"awards,omitempty"`
 }
 
 // GeneratedDocument represents the result of AI generation
@@ -216,5 +539,20 @@ type GeneratedDocument struct {
 	CompletionTokens int
 	TotalTokens      int
 	GenerationTimeMs int
+	CachedTokens     int // prompt tokens served from the provider's prompt cache
+	Provider         string
 	Model            string
+	PromptVersion    string // prompt file and version, e.g. resume@1
+	Experiment       string // experiment the generation ran in, if any
+	Variant          string
+	Cached           bool   // answered from the response cache without calling the model
+	CacheKey         string // response cache key of the request, empty without a cache
+}
+
+// setExperiment records the experiment variant the generation ran with
+func (g *GeneratedDocument) setExperiment(opts PromptOptions) {
+	if opts.Experiment != nil {
+		g.Experiment = opts.Experiment.Experiment
+		g.Variant = opts.Experiment.Variant.Name
+	}
 }
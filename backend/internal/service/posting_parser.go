@@ -0,0 +1,648 @@
+package service
+
+import (
+	"encoding/json"
+	"html"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+)
+
+// Posting sources recognised by ParseJobPosting
+const (
+	PostingSourceLinkedIn   = "linkedin"
+	PostingSourceIndeed     = "indeed"
+	PostingSourceGreenhouse = "greenhouse"
+	PostingSourceLever      = "lever"
+	PostingSourceGeneric    = "generic"
+)
+
+// selector picks an HTML element by an attribute. For class the value must be
+// one of the classes, for other attributes it must match exactly.
+type selector struct {
+	attr  string
+	value string
+}
+
+// postingFormat describes how one job board lays out a posting, both as page
+// HTML and as the text copied from the rendered page
+type postingFormat struct {
+	source string
+	// Strong markers only the board's pages carry: urls are lowercase job
+	// URLs without the scheme, matched from the start of a host name;
+	// classes are prefixes of HTML class and id values; footers are whole
+	// lines of copied text. markers are lowercase phrases of the board's pages
+	// that other postings may use too.
+	urls    []string
+	classes []string
+	footers []string
+	markers []string
+
+	title       []selector
+	company     []selector
+	location    []selector
+	description []selector
+
+	// companyFromPageTitle reads the company from a "Company - Title" <title>
+	companyFromPageTitle bool
+
+	// textHeader reads the header of copied text and returns how many lines it used
+	textHeader func(lines []string, posting *models.ParsedJobPosting) int
+	// textStart are lines the description follows, textEnd lines it stops at
+	textStart []string
+	textEnd   []string
+}
+
+var postingFormats = []postingFormat{
+	{
+		source:      PostingSourceLinkedIn,
+		urls:        []string{"linkedin.com/jobs/"},
+		classes:     []string{"topcard__", "top-card-layout", "show-more-less-html"},
+		markers:     []string{"about the job", "easy apply", "applicants"},
+		title:       []selector{{"class", "top-card-layout__title"}, {"class", "topcard__title"}, {"class", "job-details-jobs-unified-top-card__job-title"}},
+		company:     []selector{{"class", "topcard__org-name-link"}, {"class", "job-details-jobs-unified-top-card__company-name"}},
+		location:    []selector{{"class", "topcard__flavor--bullet"}},
+		description: []selector{{"class", "show-more-less-html__markup"}, {"class", "description__text"}, {"id", "job-details"}},
+		textHeader:  linkedInTextHeader,
+		textStart:   []string{"about the job"},
+		textEnd:     []string{"seniority level", "employment type", "set alert for similar jobs", "about the company"},
+	},
+	{
+		source:      PostingSourceIndeed,
+		urls:        []string{"indeed.com/viewjob", "indeed.com/rc/clk"},
+		classes:     []string{"jobsearch-", "jobdescriptiontext"},
+		markers:     []string{"full job description", "report job", "out of 5 stars"},
+		title:       []selector{{"class", "jobsearch-JobInfoHeader-title"}, {"data-testid", "jobsearch-JobInfoHeader-title"}},
+		company:     []selector{{"data-company-name", "true"}, {"data-testid", "inlineHeader-companyName"}},
+		location:    []selector{{"data-testid", "inlineHeader-companyLocation"}, {"data-testid", "job-location"}},
+		description: []selector{{"id", "jobDescriptionText"}},
+		textHeader:  indeedTextHeader,
+		textStart:   []string{"full job description"},
+		textEnd:     []string{"report job", "hiring insights", "- job post"},
+	},
+	{
+		source:      PostingSourceGreenhouse,
+		urls:        []string{"boards.greenhouse.io/", "job-boards.greenhouse.io/"},
+		classes:     []string{"app-title", "grnhse"},
+		footers:     []string{"powered by greenhouse"},
+		markers:     []string{"apply for this job", "* required"},
+		title:       []selector{{"class", "app-title"}, {"class", "job__title"}},
+		company:     []selector{{"class", "company-name"}},
+		location:    []selector{{"class", "location"}, {"class", "job__location"}},
+		description: []selector{{"id", "content"}, {"class", "job__description"}},
+		textHeader:  greenhouseTextHeader,
+		textEnd:     []string{"apply for this job", "powered by greenhouse", "* required"},
+	},
+	{
+		source:               PostingSourceLever,
+		urls:                 []string{"jobs.lever.co/"},
+		classes:              []string{"posting-headline", "posting-categories"},
+		footers:              []string{"jobs powered by", "lever logo"},
+		markers:              []string{"apply for this job"},
+		title:                []selector{{"class", "posting-headline"}},
+		location:             []selector{{"class", "location"}},
+		description:          []selector{{"data-qa", "job-description"}, {"class", "posting-page"}},
+		companyFromPageTitle: true,
+		textHeader:           leverTextHeader,
+		textEnd:              []string{"apply for this job", "jobs powered by", "lever logo"},
+	},
+}
+
+// boilerplateLines are page controls that end up in pasted postings
+var boilerplateLines = map[string]bool{
+	"apply": true, "apply now": true, "easy apply": true, "apply on company site": true, "apply for this job": true,
+	"save": true, "saved": true, "share": true, "copy link": true,
+	"show more": true, "show less": true, "see more": true, "see less": true,
+	"report this job": true, "sign in": true, "join now": true, "back to jobs": true, "view all jobs": true,
+}
+
+var (
+	htmlPattern     = regexp.MustCompile(`(?i)<(html|body|div|p|span|h[1-6]|script|meta|li|ul|br|section|article)[\s>/]`)
+	dropBlocks      = regexp.MustCompile(`(?is)<(script|style|noscript|svg|head|button|form)\b[^>]*>.*?</(script|style|noscript|svg|head|button|form)>|<!--.*?-->`)
+	jsonLDPattern   = regexp.MustCompile(`(?is)<script[^>]*type\s*=\s*["']application/ld\+json["'][^>]*>(.*?)</script>`)
+	pageTitle       = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	ogTitle         = regexp.MustCompile(`(?is)<meta[^>]*property\s*=\s*["']og:title["'][^>]*content\s*=\s*["']([^"']*)["']`)
+	lineBreakTags   = regexp.MustCompile(`(?i)<br\s*/?>|</?(p|div|h[1-6]|ul|ol|tr|section|article|header|footer|blockquote)\b[^>]*>|</li>`)
+	listItemTag     = regexp.MustCompile(`(?i)<li\b[^>]*>`)
+	anyTag          = regexp.MustCompile(`(?s)<[^>]*>`)
+	openTag         = regexp.MustCompile(`(?s)<([a-zA-Z][a-zA-Z0-9]*)(\s[^>]*)?>`)
+	tagAttribute    = regexp.MustCompile(`([a-zA-Z_:][-a-zA-Z0-9_:.]*)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	spaceRun        = regexp.MustCompile(`[ \t\f\v\x{00a0}]+`)
+	blankLines      = regexp.MustCompile(`\n{3,}`)
+	danglingBullet  = regexp.MustCompile(`(?m)^-\n+`)
+	ratingLine      = regexp.MustCompile(`(?i)out of 5 stars|^\d(\.\d)?$|\breviews?\b`)
+	salaryLine      = regexp.MustCompile(`[$€£]\s?\d|(?i)\b(a|per) (year|month|hour)\b`)
+	headerSeparator = regexp.MustCompile(`\s+[·•|]\s+`)
+	classAttribute  = regexp.MustCompile(`(?i)\b(?:class|id)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+)
+
+// ParseJobPosting extracts the title, company, location and description from
+// a job posting pasted as page HTML or copied text. It detects LinkedIn,
+// Indeed, Greenhouse and Lever pages and drops their boilerplate; anything
+// else is treated as a generic posting and only cleaned up.
+func ParseJobPosting(content string) *models.ParsedJobPosting {
+	format := detectPostingFormat(content)
+
+	var posting *models.ParsedJobPosting
+	if htmlPattern.MatchString(content) {
+		posting = parseHTMLPosting(content, format)
+	} else if posting = parseTextPosting(content, format); posting == nil {
+		// The text has the board's phrases but not its layout
+		format = nil
+		posting = parseTextPosting(content, nil)
+	}
+
+	posting.Source = PostingSourceGeneric
+	if format != nil {
+		posting.Source = format.source
+	}
+	posting.Title = cleanInline(posting.Title)
+	posting.CompanyName = strings.TrimPrefix(cleanInline(posting.CompanyName), "at ")
+	posting.Location = cleanInline(posting.Location)
+
+	return posting
+}
+
+// detectPostingFormat returns the format that content matches best, or nil
+// if none match. A format matches on one of its strong markers or on two of
+// its other markers, since a phrase such as "applicants" alone shows up in
+// plenty of postings that never were on the board.
+func detectPostingFormat(content string) *postingFormat {
+	lower := strings.ToLower(content)
+
+	var classes []string
+	if htmlPattern.MatchString(content) {
+		for _, match := range classAttribute.FindAllStringSubmatch(lower, -1) {
+			classes = append(classes, strings.Fields(match[1]+match[2])...)
+		}
+	}
+	lines := strings.Split(lower, "\n")
+
+	var best *postingFormat
+	bestScore := 0
+	for i := range postingFormats {
+		format := &postingFormats[i]
+		strong := 0
+		for _, url := range format.urls {
+			if containsURL(lower, url) {
+				strong++
+			}
+		}
+		for _, prefix := range format.classes {
+			if slices.ContainsFunc(classes, func(class string) bool { return strings.HasPrefix(class, prefix) }) {
+				strong++
+			}
+		}
+		for _, footer := range format.footers {
+			if slices.ContainsFunc(lines, func(line string) bool { return strings.TrimSpace(line) == footer }) {
+				strong++
+			}
+		}
+
+		score := 2 * strong
+		for _, marker := range format.markers {
+			if strings.Contains(lower, marker) {
+				score++
+			}
+		}
+		if score >= 2 && score > bestScore {
+			best, bestScore = format, score
+		}
+	}
+
+	return best
+}
+
+// containsURL reports whether lower, the lowercased content, holds url at the
+// start of a host name, so that "jobs.lever.co/" matches
+// "https://jobs.lever.co/acme" but "lever.co" doesn't match "clever.com"
+func containsURL(lower, url string) bool {
+	for offset := 0; ; {
+		i := strings.Index(lower[offset:], url)
+		if i < 0 {
+			return false
+		}
+		i += offset
+		if i == 0 || !isHostRune(rune(lower[i-1])) {
+			return true
+		}
+		offset = i + 1
+	}
+}
+
+func isHostRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-'
+}
+
+func parseHTMLPosting(content string, format *postingFormat) *models.ParsedJobPosting {
+	posting := parseJSONLDPosting(content)
+	if posting == nil {
+		posting = &models.ParsedJobPosting{}
+	}
+
+	if format != nil {
+		if text := firstElementText(content, format.title); text != "" {
+			posting.Title = firstLine(text)
+		}
+		if text := firstElementText(content, format.company); text != "" {
+			posting.CompanyName = firstLine(text)
+		}
+		if text := firstElementText(content, format.location); text != "" {
+			posting.Location = firstLine(text)
+		}
+		if text := firstElementText(content, format.description); text != "" {
+			posting.Description = text
+		}
+		if posting.CompanyName == "" && format.companyFromPageTitle {
+			if match := pageTitle.FindStringSubmatch(content); match != nil {
+				if company, _, ok := strings.Cut(html.UnescapeString(match[1]), " - "); ok {
+					posting.CompanyName = company
+				}
+			}
+		}
+	}
+
+	if posting.Title == "" {
+		if match := ogTitle.FindStringSubmatch(content); match != nil {
+			posting.Title = html.UnescapeString(match[1])
+		}
+	}
+	if posting.Description == "" {
+		posting.Description = htmlToText(content)
+	}
+	posting.Description = cleanDescription(posting.Description, nil)
+
+	return posting
+}
+
+// jsonLDJobPosting is the part of a schema.org JobPosting the parser reads
+type jsonLDJobPosting struct {
+	Type               interface{} `json:"@type"`
+	Title              string      `json:"title"`
+	Description        string      `json:"description"`
+	HiringOrganization struct {
+		Name string `json:"name"`
+	} `json:"hiringOrganization"`
+	JobLocation json.RawMessage `json:"jobLocation"`
+}
+
+type jsonLDPlace struct {
+	Address struct {
+		Locality string `json:"addressLocality"`
+		Region   string `json:"addressRegion"`
+		Country  string `json:"addressCountry"`
+	} `json:"address"`
+}
+
+// parseJSONLDPosting reads the schema.org JobPosting many job boards embed
+// for search engines, or returns nil if there is none
+func parseJSONLDPosting(content string) *models.ParsedJobPosting {
+	for _, match := range jsonLDPattern.FindAllStringSubmatch(content, -1) {
+		var data jsonLDJobPosting
+		if err := json.Unmarshal([]byte(strings.TrimSpace(match[1])), &data); err != nil {
+			continue
+		}
+		if typ, _ := data.Type.(string); typ != "JobPosting" {
+			continue
+		}
+
+		posting := &models.ParsedJobPosting{
+			Title:       html.UnescapeString(data.Title),
+			CompanyName: html.UnescapeString(data.HiringOrganization.Name),
+			Description: htmlToText(html.UnescapeString(data.Description)),
+		}
+
+		var places []jsonLDPlace
+		if err := json.Unmarshal(data.JobLocation, &places); err != nil {
+			var place jsonLDPlace
+			if json.Unmarshal(data.JobLocation, &place) == nil {
+				places = []jsonLDPlace{place}
+			}
+		}
+		if len(places) > 0 {
+			var parts []string
+			for _, part := range []string{places[0].Address.Locality, places[0].Address.Region, places[0].Address.Country} {
+				if part != "" {
+					parts = append(parts, part)
+				}
+			}
+			posting.Location = strings.Join(parts, ", ")
+		}
+
+		return posting
+	}
+
+	return nil
+}
+
+// parseTextPosting reads copied text laid out as format, or returns nil if
+// the text lacks the board's start heading or header
+func parseTextPosting(content string, format *postingFormat) *models.ParsedJobPosting {
+	posting := &models.ParsedJobPosting{}
+	if format == nil {
+		posting.Description = cleanDescription(content, nil)
+		return posting
+	}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	start := -1
+	for i, line := range lines {
+		if containsLine(format.textStart, line) {
+			start = i
+			break
+		}
+	}
+
+	// The header is read from the lines before the board's start heading, or
+	// from the first lines on boards without one. Text without the heading or
+	// the header may well be a description that mentions the board, and
+	// cutting it at the board's end lines would lose content.
+	switch {
+	case start >= 0:
+		header := nonBlankLines(lines[:start])
+		if format.textHeader(header, posting) == 0 && len(header) > 0 {
+			return nil
+		}
+		lines = lines[start+1:]
+	case len(format.textStart) == 0:
+		used := format.textHeader(nonBlankLines(lines), posting)
+		if used == 0 {
+			return nil
+		}
+		lines = dropNonBlankLines(lines, used)
+	default:
+		return nil
+	}
+
+	posting.Description = cleanDescription(strings.Join(lines, "\n"), format.textEnd)
+
+	return posting
+}
+
+// linkedInTextHeader reads "Title" then "Company · Location · posted ·
+// applicants". Lines of another shape aren't a LinkedIn header and are left
+// alone.
+func linkedInTextHeader(lines []string, posting *models.ParsedJobPosting) int {
+	lines = skipBoilerplate(lines)
+	if len(lines) < 2 || !headerSeparator.MatchString(lines[1]) {
+		return 0
+	}
+	posting.Title = lines[0]
+	parts := headerSeparator.Split(lines[1], -1)
+	posting.CompanyName = parts[0]
+	posting.Location = parts[1]
+	return 2
+}
+
+// indeedTextHeader reads "Title", "Company", an optional rating, then the location
+func indeedTextHeader(lines []string, posting *models.ParsedJobPosting) int {
+	lines = skipBoilerplate(lines)
+	if len(lines) == 0 {
+		return 0
+	}
+	posting.Title = lines[0]
+	if len(lines) < 2 {
+		return 1
+	}
+	posting.CompanyName = lines[1]
+
+	used := 2
+	for _, line := range lines[2:] {
+		used++
+		if ratingLine.MatchString(line) {
+			continue
+		}
+		if !salaryLine.MatchString(line) {
+			posting.Location = line
+		}
+		break
+	}
+	return used
+}
+
+// greenhouseTextHeader reads "Title", "at Company", then the location. Lines
+// without the "at Company" line aren't a Greenhouse header.
+func greenhouseTextHeader(lines []string, posting *models.ParsedJobPosting) int {
+	lines = skipBoilerplate(lines)
+	if len(lines) < 2 || !strings.HasPrefix(strings.ToLower(lines[1]), "at ") {
+		return 0
+	}
+	posting.Title = lines[0]
+	posting.CompanyName = lines[1]
+
+	used := 2
+	if len(lines) > used && len(lines[used]) <= 80 {
+		posting.Location = lines[used]
+		used++
+	}
+	return used
+}
+
+// leverTextHeader reads "Title" then the categories, location first, each
+// but the last ending in "/". The company only appears in the logo, which
+// copied text doesn't carry. Lines without the categories aren't a Lever
+// header.
+func leverTextHeader(lines []string, posting *models.ParsedJobPosting) int {
+	lines = skipBoilerplate(lines)
+	if len(lines) < 2 || len(lines[1]) > 80 || !strings.HasSuffix(lines[1], "/") {
+		return 0
+	}
+	posting.Title = lines[0]
+	posting.Location = strings.TrimSpace(strings.TrimSuffix(lines[1], "/"))
+
+	used := 2
+	for used < len(lines) && strings.HasSuffix(lines[used-1], "/") {
+		used++
+	}
+	return used
+}
+
+// firstElementText returns the text of the first element any of selectors
+// matches
+func firstElementText(content string, selectors []selector) string {
+	for _, sel := range selectors {
+		if inner, ok := findElement(content, sel); ok {
+			if text := htmlToText(inner); text != "" {
+				return text
+			}
+		}
+	}
+	return ""
+}
+
+// findElement returns the inner HTML of the first element sel matches,
+// counting nested elements of the same tag to find its end
+func findElement(content string, sel selector) (string, bool) {
+	for _, loc := range openTag.FindAllStringSubmatchIndex(content, -1) {
+		if loc[4] < 0 || !hasAttribute(content[loc[4]:loc[5]], sel) {
+			continue
+		}
+
+		tag := strings.ToLower(content[loc[2]:loc[3]])
+		start := loc[1]
+		lower := strings.ToLower(content[start:])
+		depth := 1
+		for pos := 0; pos < len(lower); {
+			next := strings.Index(lower[pos:], "<")
+			if next < 0 {
+				break
+			}
+			pos += next
+			switch {
+			case strings.HasPrefix(lower[pos:], "</"+tag) && isTagEnd(lower, pos+2+len(tag)):
+				depth--
+				if depth == 0 {
+					return content[start : start+pos], true
+				}
+			case strings.HasPrefix(lower[pos:], "<"+tag) && isTagEnd(lower, pos+1+len(tag)):
+				depth++
+			}
+			pos++
+		}
+		return content[start:], true
+	}
+
+	return "", false
+}
+
+func isTagEnd(s string, i int) bool {
+	return i < len(s) && strings.ContainsRune(" \t\n\r/>", rune(s[i]))
+}
+
+func hasAttribute(attrs string, sel selector) bool {
+	for _, match := range tagAttribute.FindAllStringSubmatch(attrs, -1) {
+		if !strings.EqualFold(match[1], sel.attr) {
+			continue
+		}
+		value := match[2] + match[3]
+		if sel.attr == "class" {
+			for _, class := range strings.Fields(value) {
+				if class == sel.value {
+					return true
+				}
+			}
+			return false
+		}
+		return value == sel.value
+	}
+	return false
+}
+
+// htmlToText renders HTML as plain text, keeping paragraphs and list items
+// on their own lines
+func htmlToText(content string) string {
+	text := dropBlocks.ReplaceAllString(content, "")
+	text = listItemTag.ReplaceAllString(text, "\n- ")
+	text = lineBreakTags.ReplaceAllString(text, "\n")
+	text = anyTag.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spaceRun.ReplaceAllString(line, " "))
+	}
+	text = strings.Join(lines, "\n")
+
+	// Join list markers to items that start with a block, as in <li><p>
+	text = danglingBullet.ReplaceAllString(text, "- ")
+
+	return strings.TrimSpace(blankLines.ReplaceAllString(tightenLists(text), "\n\n"))
+}
+
+// tightenLists drops blank lines between list items
+func tightenLists(text string) string {
+	lines := strings.Split(text, "\n")
+	kept := lines[:0]
+	for i, line := range lines {
+		if line == "" && len(kept) > 0 && strings.HasPrefix(kept[len(kept)-1], "- ") {
+			next := i + 1
+			for next < len(lines) && lines[next] == "" {
+				next++
+			}
+			if next < len(lines) && strings.HasPrefix(lines[next], "- ") {
+				continue
+			}
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
+
+// cleanDescription normalises whitespace, drops boilerplate lines and cuts
+// the text at the first of endLines that follows some content, since apply
+// buttons often sit both above and below a posting
+func cleanDescription(text string, endLines []string) string {
+	var kept []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(spaceRun.ReplaceAllString(line, " "))
+		if len(nonBlankLines(kept)) > 0 && containsLine(endLines, line) {
+			break
+		}
+		if boilerplateLines[strings.ToLower(line)] {
+			continue
+		}
+		kept = append(kept, line)
+	}
+
+	text = strings.Join(kept, "\n")
+	return strings.TrimSpace(blankLines.ReplaceAllString(text, "\n\n"))
+}
+
+// containsLine reports whether line, ignoring case and surrounding space, is
+// one of lines
+func containsLine(lines []string, line string) bool {
+	line = strings.ToLower(strings.TrimSpace(line))
+	for _, candidate := range lines {
+		if line == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+func cleanInline(text string) string {
+	return strings.TrimSpace(spaceRun.ReplaceAllString(strings.ReplaceAll(text, "\n", " "), " "))
+}
+
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	return line
+}
+
+func nonBlankLines(lines []string) []string {
+	var kept []string
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); line != "" {
+			kept = append(kept, line)
+		}
+	}
+	return kept
+}
+
+func skipBoilerplate(lines []string) []string {
+	var kept []string
+	for _, line := range lines {
+		if !boilerplateLines[strings.ToLower(line)] {
+			kept = append(kept, line)
+		}
+	}
+	return kept
+}
+
+// dropNonBlankLines drops lines up to and including the n-th line that is
+// neither blank nor boilerplate
+func dropNonBlankLines(lines []string, n int) []string {
+	for i, line := range lines {
+		if n == 0 {
+			return lines[i:]
+		}
+		line = strings.TrimSpace(line)
+		if line != "" && !boilerplateLines[strings.ToLower(line)] {
+			n--
+		}
+	}
+	return nil
+}
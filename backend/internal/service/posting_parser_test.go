@@ -0,0 +1,121 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseJobPosting(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		source   string
+		title    string
+		company  string
+		location string
+		// contains must be in the description, excludes must not
+		contains []string
+		excludes []string
+	}{
+		{
+			fixture: "linkedin.html", source: PostingSourceLinkedIn,
+			title: "Senior Backend Engineer", company: "Acme Corp", location: "Berlin, Germany",
+			contains: []string{"Acme builds payment infrastructure", "- Own our PostgreSQL data model & migrations", "What you'll do"},
+			excludes: []string{"Show more", "Seniority level", "Sign in", "tracking", "Over 200 applicants"},
+		},
+		{
+			fixture: "linkedin.txt", source: PostingSourceLinkedIn,
+			title: "Senior Backend Engineer", company: "Acme Corp", location: "Berlin, Germany (Hybrid)",
+			contains: []string{"About us\nAcme builds payment infrastructure", "- Experience with Kubernetes"},
+			excludes: []string{"Easy Apply", "About the job", "Show more", "Seniority level", "Employment type"},
+		},
+		{
+			fixture: "indeed.html", source: PostingSourceIndeed,
+			title: "Backend Developer", company: "Globex Corporation", location: "Austin, TX 78701",
+			contains: []string{"Globex is hiring a Backend Developer", "- Build REST APIs in Go", "Benefits: health insurance"},
+			excludes: []string{"Short teaser", "Report job", "Apply now"},
+		},
+		{
+			fixture: "indeed.txt", source: PostingSourceIndeed,
+			title: "Backend Developer", company: "Globex Corporation", location: "Austin, TX 78701",
+			contains: []string{"Globex is hiring a Backend Developer", "- Write SQL for reporting"},
+			excludes: []string{"out of 5 stars", "Full job description", "Hiring Insights", "Report job", "$120,000"},
+		},
+		{
+			fixture: "greenhouse.html", source: PostingSourceGreenhouse,
+			title: "Staff Data Engineer", company: "Initech", location: "Remote - US",
+			contains: []string{"Initech is looking for a Staff Data Engineer", "- Mentor engineers across three teams"},
+			excludes: []string{"Apply for this Job", "First Name", "Powered by", "Apply Now"},
+		},
+		{
+			fixture: "greenhouse.txt", source: PostingSourceGreenhouse,
+			title: "Staff Data Engineer", company: "Initech", location: "Remote - US",
+			contains: []string{"Initech is looking for a Staff Data Engineer", "- 8+ years working with data systems"},
+			excludes: []string{"Apply for this Job", "* Required", "Powered by Greenhouse", "Remote - US"},
+		},
+		{
+			fixture: "lever.html", source: PostingSourceLever,
+			title: "Product Designer", company: "Hooli", location: "London, United Kingdom",
+			contains: []string{"Hooli is hiring a Product Designer", "- Ship design systems in Figma"},
+			excludes: []string{"Apply for this job", "Jobs powered by", "Design – Product"},
+		},
+		{
+			fixture: "lever.txt", source: PostingSourceLever,
+			title: "Product Designer", location: "London, United Kingdom",
+			contains: []string{"Hooli is hiring a Product Designer", "- Run user research with enterprise customers"},
+			excludes: []string{"Apply for this job", "Jobs powered by", "Full-time"},
+		},
+		{
+			fixture: "generic.txt", source: PostingSourceGeneric,
+			contains: []string{"Site Reliability Engineer\n\nWe run the infrastructure", "You will:\n- Keep our Terraform"},
+			excludes: []string{"Apply now", "\n\n\n", "\t"},
+		},
+		{
+			// "applicants" and "About the job" without LinkedIn's
+			// "Company · Location" header line aren't a LinkedIn posting
+			fixture: "plain.txt", source: PostingSourceGeneric,
+			contains: []string{"Backend Engineer\nUmbrella Corp, Raccoon City", "We review all applicants", "About the job\n- Build Go services"},
+		},
+		{
+			// Mentioning linkedin.com isn't a LinkedIn job page, so the text
+			// isn't cut at LinkedIn's "About the company" end line
+			fixture: "linkedin-mention.txt", source: PostingSourceGeneric,
+			contains: []string{"Platform Engineer\nWayne Enterprises, Gotham", "About the company", "- Experience running Kubernetes"},
+		},
+		{
+			// Clever.com isn't lever.co, and the first lines aren't a Lever header
+			fixture: "clever.txt", source: PostingSourceGeneric,
+			contains: []string{"Frontend Engineer\nSpringfield, Remote", "- Build React components"},
+		},
+		{
+			fixture: "applicants.txt", source: PostingSourceGeneric,
+			contains: []string{"Data Analyst\n\nWe welcome applicants from all backgrounds."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			content, err := os.ReadFile(filepath.Join("testdata", "postings", tt.fixture))
+			if err != nil {
+				t.Fatalf("read fixture: %v", err)
+			}
+
+			got := ParseJobPosting(string(content))
+			if got.Source != tt.source || got.Title != tt.title || got.CompanyName != tt.company || got.Location != tt.location {
+				t.Errorf("ParseJobPosting = source %q, title %q, company %q, location %q; want %q, %q, %q, %q",
+					got.Source, got.Title, got.CompanyName, got.Location, tt.source, tt.title, tt.company, tt.location)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(got.Description, want) {
+					t.Errorf("description is missing %q:\n%s", want, got.Description)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(got.Description, unwanted) {
+					t.Errorf("description contains %q:\n%s", unwanted, got.Description)
+				}
+			}
+		})
+	}
+}
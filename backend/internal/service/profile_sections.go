@@ -0,0 +1,451 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/repository"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidLanguageLevel = errors.New("level must be a CEFR level (A1, A2, B1, B2, C1, C2) or native")
+
+// NormalizeLanguageLevel returns level as "A1".."C2" or "native"
+func NormalizeLanguageLevel(level string) (string, error) {
+	level = strings.TrimSpace(level)
+	if strings.EqualFold(level, "native") {
+		return "native", nil
+	}
+	level = strings.ToUpper(level)
+	switch level {
+	case "A1", "A2", "B1", "B2", "C1", "C2":
+		return level, nil
+	}
+	return "", ErrInvalidLanguageLevel
+}
+
+// profileIDForUser returns the ID of the user's profile profileID, or of
+// their default profile when profileID is uuid.Nil
+func (s *ProfileService) profileIDForUser(userID, profileID uuid.UUID) (uuid.UUID, error) {
+	profile, err := s.profileForUser(userID, profileID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("profile not found: %w", err)
+	}
+	return profile.ID, nil
+}
+
+// Project methods
+
+func (s *ProfileService) CreateProject(userID, profileID uuid.UUID, project *models.Project) (*models.Project, error) {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	project.ID = uuid.New()
+	project.ProfileID = profileID
+
+	if err := s.profileRepo.CreateProject(project); err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	return project, nil
+}
+
+func (s *ProfileService) GetProjects(userID, profileID uuid.UUID) ([]*models.Project, error) {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.profileRepo.GetProjects(profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	return items, nil
+}
+
+func (s *ProfileService) UpdateProject(userID, profileID, id uuid.UUID, project *models.Project) error {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return err
+	}
+
+	project.ID = id
+	project.ProfileID = profileID
+
+	if err := s.profileRepo.UpdateProject(project); err != nil {
+		if err == repository.ErrUserNotFound {
+			return err
+		}
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ProfileService) DeleteProject(userID, profileID, id uuid.UUID) error {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.profileRepo.DeleteProject(id, profileID); err != nil {
+		if err == repository.ErrUserNotFound {
+			return err
+		}
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	return nil
+}
+
+// Certification methods
+
+func (s *ProfileService) CreateCertification(userID, profileID uuid.UUID, cert *models.Certification) (*models.Certification, error) {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	cert.ID = uuid.New()
+	cert.ProfileID = profileID
+
+	if err := s.profileRepo.CreateCertification(cert); err != nil {
+		return nil, fmt.Errorf("failed to create certification: %w", err)
+	}
+
+	return cert, nil
+}
+
+func (s *ProfileService) GetCertifications(userID, profileID uuid.UUID) ([]*models.Certification, error) {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.profileRepo.GetCertifications(profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get certifications: %w", err)
+	}
+
+	return items, nil
+}
+
+func (s *ProfileService) UpdateCertification(userID, profileID, id uuid.UUID, cert *models.Certification) error {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return err
+	}
+
+	cert.ID = id
+	cert.ProfileID = profileID
+
+	if err := s.profileRepo.UpdateCertification(cert); err != nil {
+		if err == repository.ErrUserNotFound {
+			return err
+		}
+		return fmt.Errorf("failed to update certification: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ProfileService) DeleteCertification(userID, profileID, id uuid.UUID) error {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.profileRepo.DeleteCertification(id, profileID); err != nil {
+		if err == repository.ErrUserNotFound {
+			return err
+		}
+		return fmt.Errorf("failed to delete certification: %w", err)
+	}
+
+	return nil
+}
+
+// Publication methods
+
+func (s *ProfileService) CreatePublication(userID, profileID uuid.UUID, pub *models.Publication) (*models.Publication, error) {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	pub.ID = uuid.New()
+	pub.ProfileID = profileID
+
+	if err := s.profileRepo.CreatePublication(pub); err != nil {
+		return nil, fmt.Errorf("failed to create publication: %w", err)
+	}
+
+	return pub, nil
+}
+
+func (s *ProfileService) GetPublications(userID, profileID uuid.UUID) ([]*models.Publication, error) {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.profileRepo.GetPublications(profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get publications: %w", err)
+	}
+
+	return items, nil
+}
+
+func (s *ProfileService) UpdatePublication(userID, profileID, id uuid.UUID, pub *models.Publication) error {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return err
+	}
+
+	pub.ID = id
+	pub.ProfileID = profileID
+
+	if err := s.profileRepo.UpdatePublication(pub); err != nil {
+		if err == repository.ErrUserNotFound {
+			return err
+		}
+		return fmt.Errorf("failed to update publication: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ProfileService) DeletePublication(userID, profileID, id uuid.UUID) error {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.profileRepo.DeletePublication(id, profileID); err != nil {
+		if err == repository.ErrUserNotFound {
+			return err
+		}
+		return fmt.Errorf("failed to delete publication: %w", err)
+	}
+
+	return nil
+}
+
+// Language methods
+
+func (s *ProfileService) CreateLanguage(userID, profileID uuid.UUID, lang *models.Language) (*models.Language, error) {
+	level, err := NormalizeLanguageLevel(lang.Level)
+	if err != nil {
+		return nil, err
+	}
+	lang.Level = level
+
+	profileID, err = s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	lang.ID = uuid.New()
+	lang.ProfileID = profileID
+
+	if err := s.profileRepo.CreateLanguage(lang); err != nil {
+		return nil, fmt.Errorf("failed to create language: %w", err)
+	}
+
+	return lang, nil
+}
+
+func (s *ProfileService) GetLanguages(userID, profileID uuid.UUID) ([]*models.Language, error) {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.profileRepo.GetLanguages(profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get languages: %w", err)
+	}
+
+	return items, nil
+}
+
+func (s *ProfileService) UpdateLanguage(userID, profileID, id uuid.UUID, lang *models.Language) error {
+	level, err := NormalizeLanguageLevel(lang.Level)
+	if err != nil {
+		return err
+	}
+	lang.Level = level
+
+	profileID, err = s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return err
+	}
+
+	lang.ID = id
+	lang.ProfileID = profileID
+
+	if err := s.profileRepo.UpdateLanguage(lang); err != nil {
+		if err == repository.ErrUserNotFound {
+			return err
+		}
+		return fmt.Errorf("failed to update language: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ProfileService) DeleteLanguage(userID, profileID, id uuid.UUID) error {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.profileRepo.DeleteLanguage(id, profileID); err != nil {
+		if err == repository.ErrUserNotFound {
+			return err
+		}
+		return fmt.Errorf("failed to delete language: %w", err)
+	}
+
+	return nil
+}
+
+// Volunteering methods
+
+func (s *ProfileService) CreateVolunteering(userID, profileID uuid.UUID, vol *models.Volunteering) (*models.Volunteering, error) {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	vol.ID = uuid.New()
+	vol.ProfileID = profileID
+
+	if err := s.profileRepo.CreateVolunteering(vol); err != nil {
+		return nil, fmt.Errorf("failed to create volunteering: %w", err)
+	}
+
+	return vol, nil
+}
+
+func (s *ProfileService) GetVolunteering(userID, profileID uuid.UUID) ([]*models.Volunteering, error) {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.profileRepo.GetVolunteering(profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get volunteering: %w", err)
+	}
+
+	return items, nil
+}
+
+func (s *ProfileService) UpdateVolunteering(userID, profileID, id uuid.UUID, vol *models.Volunteering) error {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return err
+	}
+
+	vol.ID = id
+	vol.ProfileID = profileID
+
+	if err := s.profileRepo.UpdateVolunteering(vol); err != nil {
+		if err == repository.ErrUserNotFound {
+			return err
+		}
+		return fmt.Errorf("failed to update volunteering: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ProfileService) DeleteVolunteering(userID, profileID, id uuid.UUID) error {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.profileRepo.DeleteVolunteering(id, profileID); err != nil {
+		if err == repository.ErrUserNotFound {
+			return err
+		}
+		return fmt.Errorf("failed to delete volunteering: %w", err)
+	}
+
+	return nil
+}
+
+// Award methods
+
+func (s *ProfileService) CreateAward(userID, profileID uuid.UUID, award *models.Award) (*models.Award, error) {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	award.ID = uuid.New()
+	award.ProfileID = profileID
+
+	if err := s.profileRepo.CreateAward(award); err != nil {
+		return nil, fmt.Errorf("failed to create award: %w", err)
+	}
+
+	return award, nil
+}
+
+func (s *ProfileService) GetAwards(userID, profileID uuid.UUID) ([]*models.Award, error) {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.profileRepo.GetAwards(profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get awards: %w", err)
+	}
+
+	return items, nil
+}
+
+func (s *ProfileService) UpdateAward(userID, profileID, id uuid.UUID, award *models.Award) error {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return err
+	}
+
+	award.ID = id
+	award.ProfileID = profileID
+
+	if err := s.profileRepo.UpdateAward(award); err != nil {
+		if err == repository.ErrUserNotFound {
+			return err
+		}
+		return fmt.Errorf("failed to update award: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ProfileService) DeleteAward(userID, profileID, id uuid.UUID) error {
+	profileID, err := s.profileIDForUser(userID, profileID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.profileRepo.DeleteAward(id, profileID); err != nil {
+		if err == repository.ErrUserNotFound {
+			return err
+		}
+		return fmt.Errorf("failed to delete award: %w", err)
+	}
+
+	return nil
+}
@@ -1,14 +1,24 @@
 package service
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/feijoa-master/ai-resume-builder/internal/models"
 	"github.com/feijoa-master/ai-resume-builder/internal/repository"
+	"github.com/feijoa-master/ai-resume-builder/internal/taxonomy"
 	"github.com/google/uuid"
 )
 
+var (
+	ErrMissingProfileName   = errors.New("profile name is required")
+	ErrProfileNameTooLong   = errors.New("profile name must be at most 100 characters")
+	ErrDuplicateProfileName = errors.New("a profile with this name already exists")
+	ErrDeleteDefaultProfile = errors.New("the default profile can't be deleted")
+)
+
 type ProfileService struct {
 	profileRepo *repository.ProfileRepository
 }
@@ -19,9 +29,10 @@ func NewProfileService(profileRepo *repository.ProfileRepository) *ProfileServic
 	}
 }
 
-// GetProfile retrieves user's profile with all related data
-func (s *ProfileService) GetProfile(userID uuid.UUID) (*models.Profile, error) {
-	profile, err := s.profileRepo.GetProfileByUserID(userID)
+// GetProfile retrieves one of the user's profiles with all related data, the
+// default one when profileID is uuid.Nil
+func (s *ProfileService) GetProfile(userID, profileID uuid.UUID) (*models.Profile, error) {
+	profile, err := s.profileForUser(userID, profileID)
 	if err != nil {
 		log.Printf("❌ GetProfile SQL error for user %s: %v", userID, err)
 		return nil, fmt.Errorf("failed to get profile: %w", err)
@@ -30,8 +41,15 @@ func (s *ProfileService) GetProfile(userID uuid.UUID) (*models.Profile, error) {
 	return profile, nil
 }
 
-// UpdateProfile updates profile information
-func (s *ProfileService) UpdateProfile(userID uuid.UUID, profile *models.Profile) error {
+// UpdateProfile updates profile information of one of the user's profiles,
+// the default one when profileID is uuid.Nil
+func (s *ProfileService) UpdateProfile(userID, profileID uuid.UUID, profile *models.Profile) error {
+	target, err := s.profileForUser(userID, profileID)
+	if err != nil {
+		return fmt.Errorf("profile not found: %w", err)
+	}
+
+	profile.ID = target.ID
 	profile.UserID = userID
 
 	if err := s.profileRepo.UpdateProfile(profile); err != nil {
@@ -41,11 +59,131 @@ func (s *ProfileService) UpdateProfile(userID uuid.UUID, profile *models.Profile
 	return nil
 }
 
+// profileForUser returns the user's profile profileID, or their default
+// profile when profileID is uuid.Nil. Another user's profile is
+// repository.ErrProfileNotFound.
+func (s *ProfileService) profileForUser(userID, profileID uuid.UUID) (*models.Profile, error) {
+	if profileID == uuid.Nil {
+		return s.profileRepo.GetProfileByUserID(userID)
+	}
+	return s.profileRepo.GetProfileByID(profileID, userID)
+}
+
+// Named profiles. A user has one or more profiles, for example one per kind
+// of role they apply for. The /profile endpoints and generation without a
+// profile_id use the default one; the section methods below take the
+// profile to edit, uuid.Nil meaning the default one.
+
+// GetProfiles lists all of the user's profiles, the default one first
+func (s *ProfileService) GetProfiles(userID uuid.UUID) ([]*models.Profile, error) {
+	profiles, err := s.profileRepo.GetProfiles(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profiles: %w", err)
+	}
+
+	return profiles, nil
+}
+
+// CreateProfile adds an empty profile
+func (s *ProfileService) CreateProfile(userID uuid.UUID, name string) (*models.Profile, error) {
+	name, err := s.checkProfileName(userID, name, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &models.Profile{ID: uuid.New(), UserID: userID, Name: name}
+	if err := s.profileRepo.CreateProfile(profile); err != nil {
+		return nil, fmt.Errorf("failed to create profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+// CopyProfile adds a profile holding a copy of everything in sourceID
+func (s *ProfileService) CopyProfile(userID, sourceID uuid.UUID, name string) (*models.Profile, error) {
+	name, err := s.checkProfileName(userID, name, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &models.Profile{ID: uuid.New(), UserID: userID, Name: name}
+	if err := s.profileRepo.CopyProfile(sourceID, profile); err != nil {
+		return nil, fmt.Errorf("failed to copy profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+// RenameProfile renames one of the user's profiles and returns it
+func (s *ProfileService) RenameProfile(userID, profileID uuid.UUID, name string) (*models.Profile, error) {
+	name, err := s.checkProfileName(userID, name, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.profileRepo.RenameProfile(profileID, userID, name); err != nil {
+		return nil, fmt.Errorf("failed to rename profile: %w", err)
+	}
+
+	return s.profileRepo.GetProfileByID(profileID, userID)
+}
+
+// SetDefaultProfile makes one of the user's profiles the default and
+// returns it
+func (s *ProfileService) SetDefaultProfile(userID, profileID uuid.UUID) (*models.Profile, error) {
+	if err := s.profileRepo.SetDefaultProfile(profileID, userID); err != nil {
+		return nil, fmt.Errorf("failed to set default profile: %w", err)
+	}
+
+	return s.profileRepo.GetProfileByID(profileID, userID)
+}
+
+// DeleteProfile deletes a profile that isn't the default one
+func (s *ProfileService) DeleteProfile(userID, profileID uuid.UUID) error {
+	profile, err := s.profileRepo.GetProfileByID(profileID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get profile: %w", err)
+	}
+	if profile.IsDefault {
+		return ErrDeleteDefaultProfile
+	}
+
+	if err := s.profileRepo.DeleteProfile(profileID, userID); err != nil {
+		return fmt.Errorf("failed to delete profile: %w", err)
+	}
+
+	return nil
+}
+
+// checkProfileName trims name and checks that no other profile of the user,
+// except the one with ID except, has it, ignoring case
+func (s *ProfileService) checkProfileName(userID uuid.UUID, name string, except uuid.UUID) (string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" {
+		return "", ErrMissingProfileName
+	}
+	if len([]rune(name)) > 100 {
+		return "", ErrProfileNameTooLong
+	}
+
+	profiles, err := s.profileRepo.GetProfiles(userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get profiles: %w", err)
+	}
+	for _, profile := range profiles {
+		if profile.ID != except && strings.EqualFold(profile.Name, name) {
+			return "", ErrDuplicateProfileName
+		}
+	}
+
+	return name, nil
+}
+
 // Experience methods
 
-func (s *ProfileService) CreateExperience(userID uuid.UUID, exp *models.Experience) (*models.Experience, error) {
+func (s *ProfileService) CreateExperience(userID, profileID uuid.UUID, exp *models.Experience) (*models.Experience, error) {
 	// Get profile to verify ownership
-	profile, err := s.profileRepo.GetProfileByUserID(userID)
+	profile, err := s.profileForUser(userID, profileID)
 	if err != nil {
 		return nil, fmt.Errorf("profile not found: %w", err)
 	}
@@ -60,8 +198,8 @@ func (s *ProfileService) CreateExperience(userID uuid.UUID, exp *models.Experien
 	return exp, nil
 }
 
-func (s *ProfileService) GetExperiences(userID uuid.UUID) ([]*models.Experience, error) {
-	profile, err := s.profileRepo.GetProfileByUserID(userID)
+func (s *ProfileService) GetExperiences(userID, profileID uuid.UUID) ([]*models.Experience, error) {
+	profile, err := s.profileForUser(userID, profileID)
 	if err != nil {
 		return nil, fmt.Errorf("profile not found: %w", err)
 	}
@@ -74,8 +212,8 @@ func (s *ProfileService) GetExperiences(userID uuid.UUID) ([]*models.Experience,
 	return experiences, nil
 }
 
-func (s *ProfileService) UpdateExperience(userID uuid.UUID, expID uuid.UUID, exp *models.Experience) error {
-	profile, err := s.profileRepo.GetProfileByUserID(userID)
+func (s *ProfileService) UpdateExperience(userID, profileID, expID uuid.UUID, exp *models.Experience) error {
+	profile, err := s.profileForUser(userID, profileID)
 	if err != nil {
 		return fmt.Errorf("profile not found: %w", err)
 	}
@@ -90,8 +228,8 @@ func (s *ProfileService) UpdateExperience(userID uuid.UUID, expID uuid.UUID, exp
 	return nil
 }
 
-func (s *ProfileService) DeleteExperience(userID uuid.UUID, expID uuid.UUID) error {
-	profile, err := s.profileRepo.GetProfileByUserID(userID)
+func (s *ProfileService) DeleteExperience(userID, profileID, expID uuid.UUID) error {
+	profile, err := s.profileForUser(userID, profileID)
 	if err != nil {
 		return fmt.Errorf("profile not found: %w", err)
 	}
@@ -103,10 +241,30 @@ func (s *ProfileService) DeleteExperience(userID uuid.UUID, expID uuid.UUID) err
 	return nil
 }
 
+// ReorderExperiences sets the display order of all the user's experiences
+// and returns them in that order
+func (s *ProfileService) ReorderExperiences(userID, profileID uuid.UUID, ids []uuid.UUID) ([]*models.Experience, error) {
+	profile, err := s.profileForUser(userID, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("profile not found: %w", err)
+	}
+
+	if err := s.profileRepo.ReorderExperiences(profile.ID, ids); err != nil {
+		return nil, fmt.Errorf("failed to reorder experiences: %w", err)
+	}
+
+	experiences, err := s.profileRepo.GetExperiences(profile.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get experiences: %w", err)
+	}
+
+	return experiences, nil
+}
+
 // Education methods
 
-func (s *ProfileService) CreateEducation(userID uuid.UUID, edu *models.Education) (*models.Education, error) {
-	profile, err := s.profileRepo.GetProfileByUserID(userID)
+func (s *ProfileService) CreateEducation(userID, profileID uuid.UUID, edu *models.Education) (*models.Education, error) {
+	profile, err := s.profileForUser(userID, profileID)
 	if err != nil {
 		return nil, fmt.Errorf("profile not found: %w", err)
 	}
@@ -121,8 +279,8 @@ func (s *ProfileService) CreateEducation(userID uuid.UUID, edu *models.Education
 	return edu, nil
 }
 
-func (s *ProfileService) GetEducation(userID uuid.UUID) ([]*models.Education, error) {
-	profile, err := s.profileRepo.GetProfileByUserID(userID)
+func (s *ProfileService) GetEducation(userID, profileID uuid.UUID) ([]*models.Education, error) {
+	profile, err := s.profileForUser(userID, profileID)
 	if err != nil {
 		return nil, fmt.Errorf("profile not found: %w", err)
 	}
@@ -135,8 +293,8 @@ func (s *ProfileService) GetEducation(userID uuid.UUID) ([]*models.Education, er
 	return education, nil
 }
 
-func (s *ProfileService) UpdateEducation(userID uuid.UUID, eduID uuid.UUID, edu *models.Education) error {
-	profile, err := s.profileRepo.GetProfileByUserID(userID)
+func (s *ProfileService) UpdateEducation(userID, profileID, eduID uuid.UUID, edu *models.Education) error {
+	profile, err := s.profileForUser(userID, profileID)
 	if err != nil {
 		return fmt.Errorf("profile not found: %w", err)
 	}
@@ -151,8 +309,8 @@ func (s *ProfileService) UpdateEducation(userID uuid.UUID, eduID uuid.UUID, edu
 	return nil
 }
 
-func (s *ProfileService) DeleteEducation(userID uuid.UUID, eduID uuid.UUID) error {
-	profile, err := s.profileRepo.GetProfileByUserID(userID)
+func (s *ProfileService) DeleteEducation(userID, profileID, eduID uuid.UUID) error {
+	profile, err := s.profileForUser(userID, profileID)
 	if err != nil {
 		return fmt.Errorf("profile not found: %w", err)
 	}
@@ -164,14 +322,46 @@ func (s *ProfileService) DeleteEducation(userID uuid.UUID, eduID uuid.UUID) erro
 	return nil
 }
 
+// ReorderEducation sets the display order of all the user's education
+// entries and returns them in that order
+func (s *ProfileService) ReorderEducation(userID, profileID uuid.UUID, ids []uuid.UUID) ([]*models.Education, error) {
+	profile, err := s.profileForUser(userID, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("profile not found: %w", err)
+	}
+
+	if err := s.profileRepo.ReorderEducation(profile.ID, ids); err != nil {
+		return nil, fmt.Errorf("failed to reorder education: %w", err)
+	}
+
+	education, err := s.profileRepo.GetEducation(profile.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get education: %w", err)
+	}
+
+	return education, nil
+}
+
 // Skills methods
 
-func (s *ProfileService) CreateSkill(userID uuid.UUID, skill *models.Skill) (*models.Skill, error) {
-	profile, err := s.profileRepo.GetProfileByUserID(userID)
+func (s *ProfileService) CreateSkill(userID, profileID uuid.UUID, skill *models.Skill) (*models.Skill, error) {
+	profile, err := s.profileForUser(userID, profileID)
 	if err != nil {
 		return nil, fmt.Errorf("profile not found: %w", err)
 	}
 
+	if err := NormalizeSkill(skill); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.profileRepo.GetSkills(profile.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+	if hasSkill(existing, skill.Name, uuid.Nil) {
+		return nil, ErrDuplicateSkill
+	}
+
 	skill.ID = uuid.New()
 	skill.ProfileID = profile.ID
 
@@ -182,8 +372,8 @@ func (s *ProfileService) CreateSkill(userID uuid.UUID, skill *models.Skill) (*mo
 	return skill, nil
 }
 
-func (s *ProfileService) GetSkills(userID uuid.UUID) ([]*models.Skill, error) {
-	profile, err := s.profileRepo.GetProfileByUserID(userID)
+func (s *ProfileService) GetSkills(userID, profileID uuid.UUID) ([]*models.Skill, error) {
+	profile, err := s.profileForUser(userID, profileID)
 	if err != nil {
 		return nil, fmt.Errorf("profile not found: %w", err)
 	}
@@ -196,8 +386,96 @@ func (s *ProfileService) GetSkills(userID uuid.UUID) ([]*models.Skill, error) {
 	return skills, nil
 }
 
-func (s *ProfileService) DeleteSkill(userID uuid.UUID, skillID uuid.UUID) error {
-	profile, err := s.profileRepo.GetProfileByUserID(userID)
+// UpdateSkill updates a skill's name, category, proficiency level and group
+func (s *ProfileService) UpdateSkill(userID, profileID, skillID uuid.UUID, skill *models.Skill) error {
+	profile, err := s.profileForUser(userID, profileID)
+	if err != nil {
+		return fmt.Errorf("profile not found: %w", err)
+	}
+
+	if err := NormalizeSkill(skill); err != nil {
+		return err
+	}
+
+	existing, err := s.profileRepo.GetSkills(profile.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get skills: %w", err)
+	}
+	if hasSkill(existing, skill.Name, skillID) {
+		return ErrDuplicateSkill
+	}
+
+	skill.ID = skillID
+	skill.ProfileID = profile.ID
+
+	if err := s.profileRepo.UpdateSkill(skill); err != nil {
+		if err == repository.ErrUserNotFound {
+			return err
+		}
+		return fmt.Errorf("failed to update skill: %w", err)
+	}
+
+	return nil
+}
+
+// ReplaceSkills saves the whole skills list in the given order, e.g. after
+// the user reordered it
+func (s *ProfileService) ReplaceSkills(userID, profileID uuid.UUID, skills []*models.Skill) ([]*models.Skill, error) {
+	profile, err := s.profileForUser(userID, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("profile not found: %w", err)
+	}
+
+	existing, err := s.profileRepo.GetSkills(profile.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+	owned := make(map[uuid.UUID]bool, len(existing))
+	for _, skill := range existing {
+		owned[skill.ID] = true
+	}
+
+	seen := map[string]bool{}
+	for _, skill := range skills {
+		if err := NormalizeSkill(skill); err != nil {
+			return nil, err
+		}
+		if skill.Name == "" {
+			return nil, ErrMissingSkillName
+		}
+		key := taxonomy.Key(skill.Name)
+		if seen[key] {
+			return nil, ErrDuplicateSkill
+		}
+		seen[key] = true
+
+		// Unknown IDs, including other users' skills, become new skills, as
+		// does the second use of an ID
+		if !owned[skill.ID] {
+			skill.ID = uuid.New()
+		}
+		owned[skill.ID] = false
+	}
+
+	if err := s.profileRepo.ReplaceSkills(profile.ID, skills); err != nil {
+		return nil, fmt.Errorf("failed to replace skills: %w", err)
+	}
+
+	return skills, nil
+}
+
+// GetSkillGroups retrieves the user's skills grouped by category or custom group
+func (s *ProfileService) GetSkillGroups(userID, profileID uuid.UUID, by string) ([]*models.SkillGroup, error) {
+	skills, err := s.GetSkills(userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	return GroupSkills(skills, by)
+}
+
+func (s *ProfileService) DeleteSkill(userID, profileID, skillID uuid.UUID) error {
+	profile, err := s.profileForUser(userID, profileID)
 	if err != nil {
 		return fmt.Errorf("profile not found: %w", err)
 	}
@@ -208,3 +486,53 @@ func (s *ProfileService) DeleteSkill(userID uuid.UUID, skillID uuid.UUID) error
 
 	return nil
 }
+
+// hasSkill reports whether skills contain name, comparing canonical names.
+// The skill with ID except is ignored.
+func hasSkill(skills []*models.Skill, name string, except uuid.UUID) bool {
+	key := taxonomy.Key(taxonomy.Default().Canonical(name))
+	for _, skill := range skills {
+		if skill.ID != except && taxonomy.Key(taxonomy.Default().Canonical(skill.Name)) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// SuggestSkills autocompletes a partially typed skill name from the taxonomy
+func (s *ProfileService) SuggestSkills(query string, limit int) []taxonomy.Suggestion {
+	return taxonomy.Default().Suggest(query, limit)
+}
+
+// MergeDuplicateSkills normalises every stored skill and folds duplicates
+// within each profile into one. With apply unset it only reports what it
+// would do.
+func (s *ProfileService) MergeDuplicateSkills(apply bool) ([]SkillMerge, error) {
+	skills, err := s.profileRepo.GetAllSkills()
+	if err != nil {
+		return nil, err
+	}
+
+	byProfile := map[uuid.UUID][]*models.Skill{}
+	var profileIDs []uuid.UUID
+	for _, skill := range skills {
+		if _, ok := byProfile[skill.ProfileID]; !ok {
+			profileIDs = append(profileIDs, skill.ProfileID)
+		}
+		byProfile[skill.ProfileID] = append(byProfile[skill.ProfileID], skill)
+	}
+
+	var merges []SkillMerge
+	for _, profileID := range profileIDs {
+		for _, merge := range PlanSkillMerges(byProfile[profileID]) {
+			if apply {
+				if err := s.profileRepo.MergeSkills(merge.Keep, merge.DuplicateIDs()); err != nil {
+					return merges, fmt.Errorf("failed to merge %q in profile %s: %w", merge.Keep.Name, profileID, err)
+				}
+			}
+			merges = append(merges, merge)
+		}
+	}
+
+	return merges, nil
+}
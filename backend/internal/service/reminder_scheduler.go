@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/google/uuid"
+)
+
+// Clock tells the scheduler the time, so tests can fix it
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the wall clock
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// DefaultFollowUpDays is how many days an application may stay in a status
+// before a follow-up reminder, unless the application has its own rule.
+// Statuses missing here only get reminders on applications with a rule.
+var DefaultFollowUpDays = map[string]int{
+	models.ApplicationApplied:   7,
+	models.ApplicationInterview: 5,
+}
+
+// reminderBatchSize caps how many reminders one run delivers
+const reminderBatchSize = 100
+
+// A reminder that fails to deliver is retried after the scheduler interval,
+// doubled after each failure up to maxReminderRetryDelay, and given up on
+// after maxDeliveryAttempts failures
+const (
+	maxDeliveryAttempts   = 8
+	maxReminderRetryDelay = 24 * time.Hour
+)
+
+// ReminderStore is the storage the scheduler works on;
+// repository.ReminderRepository keeps it in Postgres
+type ReminderStore interface {
+	GetFollowUpCandidates(statuses []string) ([]*models.FollowUpCandidate, error)
+	CreateReminder(reminder *models.Reminder) (bool, error)
+	GetUndeliveredReminders(now time.Time, maxAttempts, limit int) ([]*models.Reminder, error)
+	MarkReminderDelivered(id uuid.UUID) error
+	RecordDeliveryFailure(id uuid.UUID, nextAttemptAt time.Time) error
+}
+
+// UserGetter looks up the user a reminder goes to
+type UserGetter interface {
+	GetUserByID(id uuid.UUID) (*models.User, error)
+}
+
+// ReminderScheduler periodically creates follow-up reminders for
+// applications that haven't moved for too long, and delivers new reminders
+// through its notifier
+type ReminderScheduler struct {
+	reminderRepo ReminderStore
+	userRepo     UserGetter
+	notifier     Notifier
+	clock        Clock
+	interval     time.Duration
+}
+
+func NewReminderScheduler(reminderRepo ReminderStore, userRepo UserGetter, notifier Notifier, clock Clock, interval time.Duration) *ReminderScheduler {
+	return &ReminderScheduler{
+		reminderRepo: reminderRepo,
+		userRepo:     userRepo,
+		notifier:     notifier,
+		clock:        clock,
+		interval:     interval,
+	}
+}
+
+// Start runs the scheduler in the background, once right away and then every
+// interval, until ctx is cancelled
+func (s *ReminderScheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.RunOnce(ctx); err != nil {
+				log.Printf("Reminder run failed: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// RunOnce creates the reminders that are due and delivers undelivered ones
+func (s *ReminderScheduler) RunOnce(ctx context.Context) error {
+	now := s.clock.Now()
+
+	// Applications with a follow-up rule for their status are candidates too
+	statuses := make([]string, 0, len(DefaultFollowUpDays))
+	for status := range DefaultFollowUpDays {
+		statuses = append(statuses, status)
+	}
+
+	candidates, err := s.reminderRepo.GetFollowUpCandidates(statuses)
+	if err != nil {
+		return err
+	}
+	for _, candidate := range candidates {
+		reminder := FollowUpReminder(candidate, now)
+		if reminder == nil {
+			continue
+		}
+		if _, err := s.reminderRepo.CreateReminder(reminder); err != nil {
+			return err
+		}
+	}
+
+	return s.deliver(ctx, now)
+}
+
+// deliver sends undelivered reminders. A reminder that fails is retried by a
+// later run, after a delay that grows with each failure.
+func (s *ReminderScheduler) deliver(ctx context.Context, now time.Time) error {
+	reminders, err := s.reminderRepo.GetUndeliveredReminders(now, maxDeliveryAttempts, reminderBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, reminder := range reminders {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		user, err := s.userRepo.GetUserByID(reminder.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		if err := s.notifier.Notify(ctx, user, reminder); err != nil {
+			log.Printf("Failed to deliver reminder %s: %v", reminder.ID, err)
+			if reminder.DeliveryAttempts+1 >= maxDeliveryAttempts {
+				log.Printf("Giving up on reminder %s after %d attempts", reminder.ID, maxDeliveryAttempts)
+			}
+			if err := s.reminderRepo.RecordDeliveryFailure(reminder.ID, now.Add(s.retryDelay(reminder.DeliveryAttempts))); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := s.reminderRepo.MarkReminderDelivered(reminder.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// retryDelay returns how long to wait before retrying a reminder that failed
+// after attempts earlier failures
+func (s *ReminderScheduler) retryDelay(attempts int) time.Duration {
+	delay := s.interval
+	for i := 0; i < attempts && delay < maxReminderRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxReminderRetryDelay)
+}
+
+// FollowUpReminder returns the reminder for candidate if its follow-up is
+// due at now, or nil if it isn't due or follow-ups are off for its status
+func FollowUpReminder(candidate *models.FollowUpCandidate, now time.Time) *models.Reminder {
+	days := DefaultFollowUpDays[candidate.Status]
+	if candidate.AfterDays != nil {
+		days = *candidate.AfterDays
+	}
+	if days <= 0 {
+		return nil
+	}
+
+	dueAt := candidate.StatusChangedAt.AddDate(0, 0, days)
+	if now.Before(dueAt) {
+		return nil
+	}
+
+	return &models.Reminder{
+		ID:            uuid.New(),
+		UserID:        candidate.UserID,
+		ApplicationID: candidate.ApplicationID,
+		Status:        candidate.Status,
+		StatusSince:   candidate.StatusChangedAt,
+		Message:       followUpMessage(candidate, days),
+		DueAt:         dueAt,
+	}
+}
+
+func followUpMessage(candidate *models.FollowUpCandidate, days int) string {
+	switch candidate.Status {
+	case models.ApplicationInterview:
+		return fmt.Sprintf("No news from %s about the %s interview for %d days. Consider following up.", candidate.CompanyName, candidate.JobTitle, days)
+	default:
+		return fmt.Sprintf("You applied for %s at %s %d days ago with no reply yet. Consider following up.", candidate.JobTitle, candidate.CompanyName, days)
+	}
+}
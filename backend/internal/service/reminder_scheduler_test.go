@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestFollowUpReminder(t *testing.T) {
+	changed := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+	days := func(n int) *int { return &n }
+
+	tests := []struct {
+		name      string
+		status    string
+		afterDays *int
+		now       time.Time
+		wantDue   time.Time
+	}{
+		{"applied, not due yet", models.ApplicationApplied, nil, changed.AddDate(0, 0, 7).Add(-time.Second), time.Time{}},
+		{"applied, due by default", models.ApplicationApplied, nil, changed.AddDate(0, 0, 7), changed.AddDate(0, 0, 7)},
+		{"interview, due by default", models.ApplicationInterview, nil, changed.AddDate(0, 0, 6), changed.AddDate(0, 0, 5)},
+		{"rule shortens the delay", models.ApplicationApplied, days(2), changed.AddDate(0, 0, 3), changed.AddDate(0, 0, 2)},
+		{"rule lengthens the delay", models.ApplicationApplied, days(14), changed.AddDate(0, 0, 10), time.Time{}},
+		{"rule turns follow-ups off", models.ApplicationApplied, days(0), changed.AddDate(1, 0, 0), time.Time{}},
+		{"status without follow-ups", models.ApplicationOffer, nil, changed.AddDate(1, 0, 0), time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidate := &models.FollowUpCandidate{
+				ApplicationID:   uuid.New(),
+				UserID:          uuid.New(),
+				CompanyName:     "Acme",
+				JobTitle:        "Backend Engineer",
+				Status:          tt.status,
+				StatusChangedAt: changed,
+				AfterDays:       tt.afterDays,
+			}
+
+			reminder := FollowUpReminder(candidate, fixedClock{tt.now}.Now())
+			if tt.wantDue.IsZero() {
+				if reminder != nil {
+					t.Fatalf("FollowUpReminder = %+v, want nil", reminder)
+				}
+				return
+			}
+			if reminder == nil {
+				t.Fatal("FollowUpReminder = nil, want a reminder")
+			}
+			if !reminder.DueAt.Equal(tt.wantDue) || !reminder.StatusSince.Equal(changed) ||
+				reminder.ApplicationID != candidate.ApplicationID || reminder.UserID != candidate.UserID ||
+				reminder.Status != tt.status || reminder.Message == "" {
+				t.Errorf("FollowUpReminder = %+v", reminder)
+			}
+		})
+	}
+}
+
+// fixedClock always tells the same time
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+// fakeReminderStore is a ReminderStore in memory that filters like the
+// repository does
+type fakeReminderStore struct {
+	candidates  []*models.FollowUpCandidate
+	reminders   []*models.Reminder
+	nextAttempt map[uuid.UUID]time.Time
+}
+
+func (f *fakeReminderStore) GetFollowUpCandidates(statuses []string) ([]*models.FollowUpCandidate, error) {
+	var candidates []*models.FollowUpCandidate
+	for _, candidate := range f.candidates {
+		if !slices.Contains(statuses, candidate.Status) && (candidate.AfterDays == nil || *candidate.AfterDays <= 0) {
+			continue
+		}
+		if !slices.ContainsFunc(f.reminders, func(r *models.Reminder) bool {
+			return r.ApplicationID == candidate.ApplicationID && r.StatusSince.Equal(candidate.StatusChangedAt)
+		}) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates, nil
+}
+
+func (f *fakeReminderStore) CreateReminder(reminder *models.Reminder) (bool, error) {
+	f.reminders = append(f.reminders, reminder)
+	return true, nil
+}
+
+func (f *fakeReminderStore) GetUndeliveredReminders(now time.Time, maxAttempts, limit int) ([]*models.Reminder, error) {
+	var reminders []*models.Reminder
+	for _, r := range f.reminders {
+		next, scheduled := f.nextAttempt[r.ID]
+		if r.DeliveredAt == nil && r.DismissedAt == nil && r.DeliveryAttempts < maxAttempts && (!scheduled || !next.After(now)) {
+			reminders = append(reminders, r)
+		}
+	}
+	return reminders[:min(len(reminders), limit)], nil
+}
+
+func (f *fakeReminderStore) MarkReminderDelivered(id uuid.UUID) error {
+	for _, r := range f.reminders {
+		if r.ID == id {
+			now := time.Now()
+			r.DeliveredAt = &now
+		}
+	}
+	return nil
+}
+
+func (f *fakeReminderStore) RecordDeliveryFailure(id uuid.UUID, nextAttemptAt time.Time) error {
+	for _, r := range f.reminders {
+		if r.ID == id {
+			r.DeliveryAttempts++
+			f.nextAttempt[id] = nextAttemptAt
+		}
+	}
+	return nil
+}
+
+type fakeUsers map[uuid.UUID]*models.User
+
+func (f fakeUsers) GetUserByID(id uuid.UUID) (*models.User, error) {
+	return f[id], nil
+}
+
+// schedulerTest runs a scheduler over a fake store that delivers through an
+// in-app and a webhook channel, counting what each sent
+type schedulerTest struct {
+	store       *fakeReminderStore
+	clock       *fixedClock
+	scheduler   *ReminderScheduler
+	sent        map[string]int
+	webhookDown bool
+}
+
+func newSchedulerTest(candidates ...*models.FollowUpCandidate) *schedulerTest {
+	user := &models.User{ID: uuid.New(), Email: "jo@example.com"}
+	for _, candidate := range candidates {
+		candidate.UserID = user.ID
+	}
+
+	st := &schedulerTest{
+		store: &fakeReminderStore{candidates: candidates, nextAttempt: map[uuid.UUID]time.Time{}},
+		clock: &fixedClock{now: time.Date(2024, time.March, 20, 9, 0, 0, 0, time.UTC)},
+		sent:  map[string]int{},
+	}
+	channel := func(name string) Channel {
+		return Channel{Name: name, Notifier: notifierFunc(func() error {
+			if name == "webhook" && st.webhookDown {
+				return errors.New("unreachable")
+			}
+			st.sent[name]++
+			return nil
+		})}
+	}
+	notifier := NewMultiNotifier(memoryDeliveries{}, channel("in_app"), channel("webhook"))
+	st.scheduler = NewReminderScheduler(st.store, fakeUsers{user.ID: user}, notifier, st.clock, time.Hour)
+	return st
+}
+
+func (st *schedulerTest) run(t *testing.T) {
+	t.Helper()
+	if err := st.scheduler.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+}
+
+func testCandidate(status string, changed time.Time) *models.FollowUpCandidate {
+	return &models.FollowUpCandidate{
+		ApplicationID:   uuid.New(),
+		CompanyName:     "Acme",
+		JobTitle:        "Backend Engineer",
+		Status:          status,
+		StatusChangedAt: changed,
+	}
+}
+
+func TestReminderScheduler_RunOnce(t *testing.T) {
+	now := time.Date(2024, time.March, 20, 9, 0, 0, 0, time.UTC)
+	due := testCandidate(models.ApplicationApplied, now.AddDate(0, 0, -8))
+	notDue := testCandidate(models.ApplicationApplied, now.AddDate(0, 0, -2))
+	st := newSchedulerTest(due, notDue)
+
+	st.run(t)
+	if len(st.store.reminders) != 1 || st.store.reminders[0].ApplicationID != due.ApplicationID {
+		t.Fatalf("reminders = %+v, want one for the due application", st.store.reminders)
+	}
+	if st.store.reminders[0].DeliveredAt == nil || st.sent["in_app"] != 1 || st.sent["webhook"] != 1 {
+		t.Errorf("delivered = %v, sent = %v", st.store.reminders[0].DeliveredAt, st.sent)
+	}
+
+	// Nothing new on the next run
+	st.run(t)
+	if len(st.store.reminders) != 1 || st.sent["webhook"] != 1 {
+		t.Errorf("second run: reminders = %d, sent = %v", len(st.store.reminders), st.sent)
+	}
+}
+
+func TestReminderScheduler_RetriesFailedChannel(t *testing.T) {
+	st := newSchedulerTest(testCandidate(models.ApplicationInterview, time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)))
+	st.webhookDown = true
+
+	st.run(t)
+	reminder := st.store.reminders[0]
+	if reminder.DeliveredAt != nil || reminder.DeliveryAttempts != 1 || st.sent["in_app"] != 1 {
+		t.Fatalf("after failure: reminder = %+v, sent = %v", reminder, st.sent)
+	}
+
+	// Not retried before the retry delay, the scheduler interval
+	st.webhookDown = false
+	st.clock.now = st.clock.now.Add(time.Hour - time.Second)
+	st.run(t)
+	if reminder.DeliveredAt != nil || st.sent["webhook"] != 0 {
+		t.Fatalf("retried before the delay: sent = %v", st.sent)
+	}
+
+	// The retry only goes through the channel that failed
+	st.clock.now = st.clock.now.Add(time.Second)
+	st.run(t)
+	if reminder.DeliveredAt == nil || st.sent["in_app"] != 1 || st.sent["webhook"] != 1 {
+		t.Errorf("after retry: delivered = %v, sent = %v", reminder.DeliveredAt, st.sent)
+	}
+}
+
+func TestReminderScheduler_GivesUp(t *testing.T) {
+	st := newSchedulerTest(testCandidate(models.ApplicationApplied, time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)))
+	st.webhookDown = true
+
+	for i := 0; i < maxDeliveryAttempts+3; i++ {
+		st.run(t)
+		st.clock.now = st.clock.now.Add(maxReminderRetryDelay)
+	}
+	if reminder := st.store.reminders[0]; reminder.DeliveredAt != nil || reminder.DeliveryAttempts != maxDeliveryAttempts {
+		t.Errorf("reminder = %+v, want %d failed attempts", reminder, maxDeliveryAttempts)
+	}
+}
+
+func TestReminderScheduler_SkipsDismissed(t *testing.T) {
+	st := newSchedulerTest()
+	dismissedAt := st.clock.now.Add(-time.Hour)
+	st.store.reminders = []*models.Reminder{{ID: uuid.New(), UserID: uuid.New(), DismissedAt: &dismissedAt}}
+
+	st.run(t)
+	if len(st.sent) != 0 || st.store.reminders[0].DeliveredAt != nil {
+		t.Errorf("dismissed reminder sent: %v", st.sent)
+	}
+}
+
+func TestReminderSchedulerRetryDelay(t *testing.T) {
+	s := &ReminderScheduler{interval: 15 * time.Minute}
+	for attempts, want := range []time.Duration{15 * time.Minute, 30 * time.Minute, time.Hour, 2 * time.Hour} {
+		if got := s.retryDelay(attempts); got != want {
+			t.Errorf("retryDelay(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+	if got := s.retryDelay(20); got != maxReminderRetryDelay {
+		t.Errorf("retryDelay(20) = %v, want %v", got, maxReminderRetryDelay)
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/repository"
+	"github.com/google/uuid"
+)
+
+// maxFollowUpDays caps the delay of a follow-up rule
+const maxFollowUpDays = 365
+
+var (
+	ErrInvalidFollowUpStatus = errors.New("follow-up rules can only be set for the applied and interview statuses")
+	ErrInvalidFollowUpDays   = errors.New("after_days must be between 0 and 365")
+	ErrDuplicateFollowUpRule = errors.New("each status can only have one follow-up rule")
+)
+
+type ReminderService struct {
+	reminderRepo    *repository.ReminderRepository
+	applicationRepo *repository.ApplicationRepository
+}
+
+func NewReminderService(reminderRepo *repository.ReminderRepository, applicationRepo *repository.ApplicationRepository) *ReminderService {
+	return &ReminderService{
+		reminderRepo:    reminderRepo,
+		applicationRepo: applicationRepo,
+	}
+}
+
+// GetFollowUpRules lists the follow-up rules of an application. Statuses
+// without a rule use DefaultFollowUpDays.
+func (s *ReminderService) GetFollowUpRules(userID, appID uuid.UUID) ([]models.FollowUpRule, error) {
+	if _, err := s.applicationRepo.GetApplicationByID(appID, userID); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.reminderRepo.GetFollowUpRules(appID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follow-up rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+// ReplaceFollowUpRules replaces the follow-up rules of an application
+func (s *ReminderService) ReplaceFollowUpRules(userID, appID uuid.UUID, rules []models.FollowUpRule) ([]models.FollowUpRule, error) {
+	seen := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		if _, ok := DefaultFollowUpDays[rule.Status]; !ok {
+			return nil, ErrInvalidFollowUpStatus
+		}
+		if rule.AfterDays < 0 || rule.AfterDays > maxFollowUpDays {
+			return nil, ErrInvalidFollowUpDays
+		}
+		if seen[rule.Status] {
+			return nil, ErrDuplicateFollowUpRule
+		}
+		seen[rule.Status] = true
+	}
+
+	if err := s.reminderRepo.ReplaceFollowUpRules(appID, userID, rules); err != nil {
+		if errors.Is(err, repository.ErrApplicationNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to replace follow-up rules: %w", err)
+	}
+
+	return s.GetFollowUpRules(userID, appID)
+}
+
+// GetReminders lists a user's reminders
+func (s *ReminderService) GetReminders(userID uuid.UUID, includeDismissed bool) ([]*models.Reminder, error) {
+	reminders, err := s.reminderRepo.GetReminders(userID, includeDismissed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reminders: %w", err)
+	}
+
+	return reminders, nil
+}
+
+// DismissReminder hides a reminder from the in-app list
+func (s *ReminderService) DismissReminder(userID, reminderID uuid.UUID) error {
+	return s.reminderRepo.DismissReminder(reminderID, userID)
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// ResponseCache stores model responses by a hash of the request, so a
+// retried or double-clicked generation doesn't pay for the same prompt twice.
+// repository.LLMCacheRepository keeps them in Postgres, MemoryCache in
+// process.
+type ResponseCache interface {
+	// Get returns the value stored under key, if it hasn't expired
+	Get(key string) ([]byte, bool, error)
+	Set(key string, value []byte, ttl time.Duration) error
+}
+
+// cachedResponse is what the cache keeps of a model response
+type cachedResponse struct {
+	Content string `json:"content"`
+}
+
+// responseCacheKey hashes everything that decides a model response: the
+// model, the prompt version, the sampling parameters and the rendered
+// messages, which hold the profile, the job description and the document
+func responseCacheKey(model, promptVersion string, temperature float32, maxTokens int, messages []openai.ChatCompletionMessage) string {
+	request := struct {
+		Model         string                         `json:"model"`
+		PromptVersion string                         `json:"prompt_version"`
+		Temperature   float32                        `json:"temperature"`
+		MaxTokens     int                            `json:"max_tokens"`
+		Messages      []openai.ChatCompletionMessage `json:"messages"`
+	}{model, promptVersion, temperature, maxTokens, messages}
+
+	data, _ := json.Marshal(request)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// memoryCacheSweep is the number of entries above which Set drops expired ones
+const memoryCacheSweep = 1024
+
+// MemoryCache is a ResponseCache in process memory, lost on restart and not
+// shared between instances
+type MemoryCache struct {
+	mu      sync.Mutex
+	clock   Clock
+	entries map[string]memoryEntry
+}
+
+type memoryEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+func NewMemoryCache(clock Clock) *MemoryCache {
+	return &MemoryCache{clock: clock, entries: make(map[string]memoryEntry)}
+}
+
+func (c *MemoryCache) Get(key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !c.clock.Now().Before(entry.expires) {
+		return nil, false, nil
+	}
+	return entry.value, true, nil
+}
+
+func (c *MemoryCache) Set(key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	if len(c.entries) >= memoryCacheSweep {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+
+	c.entries[key] = memoryEntry{value: value, expires: now.Add(ttl)}
+	return nil
+}
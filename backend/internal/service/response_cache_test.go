@@ -0,0 +1,88 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/prompts"
+	"github.com/sashabaranov/go-openai"
+)
+
+func TestMemoryCache(t *testing.T) {
+	clock := &fixedClock{now: time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)}
+	cache := NewMemoryCache(clock)
+
+	if _, ok, _ := cache.Get("key"); ok {
+		t.Fatal("Get on an empty cache found a value")
+	}
+
+	cache.Set("key", []byte("first"), time.Hour)
+	if got, ok, _ := cache.Get("key"); !ok || string(got) != "first" {
+		t.Errorf("Get = %q, %v, want first", got, ok)
+	}
+
+	cache.Set("key", []byte("second"), time.Hour)
+	if got, _, _ := cache.Get("key"); string(got) != "second" {
+		t.Errorf("Get after replace = %q, want second", got)
+	}
+
+	clock.now = clock.now.Add(time.Hour)
+	if _, ok, _ := cache.Get("key"); ok {
+		t.Error("Get returned an expired value")
+	}
+}
+
+func TestResponseCacheKey(t *testing.T) {
+	messages := []openai.ChatCompletionMessage{
+		{Role: openai.ChatMessageRoleSystem, Content: "system"},
+		{Role: openai.ChatMessageRoleUser, Content: "profile and job"},
+	}
+	base := responseCacheKey("gpt-4o-mini", "resume@3", 0.7, 1500, messages)
+
+	if again := responseCacheKey("gpt-4o-mini", "resume@3", 0.7, 1500, messages); again != base {
+		t.Error("the same request gave different keys")
+	}
+
+	changed := []openai.ChatCompletionMessage{messages[0], {Role: openai.ChatMessageRoleUser, Content: "another job"}}
+	tests := map[string]string{
+		"model":       responseCacheKey("gpt-4o", "resume@3", 0.7, 1500, messages),
+		"version":     responseCacheKey("gpt-4o-mini", "resume@4", 0.7, 1500, messages),
+		"temperature": responseCacheKey("gpt-4o-mini", "resume@3", 0.2, 1500, messages),
+		"max tokens":  responseCacheKey("gpt-4o-mini", "resume@3", 0.7, 800, messages),
+		"messages":    responseCacheKey("gpt-4o-mini", "resume@3", 0.7, 1500, changed),
+	}
+	for name, key := range tests {
+		if key == base {
+			t.Errorf("changing the %s kept the key", name)
+		}
+	}
+}
+
+func TestOpenAIService_CachedResponse(t *testing.T) {
+	registry, err := prompts.Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	prompt, err := registry.Get(prompts.AnalyzeJob)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	cache := NewMemoryCache(SystemClock{})
+	// No client: a request that isn't answered from the cache panics
+	s := NewOpenAIService("", "gpt-4o-mini", 1500, 0.7, registry, cache, time.Hour, Resilience{})
+	s.client = nil
+
+	messages := []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "job"}}
+	key := responseCacheKey("gpt-4o-mini", prompt.Version(), s.temperature, 1500, messages)
+	cache.Set(key, []byte(`{"content":"{\"keywords\":[\"go\"]}"}`), time.Hour)
+
+	generated, err := s.chat(prompt, PromptOptions{}, messages, 1500)
+	if err != nil {
+		t.Fatalf("chat: %v", err)
+	}
+	if !generated.Cached || generated.Content != `{"keywords":["go"]}` || generated.TotalTokens != 0 ||
+		generated.Model != "gpt-4o-mini" || generated.PromptVersion != prompt.Version() {
+		t.Errorf("chat = %+v, want the cached response", generated)
+	}
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/taxonomy"
+)
+
+// proficiencyRanks orders models.Skill.ProficiencyLevel values
+var proficiencyRanks = map[string]int{
+	"beginner":     1,
+	"intermediate": 2,
+	"advanced":     3,
+	"expert":       4,
+}
+
+// seniorityLevels is the proficiency a role of each seniority expects in its
+// required skills
+var seniorityLevels = map[string]string{
+	"intern": "beginner",
+	"junior": "beginner",
+	"mid":    "intermediate",
+	"senior": "advanced",
+	"lead":   "expert",
+}
+
+// AnalyzeSkillGap sorts the skills of a job analysis into those the profile
+// matches, matches only partially and misses. Names are compared through the
+// taxonomy, so "Golang" in a profile matches "Go" in a job description.
+func AnalyzeSkillGap(profile *ProfileData, analysis *models.JobAnalysis) *models.SkillGapReport {
+	report := &models.SkillGapReport{
+		Seniority:        analysis.Seniority,
+		Matched:          []models.SkillGapItem{},
+		PartiallyMatched: []models.SkillGapItem{},
+		Missing:          []models.SkillGapItem{},
+	}
+
+	skills := taxonomy.Default()
+
+	// The user's skills by canonical name, keeping the highest level of duplicates
+	owned := map[string]*models.Skill{}
+	for _, skill := range profile.Skills {
+		key := taxonomy.Key(skills.Canonical(skill.Name))
+		if current, ok := owned[key]; !ok || proficiencyRanks[skill.ProficiencyLevel] > proficiencyRanks[current.ProficiencyLevel] {
+			owned[key] = skill
+		}
+	}
+
+	history := experienceText(profile.Experiences)
+	inExperience := map[string]bool{}
+	for _, name := range skills.Find(history) {
+		inExperience[taxonomy.Key(name)] = true
+	}
+	mentioned := func(name string) bool {
+		if skill, ok := skills.Lookup(name); ok {
+			return inExperience[taxonomy.Key(skill.Name)]
+		}
+		return containsTerm(history, name)
+	}
+
+	requiredLevel, ok := seniorityLevels[analysis.Seniority]
+	if !ok {
+		requiredLevel = "intermediate"
+	}
+
+	var requiredCovered float64
+	assess := func(name string, required bool) {
+		item := models.SkillGapItem{
+			Skill:    skills.Canonical(name),
+			Required: required,
+		}
+		if known, ok := skills.Lookup(name); ok {
+			item.Category = known.Category
+		}
+		// Nice-to-haves count at any level
+		wantLevel := "beginner"
+		if required {
+			wantLevel = requiredLevel
+			item.RequiredLevel = requiredLevel
+		}
+
+		skill, ok := owned[taxonomy.Key(item.Skill)]
+		switch {
+		case ok:
+			item.YourSkill = skill.Name
+			item.YourLevel = skill.ProficiencyLevel
+			if item.Category == "" {
+				item.Category = skill.Category
+			}
+			rank, rated := proficiencyRanks[skill.ProficiencyLevel]
+			if !rated || rank >= proficiencyRanks[wantLevel] {
+				if !rated {
+					item.Note = "Set a proficiency level to check it against the role."
+				}
+				report.Matched = append(report.Matched, item)
+				if required {
+					requiredCovered++
+				}
+				return
+			}
+			item.Note = fmt.Sprintf("Your level is %s, the role expects %s.", skill.ProficiencyLevel, wantLevel)
+			report.PartiallyMatched = append(report.PartiallyMatched, item)
+		case mentioned(item.Skill):
+			item.Note = "Mentioned in your experience but not listed in your skills."
+			report.PartiallyMatched = append(report.PartiallyMatched, item)
+		default:
+			report.Missing = append(report.Missing, item)
+			return
+		}
+		if required {
+			requiredCovered += 0.5
+		}
+	}
+
+	for _, name := range analysis.RequiredSkills {
+		assess(name, true)
+	}
+	for _, name := range analysis.NiceToHaveSkills {
+		assess(name, false)
+	}
+
+	report.Coverage = 100
+	if len(analysis.RequiredSkills) > 0 {
+		report.Coverage = int(math.Round(100 * requiredCovered / float64(len(analysis.RequiredSkills))))
+	}
+
+	return report
+}
+
+// experienceText joins positions, descriptions and achievements of all
+// experiences into one text
+func experienceText(experiences []*models.Experience) string {
+	var parts []string
+	for _, exp := range experiences {
+		parts = append(parts, exp.Position, exp.Description)
+		parts = append(parts, exp.Achievements...)
+	}
+	return strings.Join(parts, "\n")
+}
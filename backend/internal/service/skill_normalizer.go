@@ -0,0 +1,147 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/feijoa-master/ai-resume-builder/internal/taxonomy"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidSkillCategory    = errors.New("category must be technical, soft or language")
+	ErrInvalidProficiencyLevel = errors.New("proficiency level must be beginner, intermediate, advanced or expert")
+	ErrMissingSkillName        = errors.New("skill name is required")
+	ErrDuplicateSkill          = errors.New("skill already exists in profile")
+	ErrInvalidSkillGrouping    = errors.New("group_by must be category or group")
+)
+
+// NormalizeSkill rewrites a skill to its canonical taxonomy name and fills in
+// the taxonomy category when none is given. Unknown skills keep their name,
+// trimmed. Category and proficiency level are validated.
+func NormalizeSkill(skill *models.Skill) error {
+	skills := taxonomy.Default()
+
+	skill.Name = skills.Canonical(skill.Name)
+	skill.Category = strings.ToLower(strings.TrimSpace(skill.Category))
+	skill.ProficiencyLevel = strings.ToLower(strings.TrimSpace(skill.ProficiencyLevel))
+	skill.Group = strings.Join(strings.Fields(skill.Group), " ")
+
+	if skill.Category == "" {
+		skill.Category = taxonomy.CategoryTechnical
+		if known, ok := skills.Lookup(skill.Name); ok {
+			skill.Category = known.Category
+		}
+	}
+	if !taxonomy.IsCategory(skill.Category) {
+		return ErrInvalidSkillCategory
+	}
+	if _, ok := proficiencyRanks[skill.ProficiencyLevel]; skill.ProficiencyLevel != "" && !ok {
+		return ErrInvalidProficiencyLevel
+	}
+
+	return nil
+}
+
+// GroupSkills splits skills, kept in display order, into groups by category
+// or by custom group. Skills without a custom group fall back to their
+// category. Groups appear in the order of their first skill.
+func GroupSkills(skills []*models.Skill, by string) ([]*models.SkillGroup, error) {
+	if by != "category" && by != "group" {
+		return nil, ErrInvalidSkillGrouping
+	}
+
+	groups := []*models.SkillGroup{}
+	index := map[string]*models.SkillGroup{}
+	for _, skill := range skills {
+		name := skill.Category
+		if by == "group" && skill.Group != "" {
+			name = skill.Group
+		}
+		group, ok := index[name]
+		if !ok {
+			group = &models.SkillGroup{Name: name, Skills: []*models.Skill{}}
+			index[name] = group
+			groups = append(groups, group)
+		}
+		group.Skills = append(group.Skills, skill)
+	}
+
+	return groups, nil
+}
+
+// SkillMerge folds duplicate skills of one profile into a single skill
+type SkillMerge struct {
+	Keep       *models.Skill   // normalised; the row to update
+	OldName    string          // Keep's name before normalisation
+	Duplicates []*models.Skill // rows to delete
+}
+
+// DuplicateIDs returns the IDs of the rows to delete
+func (m SkillMerge) DuplicateIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, len(m.Duplicates))
+	for i, skill := range m.Duplicates {
+		ids[i] = skill.ID
+	}
+	return ids
+}
+
+// PlanSkillMerges groups a profile's skills by canonical name. Every group
+// with duplicates, or whose skill isn't stored in normalised form, yields a
+// merge. The skill with the highest proficiency is kept, the oldest on ties.
+func PlanSkillMerges(skills []*models.Skill) []SkillMerge {
+	groups := map[string][]*models.Skill{}
+	var order []string
+	for _, skill := range skills {
+		key := taxonomy.Key(taxonomy.Default().Canonical(skill.Name))
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], skill)
+	}
+
+	var merges []SkillMerge
+	for _, key := range order {
+		group := groups[key]
+		sort.SliceStable(group, func(i, j int) bool {
+			rankI := proficiencyRanks[strings.ToLower(group[i].ProficiencyLevel)]
+			rankJ := proficiencyRanks[strings.ToLower(group[j].ProficiencyLevel)]
+			if rankI != rankJ {
+				return rankI > rankJ
+			}
+			return group[i].CreatedAt.Before(group[j].CreatedAt)
+		})
+
+		original := *group[0]
+		keep := original
+		// Legacy rows may hold any category or level; prefer a valid category
+		// from a duplicate, then the taxonomy's
+		if !taxonomy.IsCategory(strings.ToLower(strings.TrimSpace(keep.Category))) {
+			keep.Category = ""
+			for _, other := range group[1:] {
+				if taxonomy.IsCategory(strings.ToLower(strings.TrimSpace(other.Category))) {
+					keep.Category = other.Category
+					break
+				}
+			}
+		}
+		if _, ok := proficiencyRanks[strings.ToLower(strings.TrimSpace(keep.ProficiencyLevel))]; !ok {
+			keep.ProficiencyLevel = ""
+		}
+		// Can't fail now that category and level are valid or empty
+		_ = NormalizeSkill(&keep)
+
+		if len(group) == 1 && keep == original {
+			continue
+		}
+		merges = append(merges, SkillMerge{
+			Keep:       &keep,
+			OldName:    original.Name,
+			Duplicates: group[1:],
+		})
+	}
+
+	return merges
+}
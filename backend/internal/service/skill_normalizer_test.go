@@ -0,0 +1,142 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/feijoa-master/ai-resume-builder/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestNormalizeSkill(t *testing.T) {
+	tests := []struct {
+		name  string
+		skill models.Skill
+		want  models.Skill
+		err   error
+	}{
+		{
+			name:  "alias to canonical name",
+			skill: models.Skill{Name: " golang ", ProficiencyLevel: "Expert"},
+			want:  models.Skill{Name: "Go", Category: "technical", ProficiencyLevel: "expert"},
+		},
+		{
+			name:  "category from the taxonomy",
+			skill: models.Skill{Name: "communication skills"},
+			want:  models.Skill{Name: "Communication", Category: "soft"},
+		},
+		{
+			name:  "given category kept",
+			skill: models.Skill{Name: "English", Category: " Technical "},
+			want:  models.Skill{Name: "English", Category: "technical"},
+		},
+		{
+			name:  "unknown skill trimmed",
+			skill: models.Skill{Name: "  Internal   Tools ", Group: " Back   end "},
+			want:  models.Skill{Name: "Internal Tools", Category: "technical", Group: "Back end"},
+		},
+		{
+			name:  "invalid category",
+			skill: models.Skill{Name: "Go", Category: "hobby"},
+			err:   ErrInvalidSkillCategory,
+		},
+		{
+			name:  "invalid proficiency",
+			skill: models.Skill{Name: "Go", ProficiencyLevel: "guru"},
+			err:   ErrInvalidProficiencyLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skill := tt.skill
+			err := NormalizeSkill(&skill)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("NormalizeSkill error = %v, want %v", err, tt.err)
+			}
+			if tt.err == nil && skill != tt.want {
+				t.Errorf("NormalizeSkill = %+v, want %+v", skill, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanSkillMerges(t *testing.T) {
+	created := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	skill := func(name, category, level string, age int) *models.Skill {
+		return &models.Skill{
+			ID:               uuid.New(),
+			Name:             name,
+			Category:         category,
+			ProficiencyLevel: level,
+			CreatedAt:        created.Add(time.Duration(age) * time.Hour),
+		}
+	}
+
+	goOld := skill("golang", "technical", "advanced", 0)
+	goNew := skill("Go", "technical", "advanced", 1)
+	goBeginner := skill("GO", "technical", "beginner", 2)
+	postgres := skill("Postgres", "legacy", "expert", 0)
+	postgresTyped := skill("PostgreSQL", "technical", "", 1)
+	python := skill("Python", "technical", "intermediate", 0)
+	legacy := skill("Docker", "tools", "pro", 0)
+
+	tests := []struct {
+		name   string
+		skills []*models.Skill
+		want   []SkillMerge
+	}{
+		{
+			name:   "nothing to merge",
+			skills: []*models.Skill{python},
+		},
+		{
+			// Highest proficiency wins, the oldest on ties
+			name:   "duplicates by alias and case",
+			skills: []*models.Skill{goNew, goBeginner, python, goOld},
+			want: []SkillMerge{{
+				Keep:       &models.Skill{ID: goOld.ID, Name: "Go", Category: "technical", ProficiencyLevel: "advanced", CreatedAt: goOld.CreatedAt},
+				OldName:    "golang",
+				Duplicates: []*models.Skill{goNew, goBeginner},
+			}},
+		},
+		{
+			name:   "invalid category taken from a duplicate",
+			skills: []*models.Skill{postgresTyped, postgres},
+			want: []SkillMerge{{
+				Keep:       &models.Skill{ID: postgres.ID, Name: "PostgreSQL", Category: "technical", ProficiencyLevel: "expert", CreatedAt: postgres.CreatedAt},
+				OldName:    "Postgres",
+				Duplicates: []*models.Skill{postgresTyped},
+			}},
+		},
+		{
+			name:   "single legacy row normalised",
+			skills: []*models.Skill{legacy},
+			want: []SkillMerge{{
+				Keep:       &models.Skill{ID: legacy.ID, Name: "Docker", Category: "technical", CreatedAt: legacy.CreatedAt},
+				OldName:    "Docker",
+				Duplicates: []*models.Skill{},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PlanSkillMerges(append([]*models.Skill(nil), tt.skills...))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PlanSkillMerges = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkillMergeDuplicateIDs(t *testing.T) {
+	a, b := &models.Skill{ID: uuid.New()}, &models.Skill{ID: uuid.New()}
+	merge := SkillMerge{Keep: &models.Skill{ID: uuid.New()}, Duplicates: []*models.Skill{a, b}}
+
+	if got, want := merge.DuplicateIDs(), []uuid.UUID{a.ID, b.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DuplicateIDs = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,229 @@
+// Package taxonomy is the bundled vocabulary of skills the service recognises:
+// canonical names, aliases and categories. It is used to pull skills out of
+// job descriptions and to treat "Golang" and "Go" as the same skill.
+package taxonomy
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Skill categories, matching the values of models.Skill.Category
+const (
+	CategoryTechnical = "technical"
+	CategorySoft      = "soft"
+	CategoryLanguage  = "language"
+)
+
+//go:embed skills.json
+var skillsJSON []byte
+
+// Skill is one entry of the taxonomy
+type Skill struct {
+	Name     string   `json:"name"`
+	Category string   `json:"category"`
+	Aliases  []string `json:"aliases,omitempty"`
+	// CaseSensitive is set for names that are also common English words, so
+	// that "rust" or "excel" in free text aren't read as skills
+	CaseSensitive bool `json:"case_sensitive,omitempty"`
+}
+
+// Taxonomy looks skills up by name or alias and finds them in free text
+type Taxonomy struct {
+	skills   []Skill
+	byKey    map[string]int // normalised name or alias -> index in skills
+	matchers [][]*regexp.Regexp
+}
+
+var defaultTaxonomy = mustLoad(skillsJSON)
+
+// Default returns the taxonomy bundled with the binary
+func Default() *Taxonomy {
+	return defaultTaxonomy
+}
+
+// Load parses a taxonomy file. Every name and alias must be unique, ignoring
+// case, and every category must be a known one.
+func Load(data []byte) (*Taxonomy, error) {
+	var skills []Skill
+	if err := json.Unmarshal(data, &skills); err != nil {
+		return nil, fmt.Errorf("failed to parse taxonomy: %w", err)
+	}
+
+	t := &Taxonomy{
+		skills:   skills,
+		byKey:    make(map[string]int, len(skills)*2),
+		matchers: make([][]*regexp.Regexp, len(skills)),
+	}
+	for i, skill := range skills {
+		if strings.TrimSpace(skill.Name) == "" {
+			return nil, fmt.Errorf("taxonomy entry %d has no name", i)
+		}
+		if !IsCategory(skill.Category) {
+			return nil, fmt.Errorf("skill %q has unknown category %q", skill.Name, skill.Category)
+		}
+
+		for _, term := range append([]string{skill.Name}, skill.Aliases...) {
+			key := Key(term)
+			if other, ok := t.byKey[key]; ok && other != i {
+				return nil, fmt.Errorf("%q is used by both %q and %q", term, skills[other].Name, skill.Name)
+			}
+			t.byKey[key] = i
+			t.matchers[i] = append(t.matchers[i], termPattern(term, skill.CaseSensitive))
+		}
+	}
+
+	return t, nil
+}
+
+func mustLoad(data []byte) *Taxonomy {
+	t, err := Load(data)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// IsCategory reports whether category is one of the skill categories
+func IsCategory(category string) bool {
+	switch category {
+	case CategoryTechnical, CategorySoft, CategoryLanguage:
+		return true
+	}
+	return false
+}
+
+// termPattern matches term as a whole word. Terms of two characters or fewer
+// are always case-sensitive so that "Go" doesn't match the verb "go", and a
+// dash or an ampersand joins them to their neighbours so that "Go-getter" and
+// "R&D" aren't read as skills. A single letter is also a common word or label,
+// as in "Series C", so it only counts in a list of skills: next to a comma,
+// slash, semicolon or bracket, at the start of a line or a list item, or
+// after "in" or "with".
+func termPattern(term string, caseSensitive bool) *regexp.Regexp {
+	quoted := regexp.QuoteMeta(term)
+	if len([]rune(term)) == 1 {
+		return regexp.MustCompile(`(?m)` +
+			`(?:^[ \t]*(?:[-*•][ \t]*)?|[,/;:(|][ \t]*|\b(?i:in|with)[ \t]+)` + quoted + `(?:$|[^\pL\pN+#/&-])` +
+			`|(?:^|[^\pL\pN+#./&-])` + quoted + `[ \t]*(?:[,/;)|]|$)`)
+	}
+	if len(term) <= 2 {
+		return regexp.MustCompile(`(^|[^\pL\pN+#./&-])` + quoted + `($|[^\pL\pN+#/&-])`)
+	}
+
+	flags := "(?i)"
+	if caseSensitive {
+		flags = ""
+	}
+	return regexp.MustCompile(flags + `(^|[^\pL\pN+#./])` + quoted + `($|[^\pL\pN+#/])`)
+}
+
+// Key normalises a skill name for comparison: lowercase, trimmed, with runs
+// of whitespace collapsed
+func Key(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// Lookup returns the skill whose name or alias is name, ignoring case
+func (t *Taxonomy) Lookup(name string) (Skill, bool) {
+	i, ok := t.byKey[Key(name)]
+	if !ok {
+		return Skill{}, false
+	}
+	return t.skills[i], true
+}
+
+// Canonical returns the canonical name for name, or name itself trimmed when
+// the taxonomy doesn't know it
+func (t *Taxonomy) Canonical(name string) string {
+	if skill, ok := t.Lookup(name); ok {
+		return skill.Name
+	}
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// Find returns the canonical names of all skills mentioned in text, in
+// taxonomy order
+func (t *Taxonomy) Find(text string) []string {
+	var found []string
+	for i, patterns := range t.matchers {
+		for _, pattern := range patterns {
+			if pattern.MatchString(text) {
+				found = append(found, t.skills[i].Name)
+				break
+			}
+		}
+	}
+	return found
+}
+
+// Suggestion is an autocomplete match for a partially typed skill
+type Suggestion struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Alias    string `json:"alias,omitempty"` // the alias that matched, if not the name
+}
+
+// Suggest returns up to limit skills whose name or an alias contains query,
+// ignoring case. Exact matches come first, then prefix matches on the name,
+// then prefix matches on an alias, then matches anywhere; ties are ordered by
+// name.
+func (t *Taxonomy) Suggest(query string, limit int) []Suggestion {
+	query = Key(query)
+	if query == "" || limit <= 0 {
+		return []Suggestion{}
+	}
+
+	type ranked struct {
+		suggestion Suggestion
+		rank       int
+	}
+	var matches []ranked
+	for _, skill := range t.skills {
+		best := ranked{rank: -1}
+		for i, term := range append([]string{skill.Name}, skill.Aliases...) {
+			key := Key(term)
+			rank := -1
+			switch {
+			case key == query:
+				rank = 0
+			case strings.HasPrefix(key, query) && i == 0:
+				rank = 1
+			case strings.HasPrefix(key, query):
+				rank = 2
+			case strings.Contains(key, query):
+				rank = 3
+			}
+			if rank < 0 || (best.rank >= 0 && rank >= best.rank) {
+				continue
+			}
+			best = ranked{suggestion: Suggestion{Name: skill.Name, Category: skill.Category}, rank: rank}
+			if i > 0 {
+				best.suggestion.Alias = term
+			}
+		}
+		if best.rank >= 0 {
+			matches = append(matches, best)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].rank != matches[j].rank {
+			return matches[i].rank < matches[j].rank
+		}
+		return matches[i].suggestion.Name < matches[j].suggestion.Name
+	})
+
+	suggestions := []Suggestion{}
+	for _, match := range matches {
+		if len(suggestions) == limit {
+			break
+		}
+		suggestions = append(suggestions, match.suggestion)
+	}
+	return suggestions
+}
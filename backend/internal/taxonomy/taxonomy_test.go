@@ -0,0 +1,112 @@
+package taxonomy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "names and aliases",
+			text: "Backend services in Golang on k8s, stored in Postgres",
+			want: []string{"Go", "PostgreSQL", "Kubernetes"},
+		},
+		{
+			name: "case-insensitive names",
+			text: "python and DOCKER",
+			want: []string{"Python", "Docker"},
+		},
+		{
+			name: "languages in a list",
+			text: "Languages: C, C++, R / Go",
+			want: []string{"Go", "C", "C++", "R"},
+		},
+		{
+			name: "single letters after in or with",
+			text: "Experience in C and proficiency with R",
+			want: []string{"C", "R"},
+		},
+		{
+			name: "single letter as a list item",
+			text: "Skills:\n- C\n- Python",
+			want: []string{"Python", "C"},
+		},
+		{name: "verb go", text: "Ready to go the extra mile", want: nil},
+		{name: "R&D", text: "Join our R&D team", want: nil},
+		{name: "Series C", text: "We just closed a Series C round", want: nil},
+		{name: "Go-getter", text: "We want a Go-getter", want: nil},
+		{name: "C-level", text: "Reporting to C-level executives", want: nil},
+		{name: "case-sensitive word", text: "Watch the rust on the swift river", want: nil},
+		{name: "part of another skill", text: "Objective-C and C++ only", want: []string{"C++", "Objective-C"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Default().Find(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Find(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []Suggestion
+	}{
+		{
+			name:  "exact alias first",
+			query: "k8s",
+			limit: 5,
+			want:  []Suggestion{{Name: "Kubernetes", Category: CategoryTechnical, Alias: "k8s"}},
+		},
+		{
+			name:  "name prefix before alias prefix",
+			query: "post",
+			limit: 5,
+			want:  []Suggestion{{Name: "PostgreSQL", Category: CategoryTechnical}},
+		},
+		{
+			name:  "alias prefix",
+			query: "golan",
+			limit: 5,
+			want:  []Suggestion{{Name: "Go", Category: CategoryTechnical, Alias: "Golang"}},
+		},
+		{
+			name:  "matches anywhere by name, up to the limit",
+			query: "script",
+			limit: 2,
+			want: []Suggestion{
+				{Name: "Bash", Category: CategoryTechnical, Alias: "shell scripting"},
+				{Name: "JavaScript", Category: CategoryTechnical},
+			},
+		},
+		{
+			name:  "empty query",
+			query: "  ",
+			limit: 5,
+			want:  []Suggestion{},
+		},
+		{
+			name:  "no match",
+			query: "cobolx",
+			limit: 5,
+			want:  []Suggestion{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Default().Suggest(tt.query, tt.limit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Suggest(%q, %d) = %+v, want %+v", tt.query, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
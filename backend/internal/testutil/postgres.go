@@ -0,0 +1,245 @@
+package testutil
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// ErrPostgresUnavailable is returned when neither TEST_DATABASE_URL nor local
+// PostgreSQL binaries are available
+var ErrPostgresUnavailable = errors.New("postgres unavailable: set TEST_DATABASE_URL or put initdb/pg_ctl on PATH (or PG_BIN)")
+
+// Postgres is a PostgreSQL server used by integration tests.
+//
+// Migrations are applied once to a template database and every test gets its
+// own fresh copy of that template, so tests never see each other's rows.
+type Postgres struct {
+	adminURL string
+	template string
+	dataDir  string
+	pgCtl    string
+
+	mu      sync.Mutex
+	counter int
+}
+
+// StartPostgres connects to the server named by TEST_DATABASE_URL or, when it
+// is not set, starts a throwaway local instance using initdb and pg_ctl.
+func StartPostgres() (*Postgres, error) {
+	p := &Postgres{}
+
+	if adminURL := os.Getenv("TEST_DATABASE_URL"); adminURL != "" {
+		p.adminURL = adminURL
+	} else if err := p.startLocal(); err != nil {
+		return nil, err
+	}
+
+	p.template = fmt.Sprintf("resume_builder_test_tmpl_%d", os.Getpid())
+	if err := p.createTemplate(); err != nil {
+		p.Close()
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// NewDatabase creates an empty, fully migrated database for a single test and
+// drops it when the test finishes
+func (p *Postgres) NewDatabase(t testing.TB) *sql.DB {
+	t.Helper()
+
+	p.mu.Lock()
+	p.counter++
+	name := fmt.Sprintf("%s_%d", p.template, p.counter)
+	p.mu.Unlock()
+
+	if err := p.adminExec(fmt.Sprintf(`CREATE DATABASE %s TEMPLATE %s`, name, p.template)); err != nil {
+		t.Fatalf("failed to create test database: %v", err)
+	}
+
+	db, err := sql.Open("postgres", p.databaseURL(name))
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+
+	t.Cleanup(func() {
+		db.Close()
+		if err := p.adminExec(fmt.Sprintf(`DROP DATABASE IF EXISTS %s`, name)); err != nil {
+			t.Logf("failed to drop test database %s: %v", name, err)
+		}
+	})
+
+	return db
+}
+
+// Close drops the template database and stops the local instance, if any
+func (p *Postgres) Close() error {
+	if p.template != "" && p.adminURL != "" {
+		_ = p.adminExec(fmt.Sprintf(`DROP DATABASE IF EXISTS %s`, p.template))
+	}
+
+	if p.dataDir == "" {
+		return nil
+	}
+
+	defer os.RemoveAll(p.dataDir)
+
+	out, err := exec.Command(p.pgCtl, "-D", p.dataDir, "-m", "immediate", "-w", "stop").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to stop postgres: %w: %s", err, out)
+	}
+
+	return nil
+}
+
+// startLocal initialises a data directory in a temp dir and starts a server
+// listening only on 127.0.0.1 with trust authentication
+func (p *Postgres) startLocal() error {
+	initdb, err := findPostgresBinary("initdb")
+	if err != nil {
+		return ErrPostgresUnavailable
+	}
+	pgCtl, err := findPostgresBinary("pg_ctl")
+	if err != nil {
+		return ErrPostgresUnavailable
+	}
+
+	dataDir, err := os.MkdirTemp("", "resume-builder-pg-")
+	if err != nil {
+		return fmt.Errorf("failed to create data dir: %w", err)
+	}
+
+	out, err := exec.Command(initdb, "-D", dataDir, "-U", "postgres", "--auth=trust", "--encoding=UTF8", "--no-sync").CombinedOutput()
+	if err != nil {
+		os.RemoveAll(dataDir)
+		return fmt.Errorf("initdb failed: %w: %s", err, out)
+	}
+
+	port, err := freePort()
+	if err != nil {
+		os.RemoveAll(dataDir)
+		return err
+	}
+
+	opts := fmt.Sprintf("-p %d -k %s -c listen_addresses=127.0.0.1 -c fsync=off", port, dataDir)
+	logFile := filepath.Join(dataDir, "postgres.log")
+	out, err = exec.Command(pgCtl, "-D", dataDir, "-o", opts, "-l", logFile, "-w", "start").CombinedOutput()
+	if err != nil {
+		os.RemoveAll(dataDir)
+		return fmt.Errorf("pg_ctl start failed: %w: %s", err, out)
+	}
+
+	p.dataDir = dataDir
+	p.pgCtl = pgCtl
+	p.adminURL = fmt.Sprintf("postgres://postgres@127.0.0.1:%d/postgres?sslmode=disable", port)
+
+	return nil
+}
+
+// createTemplate creates the template database and applies all migrations
+func (p *Postgres) createTemplate() error {
+	if err := p.adminExec(fmt.Sprintf(`DROP DATABASE IF EXISTS %s`, p.template)); err != nil {
+		return fmt.Errorf("failed to drop stale template: %w", err)
+	}
+	if err := p.adminExec(fmt.Sprintf(`CREATE DATABASE %s`, p.template)); err != nil {
+		return fmt.Errorf("failed to create template: %w", err)
+	}
+
+	db, err := sql.Open("postgres", p.databaseURL(p.template))
+	if err != nil {
+		return fmt.Errorf("failed to open template: %w", err)
+	}
+	defer db.Close()
+
+	if err := ApplyMigrations(db); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *Postgres) adminExec(query string) error {
+	db, err := sql.Open("postgres", p.adminURL)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// Template databases can't be copied while anyone is connected to them,
+	// so retry briefly while connections from a previous step wind down
+	for attempt := 0; ; attempt++ {
+		_, err = db.Exec(query)
+		if err == nil || attempt == 20 {
+			return err
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func (p *Postgres) databaseURL(name string) string {
+	u, err := url.Parse(p.adminURL)
+	if err != nil {
+		return p.adminURL
+	}
+	u.Path = "/" + name
+	return u.String()
+}
+
+// ApplyMigrations runs every migrations/*.sql file in lexical order
+func ApplyMigrations(db *sql.DB) error {
+	files, err := filepath.Glob(filepath.Join(MigrationsDir(), "*.sql"))
+	if err != nil {
+		return fmt.Errorf("failed to list migrations: %w", err)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		migration, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", file, err)
+		}
+		if _, err := db.Exec(string(migration)); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", filepath.Base(file), err)
+		}
+	}
+
+	return nil
+}
+
+// MigrationsDir returns the absolute path of backend/migrations
+func MigrationsDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "migrations")
+}
+
+func findPostgresBinary(name string) (string, error) {
+	if dir := os.Getenv("PG_BIN"); dir != "" {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return exec.LookPath(name)
+}
+
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find free port: %w", err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
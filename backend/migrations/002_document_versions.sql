@@ -0,0 +1,14 @@
+-- Document versions table
+-- Every update to a document first snapshots its previous title/content/status here
+CREATE TABLE document_versions (
+                                   id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                   document_id UUID REFERENCES documents(id) ON DELETE CASCADE,
+                                   version_number INT NOT NULL,
+                                   title VARCHAR(255) NOT NULL,
+                                   content JSONB NOT NULL,
+                                   status VARCHAR(50),
+                                   created_at TIMESTAMP DEFAULT NOW(),
+                                   UNIQUE (document_id, version_number)
+);
+
+CREATE INDEX idx_document_versions_document_id ON document_versions(document_id);
@@ -0,0 +1,12 @@
+-- Document messages table
+-- Chat thread used to refine a document ("make it more concise", ...)
+CREATE TABLE document_messages (
+                                   id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                   document_id UUID REFERENCES documents(id) ON DELETE CASCADE,
+                                   role VARCHAR(20) NOT NULL, -- user, assistant
+                                   content TEXT NOT NULL,
+                                   generation_id UUID REFERENCES generation_history(id) ON DELETE SET NULL,
+                                   created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_document_messages_document_id ON document_messages(document_id, created_at);
@@ -0,0 +1,2 @@
+-- Structured analysis of the job description a document was generated for
+ALTER TABLE documents ADD COLUMN job_analysis JSONB;
@@ -0,0 +1,14 @@
+-- Explicit display order and optional custom group for skills
+ALTER TABLE skills ADD COLUMN sort_order INT NOT NULL DEFAULT 0;
+ALTER TABLE skills ADD COLUMN group_name VARCHAR(100);
+
+-- Keep the order skills were shown in so far: by category, then name
+UPDATE skills
+SET sort_order = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY profile_id ORDER BY category, name) - 1 AS position
+    FROM skills
+) AS ordered
+WHERE skills.id = ordered.id;
+
+CREATE INDEX idx_skills_profile_sort_order ON skills(profile_id, sort_order);
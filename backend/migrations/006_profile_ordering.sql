@@ -0,0 +1,23 @@
+-- Explicit display order for experiences and education
+ALTER TABLE experiences ADD COLUMN sort_order INT NOT NULL DEFAULT 0;
+ALTER TABLE education ADD COLUMN sort_order INT NOT NULL DEFAULT 0;
+
+-- Keep the order entries were shown in so far: newest first
+UPDATE experiences
+SET sort_order = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY profile_id ORDER BY start_date DESC) - 1 AS position
+    FROM experiences
+) AS ordered
+WHERE experiences.id = ordered.id;
+
+UPDATE education
+SET sort_order = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY profile_id ORDER BY start_date DESC) - 1 AS position
+    FROM education
+) AS ordered
+WHERE education.id = ordered.id;
+
+CREATE INDEX idx_experiences_profile_sort_order ON experiences(profile_id, sort_order);
+CREATE INDEX idx_education_profile_sort_order ON education(profile_id, sort_order);
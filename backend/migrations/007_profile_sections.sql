@@ -0,0 +1,82 @@
+-- Optional profile sections
+
+-- Projects table
+CREATE TABLE projects (
+                          id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                          profile_id UUID REFERENCES profiles(id) ON DELETE CASCADE,
+                          name VARCHAR(255) NOT NULL,
+                          role VARCHAR(255),
+                          description TEXT,
+                          url VARCHAR(500),
+                          repository_url VARCHAR(500),
+                          tech_stack TEXT[],
+                          start_date DATE,
+                          end_date DATE,
+                          created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Certifications table
+CREATE TABLE certifications (
+                                id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                profile_id UUID REFERENCES profiles(id) ON DELETE CASCADE,
+                                name VARCHAR(255) NOT NULL,
+                                issuer VARCHAR(255) NOT NULL,
+                                issue_date DATE,
+                                expiry_date DATE,
+                                credential_id VARCHAR(255),
+                                credential_url VARCHAR(500),
+                                created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Publications table
+CREATE TABLE publications (
+                              id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                              profile_id UUID REFERENCES profiles(id) ON DELETE CASCADE,
+                              title VARCHAR(500) NOT NULL,
+                              publisher VARCHAR(255),
+                              published_date DATE,
+                              url VARCHAR(500),
+                              authors TEXT[],
+                              description TEXT,
+                              created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Spoken languages table
+CREATE TABLE languages (
+                           id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                           profile_id UUID REFERENCES profiles(id) ON DELETE CASCADE,
+                           name VARCHAR(100) NOT NULL,
+                           level VARCHAR(10) NOT NULL, -- CEFR A1-C2 or native
+                           created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Volunteering table
+CREATE TABLE volunteering (
+                              id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                              profile_id UUID REFERENCES profiles(id) ON DELETE CASCADE,
+                              organization VARCHAR(255) NOT NULL,
+                              role VARCHAR(255) NOT NULL,
+                              start_date DATE NOT NULL,
+                              end_date DATE,
+                              is_current BOOLEAN DEFAULT FALSE,
+                              description TEXT,
+                              created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Awards table
+CREATE TABLE awards (
+                        id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                        profile_id UUID REFERENCES profiles(id) ON DELETE CASCADE,
+                        title VARCHAR(255) NOT NULL,
+                        issuer VARCHAR(255),
+                        award_date DATE,
+                        description TEXT,
+                        created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_projects_profile_id ON projects(profile_id);
+CREATE INDEX idx_certifications_profile_id ON certifications(profile_id);
+CREATE INDEX idx_publications_profile_id ON publications(profile_id);
+CREATE INDEX idx_languages_profile_id ON languages(profile_id);
+CREATE INDEX idx_volunteering_profile_id ON volunteering(profile_id);
+CREATE INDEX idx_awards_profile_id ON awards(profile_id);
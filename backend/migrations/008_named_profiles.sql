@@ -0,0 +1,13 @@
+-- Multiple named profiles per user, exactly one of them the default
+ALTER TABLE profiles DROP CONSTRAINT profiles_user_id_key;
+ALTER TABLE profiles ADD COLUMN name VARCHAR(100) NOT NULL DEFAULT 'Default';
+ALTER TABLE profiles ADD COLUMN is_default BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Every existing profile is its user's only one
+UPDATE profiles SET is_default = TRUE;
+
+CREATE UNIQUE INDEX idx_profiles_user_default ON profiles(user_id) WHERE is_default;
+CREATE UNIQUE INDEX idx_profiles_user_name ON profiles(user_id, LOWER(name));
+
+-- The profile a document was generated from, so regeneration uses the same one
+ALTER TABLE documents ADD COLUMN profile_id UUID REFERENCES profiles(id) ON DELETE SET NULL;
@@ -0,0 +1,35 @@
+-- Job applications table
+-- Tracks where a user applied and with which resume and cover letter
+CREATE TABLE applications (
+                              id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                              user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+                              company_name VARCHAR(255) NOT NULL,
+                              job_title VARCHAR(255) NOT NULL,
+                              job_url VARCHAR(500),
+                              status VARCHAR(20) NOT NULL DEFAULT 'applied', -- applied, interview, offer, rejected
+                              applied_date DATE NOT NULL,
+                              notes TEXT,
+                              contacts JSONB,
+                              resume_document_id UUID REFERENCES documents(id) ON DELETE SET NULL,
+                              cover_letter_document_id UUID REFERENCES documents(id) ON DELETE SET NULL,
+                              status_changed_at TIMESTAMP DEFAULT NOW(),
+                              created_at TIMESTAMP DEFAULT NOW(),
+                              updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Application status history table
+-- One row per status an application has been in, the first with no from_status
+CREATE TABLE application_status_history (
+                                            id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                            application_id UUID REFERENCES applications(id) ON DELETE CASCADE,
+                                            from_status VARCHAR(20),
+                                            to_status VARCHAR(20) NOT NULL,
+                                            note TEXT,
+                                            changed_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_applications_user_id ON applications(user_id, applied_date DESC);
+CREATE INDEX idx_application_status_history_application_id ON application_status_history(application_id, changed_at);
+
+CREATE TRIGGER update_applications_updated_at BEFORE UPDATE ON applications
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
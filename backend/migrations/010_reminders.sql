@@ -0,0 +1,30 @@
+-- Follow-up rules table
+-- Overrides the default follow-up delay for an application in a given status;
+-- after_days = 0 turns follow-ups off for that status
+CREATE TABLE follow_up_rules (
+                                 id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                 application_id UUID REFERENCES applications(id) ON DELETE CASCADE,
+                                 status VARCHAR(20) NOT NULL,
+                                 after_days INT NOT NULL,
+                                 created_at TIMESTAMP DEFAULT NOW(),
+                                 UNIQUE (application_id, status)
+);
+
+-- Reminders table
+-- At most one reminder per application per stay in a status (status_since)
+CREATE TABLE reminders (
+                           id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                           user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+                           application_id UUID REFERENCES applications(id) ON DELETE CASCADE,
+                           status VARCHAR(20) NOT NULL,
+                           status_since TIMESTAMP NOT NULL,
+                           message TEXT NOT NULL,
+                           due_at TIMESTAMP NOT NULL,
+                           delivered_at TIMESTAMP,
+                           dismissed_at TIMESTAMP,
+                           created_at TIMESTAMP DEFAULT NOW(),
+                           UNIQUE (application_id, status_since)
+);
+
+CREATE INDEX idx_reminders_user_id ON reminders(user_id, due_at DESC);
+CREATE INDEX idx_reminders_undelivered ON reminders(created_at) WHERE delivered_at IS NULL;
@@ -0,0 +1,27 @@
+-- Job postings table
+-- A user's saved job descriptions; content_hash is the SHA-256 of the
+-- normalised description, so pasting the same posting again finds the saved one
+CREATE TABLE job_postings (
+                              id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                              user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+                              title VARCHAR(255) NOT NULL,
+                              company_name VARCHAR(255),
+                              description TEXT NOT NULL,
+                              source_url VARCHAR(500),
+                              location VARCHAR(255),
+                              salary_min INT,
+                              salary_max INT,
+                              salary_currency VARCHAR(3),
+                              content_hash CHAR(64) NOT NULL,
+                              created_at TIMESTAMP DEFAULT NOW(),
+                              updated_at TIMESTAMP DEFAULT NOW(),
+                              UNIQUE (user_id, content_hash)
+);
+
+CREATE INDEX idx_job_postings_user_id ON job_postings(user_id, created_at DESC);
+
+CREATE TRIGGER update_job_postings_updated_at BEFORE UPDATE ON job_postings
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Documents remember the saved posting they were generated for
+ALTER TABLE documents ADD COLUMN job_posting_id UUID REFERENCES job_postings(id) ON DELETE SET NULL;
@@ -0,0 +1,3 @@
+-- Record which prompt file and version produced each generation, e.g.
+-- resume@1; rows from before prompts were versioned stay NULL
+ALTER TABLE generation_history ADD COLUMN prompt_version VARCHAR(100);
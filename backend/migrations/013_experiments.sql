@@ -0,0 +1,18 @@
+-- Record the A/B experiment variant each generation ran with
+ALTER TABLE generation_history ADD COLUMN experiment VARCHAR(100);
+ALTER TABLE generation_history ADD COLUMN variant VARCHAR(100);
+
+CREATE INDEX idx_generation_history_experiment ON generation_history(experiment, variant) WHERE experiment IS NOT NULL;
+
+-- Document signals table
+-- How users received generated documents: edited, regenerated, downloaded,
+-- thumbs_up or thumbs_down. Experiment reports join them to generation_history.
+CREATE TABLE document_signals (
+                                  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                  document_id UUID REFERENCES documents(id) ON DELETE CASCADE,
+                                  user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+                                  signal VARCHAR(20) NOT NULL,
+                                  created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_document_signals_document_id ON document_signals(document_id, signal);
@@ -0,0 +1,25 @@
+-- Record the model of each generation, so feedback can be compared by model
+ALTER TABLE generation_history ADD COLUMN model VARCHAR(100);
+
+-- Generation feedback table
+-- A user's thumbs up or down on a document, or on one of its sections, with
+-- reason codes and a comment. generation_id is the document's latest
+-- generation when the feedback was given; section is '' for the whole document.
+CREATE TABLE generation_feedback (
+                                     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                     user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+                                     document_id UUID REFERENCES documents(id) ON DELETE CASCADE,
+                                     generation_id UUID REFERENCES generation_history(id) ON DELETE SET NULL,
+                                     section VARCHAR(100) NOT NULL DEFAULT '',
+                                     rating VARCHAR(10) NOT NULL,
+                                     reasons TEXT[] NOT NULL DEFAULT '{}',
+                                     comment TEXT,
+                                     created_at TIMESTAMP DEFAULT NOW(),
+                                     updated_at TIMESTAMP DEFAULT NOW(),
+                                     UNIQUE (document_id, section)
+);
+
+CREATE INDEX idx_generation_feedback_generation_id ON generation_feedback(generation_id);
+
+CREATE TRIGGER update_generation_feedback_updated_at BEFORE UPDATE ON generation_feedback
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
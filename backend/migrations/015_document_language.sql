@@ -0,0 +1,2 @@
+-- Language tag a document was generated in, e.g. ru or de-AT; empty for English
+ALTER TABLE documents ADD COLUMN language VARCHAR(20) NOT NULL DEFAULT '';
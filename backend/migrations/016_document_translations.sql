@@ -0,0 +1,4 @@
+-- Translated documents link to the document they were translated from
+ALTER TABLE documents ADD COLUMN source_id UUID REFERENCES documents(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_documents_source_id ON documents(source_id);
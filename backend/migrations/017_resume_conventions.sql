@@ -0,0 +1,7 @@
+-- Personal details some regional resume conventions show
+ALTER TABLE profiles ADD COLUMN date_of_birth DATE;
+ALTER TABLE profiles ADD COLUMN nationality VARCHAR(100);
+ALTER TABLE profiles ADD COLUMN photo_url TEXT;
+
+-- Regional convention a document follows; earlier documents are US résumés
+ALTER TABLE documents ADD COLUMN convention VARCHAR(20) NOT NULL DEFAULT 'us';
@@ -0,0 +1,15 @@
+-- LLM response cache
+-- Model responses by a hash of the request (model, prompt version,
+-- parameters and rendered prompt), so identical generations within the TTL
+-- are answered without calling the model. Expired rows are deleted on write.
+CREATE TABLE llm_cache (
+                           key VARCHAR(64) PRIMARY KEY,
+                           response BYTEA NOT NULL,
+                           created_at TIMESTAMP DEFAULT NOW(),
+                           expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_llm_cache_expires_at ON llm_cache(expires_at);
+
+-- Mark generations answered from the cache, which cost nothing
+ALTER TABLE generation_history ADD COLUMN cached BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,10 @@
+-- Record the provider of each generation and the prompt tokens it served
+-- from its prompt cache, so costs come from the pricing table of the model
+ALTER TABLE generation_history ADD COLUMN provider VARCHAR(50);
+ALTER TABLE generation_history ADD COLUMN cached_tokens INTEGER NOT NULL DEFAULT 0;
+
+-- Every generation so far went to OpenAI. Rows from before models were
+-- recorded (migration 014) were costed at gpt-4o-mini prices, so that is the
+-- model their total_cost reflects.
+UPDATE generation_history SET provider = 'openai' WHERE provider IS NULL;
+UPDATE generation_history SET model = 'gpt-4o-mini' WHERE model IS NULL;
@@ -0,0 +1,9 @@
+-- Reminder deliveries table
+-- One row per channel a reminder went out on, so a retry after a failed
+-- channel doesn't send the reminder again through the ones that worked
+CREATE TABLE reminder_deliveries (
+                                     reminder_id UUID REFERENCES reminders(id) ON DELETE CASCADE,
+                                     channel VARCHAR(20) NOT NULL,
+                                     delivered_at TIMESTAMP DEFAULT NOW(),
+                                     PRIMARY KEY (reminder_id, channel)
+);
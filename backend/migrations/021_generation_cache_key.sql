@@ -0,0 +1,6 @@
+-- Record the response cache key of each generation, so a request answered
+-- from the cache can hand back the document the first request saved instead
+-- of saving a copy
+ALTER TABLE generation_history ADD COLUMN cache_key VARCHAR(64);
+
+CREATE INDEX idx_generation_history_cache_key ON generation_history(user_id, cache_key) WHERE cache_key IS NOT NULL;
@@ -0,0 +1,4 @@
+-- Count failed deliveries of each reminder and when to try next, so a
+-- channel that keeps failing backs off and is given up on after a while
+ALTER TABLE reminders ADD COLUMN delivery_attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE reminders ADD COLUMN next_attempt_at TIMESTAMP;
@@ -0,0 +1,3 @@
+-- Section regenerations rewrite a single section and have their own free
+-- allowance instead of using up a whole free generation
+ALTER TABLE users ADD COLUMN free_regenerations_left INT NOT NULL DEFAULT 10;